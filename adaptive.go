@@ -0,0 +1,75 @@
+package decimals
+
+import (
+	"math"
+)
+
+// A Breakpoint sets the precision used by FormatAdaptive for numbers whose
+// magnitude is at least Min.
+type Breakpoint struct {
+	Min       float64
+	Precision int
+}
+
+// DefaultBreakpoints are the breakpoints used by FormatAdaptive: whole
+// numbers from one thousand, and two decimal places from one.
+var DefaultBreakpoints = []Breakpoint{
+	{1000, 0},
+	{1, 2},
+}
+
+// DefaultSignificantDigits is the number of significant digits used by
+// FormatAdaptive for numbers below the smallest breakpoint.
+const DefaultSignificantDigits = 3
+
+// FormatAdaptive formats a float64 with a precision chosen by its
+// magnitude, using DefaultBreakpoints and DefaultSignificantDigits, so
+// that a set of values with mixed scales are each shown usefully. For
+// example 12345.6 is formatted as "12,346", 12.345 as "12.35" and
+// 0.000123 as "0.000123".
+func FormatAdaptive(x float64) string {
+
+	return FormatAdaptiveWith(x, DefaultBreakpoints, DefaultSignificantDigits)
+}
+
+// FormatAdaptiveWith formats a float64 with a precision chosen by its
+// magnitude. The breakpoints must be in descending order of Min, and the
+// precision of the first breakpoint not greater than the magnitude of x is
+// used. Numbers smaller than every breakpoint are rounded to the given
+// number of significant digits.
+func FormatAdaptiveWith(x float64, breakpoints []Breakpoint, significant int) string {
+
+	return FormatFloat(x, adaptivePrecision(x, breakpoints, significant))
+}
+
+// adaptivePrecision returns the precision for x given the breakpoints and
+// the number of significant digits for numbers below them.
+func adaptivePrecision(x float64, breakpoints []Breakpoint, significant int) int {
+
+	for _, b := range breakpoints {
+
+		if math.Abs(x) >= b.Min {
+
+			return b.Precision
+		}
+	}
+
+	return significantPrecision(x, significant)
+}
+
+// significantPrecision returns the precision at which x is rounded to the
+// given number of significant digits.
+func significantPrecision(x float64, significant int) int {
+
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) {
+
+		return significant - 1
+	}
+
+	// Find the position of the leading digit from the decimal digits, so
+	// that powers of ten are not misplaced by an inexact logarithm
+	_, digits, exponent := FloatToDecimal(x)
+	leading := exponent + len(digits) - 1
+
+	return significant - 1 - leading
+}
@@ -0,0 +1,57 @@
+package decimals
+
+import (
+	"testing"
+)
+
+// Test FormatAdaptive with a range of magnitudes
+func TestFormatAdaptive(t *testing.T) {
+
+	inputs := []float64{12345.6, -12345.6, 1000, 999.999, 12.345, 1, 0.5, 0.000123, 0.00012345, 0.0456, 0}
+
+	expected := []string{
+		"12,346",
+		"-12,346",
+		"1,000",
+		"1,000.00",
+		"12.35",
+		"1.00",
+		"0.500",
+		"0.000123",
+		"0.000123",
+		"0.0456",
+		"0.00",
+	}
+
+	for i, n := range inputs {
+
+		output := FormatAdaptive(n)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatAdaptive(%v)",
+				expected[i], output, n)
+		}
+	}
+}
+
+// Test FormatAdaptiveWith with custom breakpoints
+func TestFormatAdaptiveWith(t *testing.T) {
+
+	breakpoints := []Breakpoint{{1e6, -3}, {100, 0}, {0.1, 1}}
+
+	inputs := []float64{1234567, 1234.5, 12.34, 0.01234}
+
+	expected := []string{"1,235,000", "1,235", "12.3", "0.01"}
+
+	for i, n := range inputs {
+
+		output := FormatAdaptiveWith(n, breakpoints, 1)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatAdaptiveWith(%v)",
+				expected[i], output, n)
+		}
+	}
+}
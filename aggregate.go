@@ -0,0 +1,226 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// ErrEmpty indicates that a statistic was requested of no values.
+var ErrEmpty = errors.New("decimals: no values")
+
+// Sum returns the exact sum of ds, with as many decimal places as the
+// value with the most, so that the sum of prices in cents is in cents. The
+// sum of no values is zero.
+func Sum(ds []Decimal) Decimal {
+
+	if len(ds) == 0 {
+
+		return Decimal{}
+	}
+
+	// Add the coefficients at the smallest exponent
+	exp := ds[0].exponent
+
+	for _, d := range ds[1:] {
+
+		if d.exponent < exp {
+
+			exp = d.exponent
+		}
+	}
+
+	var (
+		sum = new(big.Int)
+		c   = new(big.Int)
+	)
+
+	for _, d := range ds {
+
+		c.Mul(d.coefficient(), pow10Big(d.exponent-exp))
+		sum.Add(sum, c)
+	}
+
+	return bigDecimal(sum, exp)
+}
+
+// Mean returns the arithmetic mean of ds rounded to the given scale, the
+// number of decimal places, using the given rounding mode. The sum is
+// exact and is divided once, so the result is correctly rounded. It
+// returns ErrEmpty if ds is empty.
+func Mean(ds []Decimal, scale int, mode RoundingMode) (Decimal, error) {
+
+	if len(ds) == 0 {
+
+		return Decimal{}, ErrEmpty
+	}
+
+	return Sum(ds).Div(DecimalFromInt(int64(len(ds))), scale, mode)
+}
+
+// Median returns the median of ds rounded to the given scale using the
+// given rounding mode. For an even number of values it is the midpoint of
+// the middle two, computed exactly before it is rounded, so the mode
+// decides ties such as the median of 1.00 and 1.01 at two places. ds is
+// not modified. It returns ErrEmpty if ds is empty.
+func Median(ds []Decimal, scale int, mode RoundingMode) (Decimal, error) {
+
+	if len(ds) == 0 {
+
+		return Decimal{}, ErrEmpty
+	}
+
+	sorted := sortedDecimals(ds)
+	n := len(sorted)
+
+	// Dividing by one rounds to exactly scale places, as Div does
+	if n%2 == 1 {
+
+		return sorted[n/2].Div(DecimalFromInt(1), scale, mode)
+	}
+
+	return Sum(sorted[n/2-1:n/2+1]).Div(DecimalFromInt(2), scale, mode)
+}
+
+// TrimmedMean returns the mean of ds after discarding the given percentage
+// of the values from each end, rounded to the given scale using the given
+// rounding mode as Mean does. The trim is a percentage, not a fraction: a
+// trim of 10 drops the lowest and highest tenth, rounded down to whole
+// values, which makes the mean robust to outliers such as stalled requests
+// in latency reports. At least one value is always kept, and a trim of 50
+// gives the median. ds is not modified. It returns an error wrapping
+// ErrRange if trimPct is not 0 or from 1 to 50, since a trim such as 0.25
+// is more likely a fraction than a quarter of a percent, and ErrEmpty if
+// ds is empty.
+func TrimmedMean(ds []Decimal, trimPct float64, scale int, mode RoundingMode) (Decimal, error) {
+
+	k, err := trimCount(len(ds), trimPct)
+
+	if err != nil {
+
+		return Decimal{}, err
+	}
+
+	if len(ds) == 0 {
+
+		return Decimal{}, ErrEmpty
+	}
+
+	sorted := sortedDecimals(ds)
+
+	return Mean(sorted[k:len(sorted)-k], scale, mode)
+}
+
+// TrimmedMeanFormatted formats the trimmed mean of values using the default
+// formatter. See Formatter.TrimmedMeanFormatted.
+func TrimmedMeanFormatted(values []float64, trimPct float64, precision int) string {
+
+	return DefaultFormatter().TrimmedMeanFormatted(values, trimPct, precision)
+}
+
+// TrimmedMeanFormatted formats the mean of values after discarding the
+// given percentage from each end, as TrimmedMean does, rounded half up to
+// the given precision as by FormatFloat. The mean of the values kept is
+// computed exactly from their shortest decimals and rounded once, or with
+// floats if any of them is NaN or infinite. No values are formatted as NaN
+// is. It panics if trimPct is not 0 or from 1 to 50, so trims read from
+// configuration should be checked with TrimmedMean.
+func (f Formatter) TrimmedMeanFormatted(values []float64, trimPct float64, precision int) string {
+
+	k, err := trimCount(len(values), trimPct)
+
+	if err != nil {
+
+		panic("decimals: trimmed mean with trim percentage not 0 or from 1 to 50")
+	}
+
+	if len(values) == 0 {
+
+		return f.FormatFloat(math.NaN(), precision)
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	kept := sorted[k : len(sorted)-k]
+	ds := make([]Decimal, len(kept))
+
+	for i, x := range kept {
+
+		d, err := DecimalFromFloat(x)
+
+		if err != nil {
+
+			return f.FormatFloat(floatMean(kept), precision)
+		}
+
+		ds[i] = d
+	}
+
+	// Truncating the mean keeps its leading digit, which decides how many
+	// places the formatter shows, so the exact mean is rounded only once
+	var (
+		lf     = f.floatLimit()
+		sum    = Sum(ds)
+		n      = DecimalFromInt(int64(len(ds)))
+		t, _   = sum.Div(n, precision, Down)
+		places = lf.significantPrecision(t, precision)
+		r, _   = sum.Div(n, places, HalfUp)
+	)
+
+	// Drop the last place if rounding carried into a new leading digit, as
+	// roundSignificant does
+	if p := lf.significantPrecision(r, places); p < places {
+
+		r, places = r.Round(p, HalfUp), p
+	}
+
+	return f.applyTemplate(f.formatDecimal(r, places))
+}
+
+// trimCount returns the number of values to discard from each end of n
+// values for the trim percentage, keeping at least one. It returns an
+// error wrapping ErrRange if trimPct is not 0 or from 1 to 50.
+func trimCount(n int, trimPct float64) (int, error) {
+
+	if !(trimPct == 0 || trimPct >= 1 && trimPct <= 50) {
+
+		return 0, &NumError{"TrimmedMean", strconv.FormatFloat(trimPct, 'g', -1, 64), ErrRange}
+	}
+
+	k := int(float64(n) * trimPct / 100)
+
+	if 2*k >= n {
+
+		k = (n - 1) / 2
+	}
+
+	return k, nil
+}
+
+// floatMean returns the arithmetic mean of xs computed with floats.
+func floatMean(xs []float64) float64 {
+
+	var sum float64
+
+	for _, x := range xs {
+
+		sum += x
+	}
+
+	return sum / float64(len(xs))
+}
+
+// sortedDecimals returns a copy of ds in ascending order.
+func sortedDecimals(ds []Decimal) []Decimal {
+
+	sorted := append([]Decimal(nil), ds...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+
+	return sorted
+}
@@ -0,0 +1,223 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// parseDecimals parses each string as a Decimal
+func parseDecimals(ss ...string) []Decimal {
+
+	ds := make([]Decimal, len(ss))
+
+	for i, s := range ss {
+
+		ds[i] = MustParseDecimal(s)
+	}
+
+	return ds
+}
+
+// Test Sum adds exactly and keeps the most decimal places
+func TestSum(t *testing.T) {
+
+	inputs := [][]Decimal{
+		nil,
+		parseDecimals("0.1", "0.2"),
+		parseDecimals("1.50", "1.50"),
+		parseDecimals("12345678901234567890.01", "-0.01", "1e3"),
+		parseDecimals("5", "-5.000"),
+		parseDecimals("-2.5", "1"),
+	}
+
+	expected := []string{
+		"0",
+		"0.3",
+		"3.00",
+		"12345678901234568890.00",
+		"0.000",
+		"-1.5",
+	}
+
+	for i, input := range inputs {
+
+		if output := Sum(input).String(); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Sum(%v)", expected[i], output, input)
+		}
+	}
+}
+
+// Test Mean rounds the exact mean once
+func TestMean(t *testing.T) {
+
+	var (
+		inputs = [][]Decimal{
+			parseDecimals("10.00", "20.00", "30.01"),
+			parseDecimals("1", "2"),
+			parseDecimals("1", "2"),
+			parseDecimals("0.1", "0.2", "0.3"),
+		}
+		modes    = []RoundingMode{HalfUp, HalfEven, HalfUp, HalfUp}
+		expected = []string{"20.00", "2", "2", "0.20"}
+		scales   = []int{2, 0, 0, 2}
+	)
+
+	for i, input := range inputs {
+
+		output, err := Mean(input, scales[i], modes[i])
+
+		if err != nil || output.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing Mean(%v)", expected[i], output.String(), err, input)
+		}
+	}
+
+	if _, err := Mean(nil, 2, HalfUp); err != ErrEmpty {
+
+		t.Errorf("Expected: %v but received: %v testing Mean(nil)", ErrEmpty, err)
+	}
+}
+
+// Test Median sorts a copy and rounds the midpoint with the mode
+func TestMedian(t *testing.T) {
+
+	var (
+		inputs = [][]Decimal{
+			parseDecimals("3", "1", "2"),
+			parseDecimals("1.00", "1.01"),
+			parseDecimals("1.00", "1.01"),
+			parseDecimals("1.00", "1.01"),
+			parseDecimals("-5", "10", "2", "4"),
+			parseDecimals("7.125"),
+		}
+		modes    = []RoundingMode{HalfUp, HalfUp, HalfEven, Down, HalfUp, HalfEven}
+		scales   = []int{1, 2, 2, 3, 0, 2}
+		expected = []string{"2.0", "1.01", "1.00", "1.005", "3", "7.12"}
+	)
+
+	for i, input := range inputs {
+
+		output, err := Median(input, scales[i], modes[i])
+
+		if err != nil || output.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing Median(%v)", expected[i], output.String(), err, input)
+		}
+	}
+
+	input := parseDecimals("3", "1", "2")
+	Median(input, 0, HalfUp)
+
+	if input[0].String() != "3" {
+
+		t.Errorf("Expected: %q but received: %q testing Median leaves its input unsorted", "3", input[0].String())
+	}
+
+	if _, err := Median(nil, 2, HalfUp); err != ErrEmpty {
+
+		t.Errorf("Expected: %v but received: %v testing Median(nil)", ErrEmpty, err)
+	}
+}
+
+// Test TrimmedMean discards values from each end before taking the mean
+func TestTrimmedMean(t *testing.T) {
+
+	var (
+		latencies = parseDecimals("12", "15", "11", "14", "13", "950", "12", "16", "13", "1")
+		inputs    = [][]Decimal{latencies, latencies, latencies, latencies, parseDecimals("1", "2", "3", "10")}
+		trims     = []float64{0, 10, 19.9, 50, 50}
+		scales    = []int{1, 2, 2, 1, 1}
+		expected  = []string{"105.7", "13.25", "13.25", "13.0", "2.5"}
+	)
+
+	for i, input := range inputs {
+
+		output, err := TrimmedMean(input, trims[i], scales[i], HalfUp)
+
+		if err != nil || output.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing TrimmedMean(%v, %v)",
+				expected[i], output.String(), err, input, trims[i])
+		}
+	}
+
+	if latencies[0].String() != "12" {
+
+		t.Errorf("Expected: %q but received: %q testing TrimmedMean leaves its input unsorted", "12", latencies[0].String())
+	}
+
+	if _, err := TrimmedMean(nil, 10, 2, HalfUp); err != ErrEmpty {
+
+		t.Errorf("Expected: %v but received: %v testing TrimmedMean(nil)", ErrEmpty, err)
+	}
+}
+
+// Test TrimmedMeanFormatted with a range of values
+func TestTrimmedMeanFormatted(t *testing.T) {
+
+	latencies := []float64{12, 15, 11, 14, 13, 9500.5, 12, 16, 13, 1}
+
+	inputs := []string{
+		TrimmedMeanFormatted(latencies, 0, 2),
+		TrimmedMeanFormatted(latencies, 10, 2),
+		TrimmedMeanFormatted([]float64{0.1, 0.2, 0.3}, 0, 17),
+		TrimmedMeanFormatted([]float64{math.Inf(1), 1, 2, 3}, 25, 1),
+		TrimmedMeanFormatted([]float64{math.Inf(1), 1, 2}, 0, 1),
+		TrimmedMeanFormatted(nil, 10, 2),
+	}
+
+	expected := []string{
+		"960.75",
+		"13.25",
+		"0.20000000000000000",
+		"2.5",
+		"Inf",
+		"NaN",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing TrimmedMeanFormatted", expected[i], output)
+		}
+	}
+
+	// The exact mean is rounded once to the significant digits shown
+	f := Formatter{DecimalSeparator: ".", MaxSignificantDigits: 2}
+
+	if output := f.TrimmedMeanFormatted([]float64{1.2496}, 0, 3); output != f.FormatFloat(1.2496, 3) {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.TrimmedMeanFormatted", f.FormatFloat(1.2496, 3), output)
+	}
+
+	if output := f.TrimmedMeanFormatted([]float64{9.96, 9.97}, 0, 2); output != "10" {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.TrimmedMeanFormatted", "10", output)
+	}
+}
+
+// Test TrimmedMean returns ErrRange for a trim that is not 0 or from 1 to
+// 50, and TrimmedMeanFormatted panics
+func TestTrimmedMeanRange(t *testing.T) {
+
+	for _, trim := range []float64{60, -1, 0.25, math.NaN()} {
+
+		if _, err := TrimmedMean(parseDecimals("1", "2", "3", "100"), trim, 2, HalfUp); !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: ErrRange but received: %v testing TrimmedMean(%v)", err, trim)
+		}
+	}
+
+	defer func() {
+
+		if recover() == nil {
+
+			t.Errorf("Expected: a panic but received: none testing TrimmedMeanFormatted")
+		}
+	}()
+
+	TrimmedMeanFormatted([]float64{1}, 60, 0)
+}
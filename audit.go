@@ -0,0 +1,197 @@
+package decimals
+
+import (
+	"math"
+	"strings"
+)
+
+// A RoundingAudit describes how a float64 was rounded, so that figures
+// whose rounding depends on binary representation error can be reviewed.
+type RoundingAudit struct {
+	Value     float64      // the float that was rounded
+	Precision int          // the precision it was rounded to
+	Mode      RoundingMode // the rounding mode
+	Result    float64      // the result of RoundFloatMode
+	Exact     float64      // the result of RoundFloatExact
+
+	// Tie reports whether the shortest decimal representation of the
+	// value lies exactly halfway between two results, so the mode decided
+	// the direction.
+	Tie bool
+
+	// Ambiguous reports whether rounding the exact binary value of the
+	// float gives a different result from rounding its shortest decimal
+	// representation. The result then depends on whether the float is
+	// read as the decimal that was written or the binary value stored,
+	// as with 2.675, which is stored as a float slightly less than 2.675.
+	Ambiguous bool
+}
+
+// AuditRound rounds x as RoundFloatMode does and reports whether the
+// rounding was a tie and whether the float's binary representation made
+// the direction ambiguous. Finance teams can use it to flag figures that
+// are sensitive to representation error, for example by logging every
+// audit that is Ambiguous.
+func AuditRound(x float64, precision int, mode RoundingMode) RoundingAudit {
+
+	a := RoundingAudit{
+		Value:     x,
+		Precision: precision,
+		Mode:      mode,
+		Result:    RoundFloatMode(x, precision, mode),
+		Exact:     RoundFloatExact(x, precision, mode),
+	}
+
+	// A tie is rounded differently toward and away from zero by the
+	// nearest modes
+	if d, err := DecimalFromFloat(x); err == nil {
+
+		a.Tie = d.Round(precision, HalfUp).Cmp(d.Round(precision, HalfDown)) != 0
+	}
+
+	a.Ambiguous = a.Result != a.Exact && !math.IsNaN(x)
+
+	return a
+}
+
+// A BiasReport shows the aggregate bias of rounding a set of values under
+// each rounding mode, so that the choice of a mode such as HalfEven can be
+// demonstrated to auditors.
+type BiasReport struct {
+	Precision int        // the precision the values were rounded to
+	Count     int        // the number of finite values
+	Ties      int        // the number of values that were ties
+	Modes     []ModeBias // the bias of each mode, in the order of the modes
+}
+
+// A ModeBias is the aggregate bias of one rounding mode.
+type ModeBias struct {
+	Mode RoundingMode
+
+	// Bias is the exact sum of the differences between each rounded value
+	// and its shortest decimal representation, so a positive bias means
+	// the rounded values total more than the originals.
+	Bias Decimal
+
+	// MeanBias is the bias divided by the number of values, or zero if
+	// there are none.
+	MeanBias float64
+}
+
+// RoundingBias rounds each value to the given precision under every
+// rounding mode and reports the aggregate bias of each. Values are rounded
+// by their shortest decimal representations, as by RoundFloatMode, and the
+// bias is summed exactly. NaN and the infinities are skipped. Values are
+// rounded to no larger a power of ten than 10^MaxDecimalExponent, so that
+// the bias of modes that round up remains computable.
+func RoundingBias(xs []float64, precision int) BiasReport {
+
+	var (
+		r      = BiasReport{Precision: precision}
+		values []Decimal
+	)
+
+	if precision < -MaxDecimalExponent {
+
+		precision = -MaxDecimalExponent
+	}
+
+	for _, x := range xs {
+
+		if d, err := DecimalFromFloat(x); err == nil {
+
+			values = append(values, d)
+		}
+	}
+
+	r.Count = len(values)
+
+	for _, d := range values {
+
+		if d.Round(precision, HalfUp).Cmp(d.Round(precision, HalfDown)) != 0 {
+
+			r.Ties++
+		}
+	}
+
+	// Subtract the sum of the values from the sum of the rounded values
+	total := Sum(values).Neg()
+
+	for m := range roundingModeNames {
+
+		var (
+			mode    = RoundingMode(m)
+			rounded = []Decimal{total}
+			mb      = ModeBias{Mode: mode}
+		)
+
+		for _, d := range values {
+
+			rounded = append(rounded, d.Round(precision, mode))
+		}
+
+		mb.Bias = Sum(rounded)
+
+		if r.Count > 0 {
+
+			mb.MeanBias = mb.Bias.Float64() / float64(r.Count)
+		}
+
+		r.Modes = append(r.Modes, mb)
+	}
+
+	return r
+}
+
+// String formats the report as a table with a row for each mode, showing
+// the bias and the mean bias, for inclusion in audit documents.
+func (r BiasReport) String() string {
+
+	var (
+		b    strings.Builder
+		rows = [][]string{{"mode", "bias", "mean bias"}}
+	)
+
+	for _, mb := range r.Modes {
+
+		rows = append(rows, []string{mb.Mode.String(), mb.Bias.String(), FormatFloat(mb.MeanBias, 6)})
+	}
+
+	b.WriteString(FormatThousands(int64(r.Count)) + " values rounded to precision " + FormatThousands(int64(r.Precision)))
+	b.WriteString(", " + FormatThousands(int64(r.Ties)) + " ties\n")
+
+	// Left align the modes and right align the numbers
+	widths := make([]int, len(rows[0]))
+
+	for _, row := range rows {
+
+		for i, cell := range row {
+
+			if w := DisplayWidth(cell); w > widths[i] {
+
+				widths[i] = w
+			}
+		}
+	}
+
+	for _, row := range rows {
+
+		for i, cell := range row {
+
+			pad := strings.Repeat(" ", widths[i]-DisplayWidth(cell))
+
+			if i == 0 {
+
+				b.WriteString(cell + pad)
+
+			} else {
+
+				b.WriteString("  " + pad + cell)
+			}
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
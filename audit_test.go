@@ -0,0 +1,94 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test AuditRound flags ties and representation sensitive values
+func TestAuditRound(t *testing.T) {
+
+	inputs := []float64{2.675, 2.665, 1.005, 0.125, 2.674, 2.5, math.NaN()}
+
+	precisions := []int{2, 2, 2, 2, 2, 0, 2}
+
+	ties := []bool{true, true, true, true, false, true, false}
+
+	ambiguous := []bool{true, false, true, false, false, false, false}
+
+	for i, n := range inputs {
+
+		a := AuditRound(n, precisions[i], HalfUp)
+
+		if a.Tie != ties[i] || a.Ambiguous != ambiguous[i] {
+
+			t.Errorf("Expected: tie %v and ambiguous %v but received: %+v testing AuditRound(%v, %d)",
+				ties[i], ambiguous[i], a, n, precisions[i])
+		}
+
+		if a.Result != RoundFloat(n, precisions[i]) && !math.IsNaN(n) {
+
+			t.Errorf("Expected: %v but received: %v testing AuditRound(%v).Result",
+				RoundFloat(n, precisions[i]), a.Result, n)
+		}
+	}
+
+	// Representation error only matters to some modes
+	if a := AuditRound(2.675, 2, Floor); a.Ambiguous {
+
+		t.Errorf("Expected: not ambiguous but received: %+v testing AuditRound with Floor", a)
+	}
+}
+
+// Test RoundingBias sums the exact bias of each mode
+func TestRoundingBias(t *testing.T) {
+
+	r := RoundingBias([]float64{0.5, 1.5, 2.5, 3.5, 2.675, 1.25, -0.5, math.NaN()}, 0)
+
+	var (
+		modes    = []RoundingMode{HalfUp, HalfEven, HalfDown, Up, Down, Ceiling, Floor}
+		expected = []string{"1.575", "0.575", "-1.425", "2.575", "-2.425", "3.575", "-3.425"}
+	)
+
+	if r.Count != 7 || r.Ties != 5 || len(r.Modes) != len(modes) {
+
+		t.Fatalf("Expected: 7 values, 5 ties and 7 modes but received: %+v testing RoundingBias", r)
+	}
+
+	for i, mb := range r.Modes {
+
+		if mb.Mode != modes[i] || mb.Bias.String() != expected[i] || mb.MeanBias != mb.Bias.Float64()/7 {
+
+			t.Errorf("Expected: %v %s but received: %v %s (%v) testing RoundingBias",
+				modes[i], expected[i], mb.Mode, mb.Bias, mb.MeanBias)
+		}
+	}
+
+	table := "7 values rounded to precision 0, 5 ties\n" +
+		"mode         bias  mean bias\n" +
+		"half-up     1.575   0.225000\n" +
+		"half-even   0.575   0.082143\n" +
+		"half-down  -1.425  -0.203571\n" +
+		"up          2.575   0.367857\n" +
+		"down       -2.425  -0.346429\n" +
+		"ceiling     3.575   0.510714\n" +
+		"floor      -3.425  -0.489286\n"
+
+	if output := r.String(); output != table {
+
+		t.Errorf("Expected: %q but received: %q testing BiasReport.String", table, output)
+	}
+
+	if empty := RoundingBias(nil, 2); empty.Count != 0 || empty.Modes[0].MeanBias != 0 || empty.Modes[0].Bias.Sign() != 0 {
+
+		t.Errorf("Expected: zero bias but received: %+v testing RoundingBias(nil)", empty)
+	}
+
+	// Extreme precisions round to at most 10^MaxDecimalExponent
+	r = RoundingBias([]float64{1.5}, math.MinInt)
+
+	if r.Precision != math.MinInt || r.Modes[0].Bias.String() != "-1.5" || r.Modes[3].Bias.Cmp(Sum([]Decimal{MustParseDecimal("1e1000000"), MustParseDecimal("-1.5")})) != 0 {
+
+		t.Errorf("Expected: biases of -1.5 and 1e1000000 - 1.5 but received: %+v testing RoundingBias(1.5, MinInt)", r.Modes)
+	}
+}
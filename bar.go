@@ -0,0 +1,85 @@
+package decimals
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Characters drawn for the filled and empty parts of a bar
+const (
+	barFilled = "█"
+	barEmpty  = "░"
+)
+
+// FormatWithBar formats x as a percentage of max followed by a bar using
+// the default formatter. See Formatter.FormatWithBar.
+func FormatWithBar(x, max float64, width int) string {
+
+	return DefaultFormatter().FormatWithBar(x, max, width)
+}
+
+// FormatWithBar formats x as a percentage of max to one decimal place,
+// followed by a bar of the given width in columns for terminal dashboards,
+// as in "42.0% ██████░░░░" for 42 of 100 with a width of ten. The
+// percentage is computed exactly and rounded half up as by FormatPercent,
+// and the bar is filled in proportion to the rounded percentage, rounded
+// half up to whole columns, so the label and the bar always agree.
+// Percentages outside 0 to 100 are shown in full but fill the bar no more
+// than empty or full. If x or max is NaN or infinite the bar is empty. A
+// percentage of a max that is zero or negative is undefined, so it is
+// formatted as NaN is, as the formatter's Placeholder if it has one, with
+// an empty bar.
+func (f Formatter) FormatWithBar(x, max float64, width int) string {
+
+	var (
+		label  string
+		filled int
+	)
+
+	if width < 0 {
+
+		width = 0
+	}
+
+	dx, errx := DecimalFromFloat(x)
+	dmax, errmax := DecimalFromFloat(max)
+
+	switch {
+
+	case max <= 0 || math.IsNaN(max):
+
+		label = f.FormatPercent(math.NaN(), 1)
+
+	case errx != nil || errmax != nil:
+
+		label = f.FormatPercent(x/max, 1)
+
+	default:
+
+		// Scale x by 100 exactly and divide once to find the percentage
+		if dx.digits != "" {
+
+			dx.exponent += 2
+		}
+
+		pct, _ := dx.Div(dmax, 1, HalfUp)
+		label = f.applyTemplate(f.formatPercent(f.formatDecimal(pct, 1)))
+
+		// Fill pct × width / 100 columns, from the rounded percentage
+		var (
+			num = new(big.Int).Mul(pct.coefficient(), big.NewInt(int64(width)))
+			den = new(big.Int).Mul(pow10Big(-pct.exponent), big.NewInt(100))
+		)
+
+		cols := ratDecimal(num, den, 0, HalfUp).Float64()
+		filled = int(math.Max(0, math.Min(float64(width), cols)))
+	}
+
+	if width == 0 {
+
+		return label
+	}
+
+	return label + " " + strings.Repeat(barFilled, filled) + strings.Repeat(barEmpty, width-filled)
+}
@@ -0,0 +1,66 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatWithBar fills the bar from the rounded percentage
+func TestFormatWithBar(t *testing.T) {
+
+	var (
+		xs     = []float64{42, 0, 100, 25, 24.94, 150, -5, 1, 5, math.NaN(), 3, 1, 2, 5, 0}
+		maxes  = []float64{100, 100, 100, 100, 100, 100, 100, 3, 0, 10, math.Inf(1), 3, 8, -1, 0}
+		widths = []int{10, 10, 10, 10, 10, 10, 10, 10, 4, 4, 4, 0, 4, 4, 4}
+	)
+
+	expected := []string{
+		"42.0% ████░░░░░░",
+		"0.0% ░░░░░░░░░░",
+		"100.0% ██████████",
+		"25.0% ███░░░░░░░",
+		"24.9% ██░░░░░░░░",
+		"150.0% ██████████",
+		"-5.0% ░░░░░░░░░░",
+		"33.3% ███░░░░░░░",
+		"NaN% ░░░░",
+		"NaN% ░░░░",
+		"0.0% ░░░░",
+		"33.3%",
+		"25.0% █░░░",
+		"NaN% ░░░░",
+		"NaN% ░░░░",
+	}
+
+	for i, x := range xs {
+
+		if output := FormatWithBar(x, maxes[i], widths[i]); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatWithBar(%v, %v, %d)",
+				expected[i], output, x, maxes[i], widths[i])
+		}
+	}
+}
+
+// Test FormatWithBar uses the formatter's separators and percent pattern
+func TestFormatterFormatWithBar(t *testing.T) {
+
+	f, _ := NewFormatter("de-DE")
+	expected := "12,5\u00A0% █░░░░░░░"
+
+	if output := f.FormatWithBar(1, 8, 8); output != expected {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.FormatWithBar", expected, output)
+	}
+}
+
+// Test FormatWithBar shows the placeholder for a max that is not positive
+func TestFormatWithBarPlaceholder(t *testing.T) {
+
+	f := Formatter{GroupSeparator: ",", Placeholder: "—"}
+
+	if output := f.FormatWithBar(5, -1, 4); output != "— ░░░░" {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.FormatWithBar(5, -1, 4)", "— ░░░░", output)
+	}
+}
@@ -0,0 +1,242 @@
+package decimals
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+// compactDigits are the digits of EncodeCompactBase, which agree with
+// strconv.FormatInt up to base 36 and continue with capital letters
+const compactDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// RoundBase rounds a float64 to the given precision in another base, for
+// sexagesimal times and angles or duodecimal measures. Precision is the
+// number of places after the point in that base, so the result is the
+// nearest multiple of base^-precision. A negative precision rounds to a
+// power of the base, as RoundFloat does with powers of ten. For example,
+// RoundBase(1.4375, 12, 1) rounds 1.4375 hours to the nearest five minutes,
+// giving 1 5/12 hours. Ties are rounded away from zero. RoundBase panics if
+// base is less than 2.
+func RoundBase(x float64, base int, precision int) float64 {
+
+	return RoundBaseMode(x, base, precision, HalfUp)
+}
+
+// RoundBaseMode rounds a float64 to the given precision in another base
+// using the given rounding mode. See RoundBase. As with RoundFloat, x is
+// rounded by its shortest decimal representation, so ties written in
+// decimal are rounded as ties. It panics if base is less than 2.
+func RoundBaseMode(x float64, base int, precision int, mode RoundingMode) float64 {
+
+	if base < 2 {
+
+		panic("decimals: RoundBase with base less than 2")
+	}
+
+	// Zero, infinities and NaN are unchanged by rounding
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) {
+
+		return x
+	}
+
+	// Express |x| exactly as the fraction n/d of its decimal digits
+	dec, _ := DecimalFromFloat(x)
+	n, _ := new(big.Int).SetString(dec.digits, 10)
+	d := big.NewInt(1)
+
+	if dec.exponent >= 0 {
+
+		n.Mul(n, pow10Big(dec.exponent))
+
+	} else {
+
+		d.Set(pow10Big(-dec.exponent))
+	}
+
+	// Scale so the rounding point is at the units place
+	scale := new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(absInt(precision))), nil)
+
+	if precision >= 0 {
+
+		n.Mul(n, scale)
+
+	} else {
+
+		d.Mul(d, scale)
+	}
+
+	q := roundQuo(n, d, x < 0, mode)
+
+	// Scale back and convert to the nearest float
+	var r float64
+
+	if precision >= 0 {
+
+		r, _ = new(big.Rat).SetFrac(q, scale).Float64()
+
+	} else {
+
+		r, _ = new(big.Rat).SetInt(q.Mul(q, scale)).Float64()
+	}
+
+	return math.Copysign(r, x)
+}
+
+// EncodeCompact encodes an integer in base 62, with the digits 0 to 9, a
+// to z and A to Z, for short identifiers in URLs. See EncodeCompactBase.
+func EncodeCompact(x int64) string {
+
+	return EncodeCompactBase(x, 62)
+}
+
+// EncodeCompactBase encodes an integer in the given base, from 2 to 62,
+// with the digits 0 to 9, then a to z, then A to Z, so that base 36 is the
+// shortest that is not case sensitive and base 62 the shortest that uses
+// only letters and digits. Up to base 36 the encoding is that of
+// strconv.FormatInt. Negative numbers have a leading minus sign, which is
+// also safe in URLs. EncodeCompactBase panics if base is not from 2 to 62.
+func EncodeCompactBase(x int64, base int) string {
+
+	if base < 2 || base > len(compactDigits) {
+
+		panic("decimals: EncodeCompactBase with base outside 2 to 62")
+	}
+
+	var (
+		u   = absUint64(x)
+		b   = uint64(base)
+		buf [65]byte
+		i   = len(buf)
+	)
+
+	for {
+
+		i--
+		buf[i] = compactDigits[u%b]
+		u /= b
+
+		if u == 0 {
+
+			break
+		}
+	}
+
+	if x < 0 {
+
+		i--
+		buf[i] = '-'
+	}
+
+	return string(buf[i:])
+}
+
+// DecodeCompact decodes an integer encoded in base 62 by EncodeCompact. See
+// DecodeCompactBase.
+func DecodeCompact(s string) (int64, error) {
+
+	return decodeCompact("DecodeCompact", s, 62)
+}
+
+// DecodeCompactBase decodes an integer encoded in the given base by
+// EncodeCompactBase. Up to base 36 letters are read in either case, as
+// strconv.ParseInt reads them. An error wrapping ErrSyntax is returned if
+// s is not an encoded integer, and one wrapping ErrRange if it does not
+// fit in an int64. DecodeCompactBase panics if base is not from 2 to 62.
+func DecodeCompactBase(s string, base int) (int64, error) {
+
+	return decodeCompact("DecodeCompactBase", s, base)
+}
+
+// FormatDecodedCompact decodes a base 62 identifier and formats it using
+// the default formatter. See Formatter.FormatDecodedCompact.
+func FormatDecodedCompact(s string) (string, error) {
+
+	return DefaultFormatter().FormatDecodedCompact(s)
+}
+
+// FormatDecodedCompact decodes an integer encoded in base 62 by
+// EncodeCompact and formats it as FormatThousands does, so that short
+// identifiers can be shown as the numbers they stand for. An error is
+// returned as by DecodeCompact.
+func (f Formatter) FormatDecodedCompact(s string) (string, error) {
+
+	x, err := decodeCompact("FormatDecodedCompact", s, 62)
+
+	if err != nil {
+
+		return "", err
+	}
+
+	return f.FormatThousands(x), nil
+}
+
+// decodeCompact decodes an integer encoded in the given base, reporting
+// errors as from the named function.
+func decodeCompact(name string, s string, base int) (int64, error) {
+
+	if base < 2 || base > len(compactDigits) {
+
+		panic("decimals: DecodeCompactBase with base outside 2 to 62")
+	}
+
+	var (
+		digits   = strings.TrimPrefix(s, "-")
+		negative = len(digits) < len(s)
+		limit    = uint64(1<<63 - 1)
+		u        uint64
+	)
+
+	if digits == "" {
+
+		return 0, &NumError{name, s, ErrSyntax}
+	}
+
+	if negative {
+
+		limit++
+	}
+
+	for i := 0; i < len(digits); i++ {
+
+		c := digits[i]
+
+		// Read letters in either case when the base has no capitals
+		if base <= 36 && c >= 'A' && c <= 'Z' {
+
+			c += 'a' - 'A'
+		}
+
+		d := strings.IndexByte(compactDigits[:base], c)
+
+		if d < 0 {
+
+			return 0, &NumError{name, s, ErrSyntax}
+		}
+
+		if u > (limit-uint64(d))/uint64(base) {
+
+			return 0, &NumError{name, s, ErrRange}
+		}
+
+		u = u*uint64(base) + uint64(d)
+	}
+
+	if negative {
+
+		return int64(-u), nil
+	}
+
+	return int64(u), nil
+}
+
+// absInt returns the absolute value of x.
+func absInt(x int) int {
+
+	if x < 0 {
+
+		return -x
+	}
+
+	return x
+}
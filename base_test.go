@@ -0,0 +1,165 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// Test RoundBase with sexagesimal and duodecimal precisions
+func TestRoundBase(t *testing.T) {
+
+	inputs := []float64{1.4375, 1.4375, 1.5, 2.0 / 3, -1.4375, 7.5, 7.5, 90.123456}
+
+	bases := []int{12, 60, 2, 12, 12, 2, 60, 60}
+
+	precisions := []int{1, 1, 0, 1, 1, -2, -1, 2}
+
+	expected := []float64{
+		1 + 5.0/12,
+		1 + 26.0/60,
+		2,
+		8.0 / 12,
+		-(1 + 5.0/12),
+		8,
+		0,
+		90 + 7.0/60 + 24.0/3600,
+	}
+
+	for i, n := range inputs {
+
+		output := RoundBase(n, bases[i], precisions[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v testing RoundBase(%v, %d, %d)",
+				expected[i], output, n, bases[i], precisions[i])
+		}
+	}
+}
+
+// Test RoundBaseMode with ties and directed modes
+func TestRoundBaseMode(t *testing.T) {
+
+	inputs := []float64{0.125, 0.125, 0.375, -0.1, 0.1}
+
+	modes := []RoundingMode{HalfUp, HalfEven, HalfEven, Floor, Ceiling}
+
+	expected := []float64{0.25, 0, 0.5, -0.25, 0.25}
+
+	for i, n := range inputs {
+
+		output := RoundBaseMode(n, 4, 1, modes[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v testing RoundBaseMode(%v, 4, 1, %v)",
+				expected[i], output, n, modes[i])
+		}
+	}
+
+	defer func() {
+
+		if recover() == nil {
+
+			t.Errorf("Expected: a panic but received: none testing RoundBase with base 1")
+		}
+	}()
+
+	RoundBase(1, 1, 1)
+}
+
+// Test EncodeCompact and DecodeCompact round trip and match strconv up to
+// base 36
+func TestEncodeCompact(t *testing.T) {
+
+	inputs := []int64{0, 61, 62, -62, 1234567890, math.MaxInt64, math.MinInt64}
+
+	expected := []string{"0", "Z", "10", "-10", "1ly7vk", "aZl8N0y58M7", "-aZl8N0y58M8"}
+
+	for i, n := range inputs {
+
+		output := EncodeCompact(n)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing EncodeCompact(%d)", expected[i], output, n)
+		}
+
+		if x, err := DecodeCompact(output); err != nil || x != n {
+
+			t.Errorf("Expected: %d but received: %d (%v) testing DecodeCompact(%q)", n, x, err, output)
+		}
+
+		for base := 2; base <= 36; base++ {
+
+			if s, e := EncodeCompactBase(n, base), strconv.FormatInt(n, base); s != e {
+
+				t.Errorf("Expected: %q but received: %q testing EncodeCompactBase(%d, %d)", e, s, n, base)
+			}
+		}
+	}
+}
+
+// Test DecodeCompact and DecodeCompactBase reject invalid and overflowing
+// input and read either case up to base 36
+func TestDecodeCompact(t *testing.T) {
+
+	if x, err := DecodeCompactBase("ZZ", 36); err != nil || x != 1295 {
+
+		t.Errorf("Expected: 1295 but received: %d (%v) testing DecodeCompactBase(\"ZZ\", 36)", x, err)
+	}
+
+	inputs := []string{"", "-", "+5", "a-b", "ab_c", "aZl8N0y58M8", "-aZl8N0y58M9", "100000000000"}
+
+	expected := []error{ErrSyntax, ErrSyntax, ErrSyntax, ErrSyntax, ErrSyntax, ErrRange, ErrRange, ErrRange}
+
+	for i, s := range inputs {
+
+		if _, err := DecodeCompact(s); !errors.Is(err, expected[i]) {
+
+			t.Errorf("Expected: %v but received: %v testing DecodeCompact(%q)", expected[i], err, s)
+		}
+	}
+
+	if _, err := DecodeCompactBase("Z", 35); !errors.Is(err, ErrSyntax) {
+
+		t.Errorf("Expected: %v but received: %v testing DecodeCompactBase(\"Z\", 35)", ErrSyntax, err)
+	}
+}
+
+// Test FormatDecodedCompact formats decoded identifiers
+func TestFormatDecodedCompact(t *testing.T) {
+
+	if output, err := FormatDecodedCompact("1ly7vk"); err != nil || output != "1,234,567,890" {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing FormatDecodedCompact", "1,234,567,890", output, err)
+	}
+
+	f := Formatter{GroupSeparator: ".", DecimalSeparator: ","}
+
+	if output, _ := f.FormatDecodedCompact("-10"); output != "-62" {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.FormatDecodedCompact", "-62", output)
+	}
+
+	if _, err := FormatDecodedCompact("!"); !errors.Is(err, ErrSyntax) {
+
+		t.Errorf("Expected: %v but received: %v testing FormatDecodedCompact", ErrSyntax, err)
+	}
+}
+
+// Test EncodeCompactBase panics with a base outside 2 to 62
+func TestEncodeCompactBasePanics(t *testing.T) {
+
+	defer func() {
+
+		if recover() == nil {
+
+			t.Errorf("Expected: a panic but received: none testing EncodeCompactBase")
+		}
+	}()
+
+	EncodeCompactBase(1, 63)
+}
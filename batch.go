@@ -0,0 +1,111 @@
+package decimals
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// checkInterval is the number of values processed between checks for
+// cancellation by the batch and stream functions
+const checkInterval = 1024
+
+// FormatFloats formats a slice of float64s using the default formatter. See
+// Formatter.FormatFloats.
+func FormatFloats(ctx context.Context, xs []float64, precision int) ([]string, error) {
+
+	return DefaultFormatter().FormatFloats(ctx, xs, precision)
+}
+
+// FormatFloats formats each value in xs as by FormatFloat, for exports of
+// many values. The context is checked for cancellation before the first
+// value and every 1024 values after it, so a long job can be aborted. If
+// the context is cancelled the values formatted so far are returned with
+// the context's error.
+func (f Formatter) FormatFloats(ctx context.Context, xs []float64, precision int) ([]string, error) {
+
+	out := make([]string, 0, len(xs))
+
+	for i, x := range xs {
+
+		if i%checkInterval == 0 {
+
+			if err := ctx.Err(); err != nil {
+
+				return out, err
+			}
+		}
+
+		out = append(out, f.FormatFloat(x, precision))
+	}
+
+	return out, nil
+}
+
+// A DecimalScanner reads Decimals separated by whitespace from a stream,
+// such as one number per line. Successive calls to Scan step through the
+// numbers, as with bufio.Scanner.
+type DecimalScanner struct {
+	scanner *bufio.Scanner
+	flags   ParseFlag
+	d       Decimal
+	n       int
+	err     error
+}
+
+// NewDecimalScanner returns a scanner that reads from r and parses numbers
+// as ParseDecimal does with the given flags.
+func NewDecimalScanner(r io.Reader, flags ParseFlag) *DecimalScanner {
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+
+	return &DecimalScanner{scanner: scanner, flags: flags}
+}
+
+// Scan advances to the next number, which is then available from Decimal.
+// It returns false at the end of the input or on an error, which is then
+// available from Err. The context is checked for cancellation before the
+// first number and every 1024 numbers after it. A read that blocks is not
+// interrupted by cancellation.
+func (s *DecimalScanner) Scan(ctx context.Context) bool {
+
+	if s.err != nil {
+
+		return false
+	}
+
+	if s.n%checkInterval == 0 {
+
+		if s.err = ctx.Err(); s.err != nil {
+
+			return false
+		}
+	}
+
+	if !s.scanner.Scan() {
+
+		s.err = s.scanner.Err()
+		return false
+	}
+
+	s.n++
+	s.d, s.err = ParseDecimal(s.scanner.Text(), s.flags)
+
+	return s.err == nil
+}
+
+// Decimal returns the number read by the most recent call to Scan.
+func (s *DecimalScanner) Decimal() Decimal {
+
+	return s.d
+}
+
+// Err returns the first error met by the scanner: an error reading the
+// input, a *NumError for a number that could not be parsed, or the
+// context's error if the scan was cancelled. It returns nil at the end of
+// the input.
+func (s *DecimalScanner) Err() error {
+
+	return s.err
+}
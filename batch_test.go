@@ -0,0 +1,105 @@
+package decimals
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test FormatFloats formats each value
+func TestFormatFloats(t *testing.T) {
+
+	var (
+		inputs   = []float64{1234.5678, -0.125, 0}
+		expected = []string{"1,234.57", "-0.13", "0.00"}
+	)
+
+	outputs, err := FormatFloats(context.Background(), inputs, 2)
+
+	if err != nil || len(outputs) != len(expected) {
+
+		t.Fatalf("Expected: %q but received: %q (%v) testing FormatFloats", expected, outputs, err)
+	}
+
+	for i, output := range outputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatFloats", expected[i], output)
+		}
+	}
+}
+
+// Test FormatFloats stops when the context is cancelled
+func TestFormatFloatsCancel(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	outputs, err := FormatFloats(ctx, make([]float64, 5000), 0)
+
+	if !errors.Is(err, context.Canceled) || len(outputs) != 0 {
+
+		t.Errorf("Expected: 0 values and %v but received: %d values and %v testing FormatFloats", context.Canceled, len(outputs), err)
+	}
+}
+
+// Test DecimalScanner reads numbers separated by whitespace
+func TestDecimalScanner(t *testing.T) {
+
+	var (
+		input    = "1.50\n-2  3e2\n\n1,234.5\n"
+		expected = []string{"1.50", "-2", "300", "1234.5"}
+		outputs  []string
+		ctx      = context.Background()
+	)
+
+	s := NewDecimalScanner(strings.NewReader(input), ParseLenient)
+
+	for s.Scan(ctx) {
+
+		outputs = append(outputs, s.Decimal().String())
+	}
+
+	if err := s.Err(); err != nil {
+
+		t.Fatalf("Expected: nil but received: %v testing DecimalScanner", err)
+	}
+
+	if strings.Join(outputs, " ") != strings.Join(expected, " ") {
+
+		t.Errorf("Expected: %q but received: %q testing DecimalScanner", expected, outputs)
+	}
+}
+
+// Test DecimalScanner stops on a syntax error or cancellation
+func TestDecimalScannerErrors(t *testing.T) {
+
+	s := NewDecimalScanner(strings.NewReader("1 x 2"), 0)
+
+	if !s.Scan(context.Background()) || s.Scan(context.Background()) || !errors.Is(s.Err(), ErrSyntax) {
+
+		t.Errorf("Expected: %v but received: %v testing DecimalScanner", ErrSyntax, s.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s = NewDecimalScanner(strings.NewReader(strings.Repeat("1 ", 5000)), 0)
+
+	var n int
+
+	for s.Scan(ctx) {
+
+		if n++; n == 1 {
+
+			cancel()
+		}
+	}
+
+	if !errors.Is(s.Err(), context.Canceled) || n != checkInterval {
+
+		t.Errorf("Expected: %d numbers and %v but received: %d and %v testing DecimalScanner", checkInterval, context.Canceled, n, s.Err())
+	}
+}
@@ -0,0 +1,353 @@
+package decimals
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Sign nibbles used by packed and zoned decimals
+const (
+	bcdPositive byte = 0xC
+	bcdNegative byte = 0xD
+	bcdUnsigned byte = 0xF
+)
+
+// EncodeBCD encodes an int64 as a packed binary coded decimal, the format
+// used for amounts in mainframe and banking files. Each byte holds two
+// decimal digits, one per nibble, and the final nibble holds the sign: C
+// for positive and D for negative. If size is positive the result is
+// padded with leading zeros to size bytes, and an error wrapping ErrRange
+// is returned if x does not fit. Otherwise the shortest encoding is used.
+func EncodeBCD(x int64, size int) ([]byte, error) {
+
+	return encodeBCD("EncodeBCD", strconv.FormatInt(x, 10), x < 0, strconv.FormatUint(absUint64(x), 10), size)
+}
+
+// EncodeBCDDecimal encodes a Decimal as a packed binary coded decimal with
+// an implied scale, the number of digits after a decimal point that is not
+// stored, as in a COBOL field declared S9(7)V99 with a scale of two. The
+// digits of d × 10^scale are encoded and padded as by EncodeBCD. An error
+// wrapping ErrRange is returned if d has more decimal places than the
+// scale, since they would be lost, or if it does not fit in size bytes.
+func EncodeBCDDecimal(d Decimal, scale int, size int) ([]byte, error) {
+
+	digits, ok := d.scaledDigits(scale)
+
+	if !ok {
+
+		return nil, &NumError{"EncodeBCDDecimal", d.String(), ErrRange}
+	}
+
+	return encodeBCD("EncodeBCDDecimal", d.String(), d.negative, digits, size)
+}
+
+// encodeBCD encodes the unsigned digits of a number as a packed decimal,
+// reporting errors against fn and s.
+func encodeBCD(fn string, s string, negative bool, digits string, size int) ([]byte, error) {
+
+	// A packed decimal holds an odd number of digits plus the sign
+	if len(digits)%2 == 0 {
+
+		digits = "0" + digits
+	}
+
+	n := (len(digits) + 1) / 2
+
+	if size > 0 {
+
+		if n > size {
+
+			return nil, &NumError{fn, s, ErrRange}
+		}
+
+		n = size
+	}
+
+	b := make([]byte, n)
+	sign := bcdPositive
+
+	if negative {
+
+		sign = bcdNegative
+	}
+
+	// Fill the nibbles from the right, starting with the sign
+	b[n-1] = sign
+
+	for i, k := len(digits)-1, 2*n-2; i >= 0; i, k = i-1, k-1 {
+
+		b[k/2] |= (digits[i] - '0') << (4 * uint(1-k%2))
+	}
+
+	return b, nil
+}
+
+// DecodeBCD decodes a packed binary coded decimal into an int64. The final
+// nibble is the sign: D or B for negative, and C, A, E or F for positive
+// or unsigned. An error wrapping ErrSyntax is returned if any other nibble
+// is not a decimal digit, and one wrapping ErrRange if the value does not
+// fit in an int64.
+func DecodeBCD(b []byte) (int64, error) {
+
+	negative, digits, err := decodeBCD("DecodeBCD", b)
+
+	if err != nil {
+
+		return 0, err
+	}
+
+	return decodeInt64("DecodeBCD", b, negative, digits)
+}
+
+// DecodeBCDDecimal decodes a packed binary coded decimal with an implied
+// scale into a Decimal, so that the digits of b are divided by 10^scale.
+// Errors wrap ErrSyntax as for DecodeBCD. Any number of digits can be
+// decoded.
+func DecodeBCDDecimal(b []byte, scale int) (Decimal, error) {
+
+	negative, digits, err := decodeBCD("DecodeBCDDecimal", b)
+
+	if err != nil {
+
+		return Decimal{}, err
+	}
+
+	return newDecimal(negative, digits, -scale), nil
+}
+
+// decodeBCD returns the sign and digits of a packed decimal, reporting
+// errors against fn.
+func decodeBCD(fn string, b []byte) (bool, string, error) {
+
+	if len(b) == 0 {
+
+		return false, "", &NumError{fn, "", ErrSyntax}
+	}
+
+	digits := make([]byte, 0, 2*len(b)-1)
+
+	for i, c := range b {
+
+		digits = append(digits, c>>4)
+
+		if i < len(b)-1 {
+
+			digits = append(digits, c&0x0F)
+		}
+	}
+
+	return decodeDigits(fn, b, digits, b[len(b)-1]&0x0F)
+}
+
+// EncodeZoned encodes an int64 as a zoned decimal in EBCDIC, with one
+// byte per digit. Each digit has the zone F, except the last whose zone
+// holds the sign: C for positive and D for negative. If size is positive
+// the result is padded with leading zeros to size digits, and an error
+// wrapping ErrRange is returned if x does not fit.
+func EncodeZoned(x int64, size int) ([]byte, error) {
+
+	return encodeZoned("EncodeZoned", strconv.FormatInt(x, 10), x < 0, strconv.FormatUint(absUint64(x), 10), size)
+}
+
+// EncodeZonedDecimal encodes a Decimal as an EBCDIC zoned decimal with an
+// implied scale, as EncodeBCDDecimal does for packed decimals. An error
+// wrapping ErrRange is returned if d has more decimal places than the
+// scale or does not fit in size digits.
+func EncodeZonedDecimal(d Decimal, scale int, size int) ([]byte, error) {
+
+	digits, ok := d.scaledDigits(scale)
+
+	if !ok {
+
+		return nil, &NumError{"EncodeZonedDecimal", d.String(), ErrRange}
+	}
+
+	return encodeZoned("EncodeZonedDecimal", d.String(), d.negative, digits, size)
+}
+
+// encodeZoned encodes the unsigned digits of a number as a zoned decimal,
+// reporting errors against fn and s.
+func encodeZoned(fn string, s string, negative bool, digits string, size int) ([]byte, error) {
+
+	if size > 0 {
+
+		if len(digits) > size {
+
+			return nil, &NumError{fn, s, ErrRange}
+		}
+
+		digits = fmt.Sprintf("%0*s", size, digits)
+	}
+
+	b := make([]byte, len(digits))
+
+	for i := range digits {
+
+		b[i] = bcdUnsigned<<4 | (digits[i] - '0')
+	}
+
+	sign := bcdPositive
+
+	if negative {
+
+		sign = bcdNegative
+	}
+
+	b[len(b)-1] = sign<<4 | b[len(b)-1]&0x0F
+
+	return b, nil
+}
+
+// DecodeZoned decodes an EBCDIC zoned decimal into an int64. The zone of
+// the last byte is the sign, as for DecodeBCD, and the other zones must be
+// F. Errors wrap ErrSyntax or ErrRange as for DecodeBCD.
+func DecodeZoned(b []byte) (int64, error) {
+
+	negative, digits, err := decodeZoned("DecodeZoned", b)
+
+	if err != nil {
+
+		return 0, err
+	}
+
+	return decodeInt64("DecodeZoned", b, negative, digits)
+}
+
+// DecodeZonedDecimal decodes an EBCDIC zoned decimal with an implied scale
+// into a Decimal, as DecodeBCDDecimal does for packed decimals.
+func DecodeZonedDecimal(b []byte, scale int) (Decimal, error) {
+
+	negative, digits, err := decodeZoned("DecodeZonedDecimal", b)
+
+	if err != nil {
+
+		return Decimal{}, err
+	}
+
+	return newDecimal(negative, digits, -scale), nil
+}
+
+// decodeZoned returns the sign and digits of a zoned decimal, reporting
+// errors against fn.
+func decodeZoned(fn string, b []byte) (bool, string, error) {
+
+	if len(b) == 0 {
+
+		return false, "", &NumError{fn, "", ErrSyntax}
+	}
+
+	digits := make([]byte, len(b))
+
+	for i, c := range b {
+
+		if i < len(b)-1 && c>>4 != bcdUnsigned {
+
+			return false, "", &NumError{fn, fmt.Sprintf("% X", b), ErrSyntax}
+		}
+
+		digits[i] = c & 0x0F
+	}
+
+	return decodeDigits(fn, b, digits, b[len(b)-1]>>4)
+}
+
+// decodeDigits checks the digit values and sign nibble of a packed or
+// zoned decimal and returns its sign and decimal digits, reporting errors
+// against fn and b.
+func decodeDigits(fn string, b []byte, digits []byte, sign byte) (bool, string, error) {
+
+	var negative bool
+
+	switch sign {
+
+	case bcdNegative, 0xB:
+
+		negative = true
+
+	case bcdPositive, bcdUnsigned, 0xA, 0xE:
+
+	default:
+
+		return false, "", &NumError{fn, fmt.Sprintf("% X", b), ErrSyntax}
+	}
+
+	s := make([]byte, len(digits))
+
+	for i, d := range digits {
+
+		if d > 9 {
+
+			return false, "", &NumError{fn, fmt.Sprintf("% X", b), ErrSyntax}
+		}
+
+		s[i] = '0' + d
+	}
+
+	return negative, string(s), nil
+}
+
+// decodeInt64 converts the sign and digits of a packed or zoned decimal
+// into an int64, reporting errors against fn and b.
+func decodeInt64(fn string, b []byte, negative bool, digits string) (int64, error) {
+
+	// Accumulate the magnitude, allowing for the minimum int64
+	var (
+		r     uint64
+		limit = uint64(1<<63 - 1)
+	)
+
+	if negative {
+
+		limit++
+	}
+
+	for i := range digits {
+
+		d := uint64(digits[i] - '0')
+
+		if r > (limit-d)/10 {
+
+			return 0, &NumError{fn, fmt.Sprintf("% X", b), ErrRange}
+		}
+
+		r = r*10 + d
+	}
+
+	if negative {
+
+		return -int64(r-1) - 1, nil
+	}
+
+	return int64(r), nil
+}
+
+// scaledDigits returns the unsigned digits of d × 10^scale, with no
+// leading zeros except a single zero for zero. It reports false if d has
+// more decimal places than the scale.
+func (d Decimal) scaledDigits(scale int) (string, bool) {
+
+	if d.Round(scale, Down).Cmp(d) != 0 {
+
+		return "", false
+	}
+
+	if d.digits == "" {
+
+		return "0", true
+	}
+
+	// The digits dropped below the scale are all zeros
+	digits, shift := d.digits, d.exponent+scale
+
+	if shift >= 0 {
+
+		digits += strings.Repeat("0", shift)
+
+	} else {
+
+		digits = digits[:len(digits)+shift]
+	}
+
+	return strings.TrimLeft(digits, "0"), true
+}
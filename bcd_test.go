@@ -0,0 +1,210 @@
+package decimals
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+// Test EncodeBCD and DecodeBCD with a range of values
+func TestBCD(t *testing.T) {
+
+	inputs := []int64{0, 5, -5, 12345, -1234, math.MaxInt64, math.MinInt64}
+
+	sizes := []int{0, 0, 0, 0, 4, 0, 0}
+
+	expected := [][]byte{
+		{0x0C},
+		{0x5C},
+		{0x5D},
+		{0x12, 0x34, 0x5C},
+		{0x00, 0x01, 0x23, 0x4D},
+		{0x92, 0x23, 0x37, 0x20, 0x36, 0x85, 0x47, 0x75, 0x80, 0x7C},
+		{0x92, 0x23, 0x37, 0x20, 0x36, 0x85, 0x47, 0x75, 0x80, 0x8D},
+	}
+
+	for i, n := range inputs {
+
+		output, err := EncodeBCD(n, sizes[i])
+
+		if err != nil || !bytes.Equal(output, expected[i]) {
+
+			t.Errorf("Expected: % X but received: % X (%v) testing EncodeBCD(%d, %d)",
+				expected[i], output, err, n, sizes[i])
+		}
+
+		decoded, err := DecodeBCD(expected[i])
+
+		if err != nil || decoded != n {
+
+			t.Errorf("Expected: %d but received: %d (%v) testing DecodeBCD(% X)",
+				n, decoded, err, expected[i])
+		}
+	}
+
+	// Unsigned packed decimals decode as positive
+	if output, err := DecodeBCD([]byte{0x12, 0x3F}); err != nil || output != 123 {
+
+		t.Errorf("Expected: 123 but received: %d (%v) testing DecodeBCD", output, err)
+	}
+}
+
+// Test EncodeBCD and DecodeBCD report errors
+func TestBCDErrors(t *testing.T) {
+
+	if _, err := EncodeBCD(12345, 2); !errors.Is(err, ErrRange) {
+
+		t.Errorf("Expected: ErrRange but received: %v testing EncodeBCD", err)
+	}
+
+	inputs := [][]byte{{}, {0x1A, 0x2C}, {0x12, 0x34}}
+
+	for _, b := range inputs {
+
+		if _, err := DecodeBCD(b); !errors.Is(err, ErrSyntax) {
+
+			t.Errorf("Expected: ErrSyntax but received: %v testing DecodeBCD(% X)", err, b)
+		}
+	}
+
+	overflow := []byte{0x92, 0x23, 0x37, 0x20, 0x36, 0x85, 0x47, 0x75, 0x80, 0x8C}
+
+	if _, err := DecodeBCD(overflow); !errors.Is(err, ErrRange) {
+
+		t.Errorf("Expected: ErrRange but received: %v testing DecodeBCD", err)
+	}
+}
+
+// Test EncodeZoned and DecodeZoned with a range of values
+func TestZoned(t *testing.T) {
+
+	inputs := []int64{0, 123, -123, 42}
+
+	sizes := []int{0, 0, 0, 5}
+
+	expected := [][]byte{
+		{0xC0},
+		{0xF1, 0xF2, 0xC3},
+		{0xF1, 0xF2, 0xD3},
+		{0xF0, 0xF0, 0xF0, 0xF4, 0xC2},
+	}
+
+	for i, n := range inputs {
+
+		output, err := EncodeZoned(n, sizes[i])
+
+		if err != nil || !bytes.Equal(output, expected[i]) {
+
+			t.Errorf("Expected: % X but received: % X (%v) testing EncodeZoned(%d, %d)",
+				expected[i], output, err, n, sizes[i])
+		}
+
+		decoded, err := DecodeZoned(expected[i])
+
+		if err != nil || decoded != n {
+
+			t.Errorf("Expected: %d but received: %d (%v) testing DecodeZoned(% X)",
+				n, decoded, err, expected[i])
+		}
+	}
+
+	if _, err := EncodeZoned(123, 2); !errors.Is(err, ErrRange) {
+
+		t.Errorf("Expected: ErrRange but received: %v testing EncodeZoned", err)
+	}
+
+	if _, err := DecodeZoned([]byte{0x31, 0xC2}); !errors.Is(err, ErrSyntax) {
+
+		t.Errorf("Expected: ErrSyntax but received: %v testing DecodeZoned", err)
+	}
+}
+
+// Test the Decimal encodings with an implied scale
+func TestBCDDecimal(t *testing.T) {
+
+	inputs := []string{"0", "12.34", "-12.3", "1230", "-0.05", "123456789012345678901234.56"}
+
+	scales := []int{2, 2, 2, -1, 3, 2}
+
+	expected := [][]byte{
+		{0x0C},
+		{0x01, 0x23, 0x4C},
+		{0x01, 0x23, 0x0D},
+		{0x12, 0x3C},
+		{0x05, 0x0D},
+		{0x01, 0x23, 0x45, 0x67, 0x89, 0x01, 0x23, 0x45, 0x67, 0x89, 0x01, 0x23, 0x45, 0x6C},
+	}
+
+	zoned := [][]byte{
+		{0xC0},
+		{0xF1, 0xF2, 0xF3, 0xC4},
+		{0xF1, 0xF2, 0xF3, 0xD0},
+		nil,
+		{0xF5, 0xD0},
+		nil,
+	}
+
+	for i, s := range inputs {
+
+		d, _ := ParseDecimal(s, ParseStrict)
+
+		output, err := EncodeBCDDecimal(d, scales[i], 0)
+
+		if err != nil || !bytes.Equal(output, expected[i]) {
+
+			t.Errorf("Expected: % X but received: % X (%v) testing EncodeBCDDecimal(%s, %d, 0)",
+				expected[i], output, err, s, scales[i])
+		}
+
+		decoded, err := DecodeBCDDecimal(expected[i], scales[i])
+
+		if err != nil || decoded.Cmp(d) != 0 {
+
+			t.Errorf("Expected: %s but received: %s (%v) testing DecodeBCDDecimal(% X, %d)",
+				s, decoded, err, expected[i], scales[i])
+		}
+
+		if zoned[i] == nil {
+
+			continue
+		}
+
+		output, err = EncodeZonedDecimal(d, scales[i], 0)
+
+		if err != nil || !bytes.Equal(output, zoned[i]) {
+
+			t.Errorf("Expected: % X but received: % X (%v) testing EncodeZonedDecimal(%s, %d, 0)",
+				zoned[i], output, err, s, scales[i])
+		}
+
+		decoded, err = DecodeZonedDecimal(zoned[i], scales[i])
+
+		if err != nil || decoded.Cmp(d) != 0 {
+
+			t.Errorf("Expected: %s but received: %s (%v) testing DecodeZonedDecimal(% X, %d)",
+				s, decoded, err, zoned[i], scales[i])
+		}
+	}
+
+	// Decimals with more places than the scale or too many digits
+	for _, s := range []string{"1.234", "1234.5", "-0.001"} {
+
+		d, _ := ParseDecimal(s, ParseStrict)
+
+		if _, err := EncodeBCDDecimal(d, 2, 2); !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: ErrRange but received: %v testing EncodeBCDDecimal(%s, 2, 2)", err, s)
+		}
+
+		if _, err := EncodeZonedDecimal(d, 2, 4); !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: ErrRange but received: %v testing EncodeZonedDecimal(%s, 2, 4)", err, s)
+		}
+	}
+
+	if _, err := DecodeZonedDecimal([]byte{0xF1, 0x2C}, 2); !errors.Is(err, ErrSyntax) {
+
+		t.Errorf("Expected: ErrSyntax but received: %v testing DecodeZonedDecimal", err)
+	}
+}
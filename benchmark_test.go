@@ -0,0 +1,152 @@
+package decimals
+
+import (
+	"testing"
+)
+
+// Sinks that stop the compiler optimising away benchmarked calls
+var (
+	benchString string
+	benchFloat  float64
+	benchInt    int64
+)
+
+func BenchmarkRoundInt(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchInt = RoundInt(5555555555, -3)
+	}
+}
+
+func BenchmarkRoundFloat(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchFloat = RoundFloat(5555.5555, 2)
+	}
+}
+
+func BenchmarkRoundFloatExact(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchFloat = RoundFloatExact(5555.5555, 2, HalfEven)
+	}
+}
+
+func BenchmarkFormatThousands(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchString = FormatThousands(5555555555)
+	}
+}
+
+func BenchmarkFormatThousands128(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchString = FormatThousands128(MaxInt128)
+	}
+}
+
+func BenchmarkFormatInt(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchString = FormatInt(5555555555, -3)
+	}
+}
+
+func BenchmarkFormatFloat(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchString = FormatFloat(5555555.5555, 2)
+	}
+}
+
+func BenchmarkFormatFloatInteger(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchString = FormatFloat(5555555, 2)
+	}
+}
+
+func BenchmarkFormatWithSpec(b *testing.B) {
+
+	spec := FormatSpec{Precision: 2, Mode: HalfEven, Grouping: true, Width: 16}
+
+	for i := 0; i < b.N; i++ {
+
+		benchString = FormatWithSpec(5555555.5555, spec)
+	}
+}
+
+func BenchmarkFormatCompact(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchString = FormatCompact(5555555.5555, 1)
+	}
+}
+
+func BenchmarkExactPercent(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchString = ExactPercent(1, 3, 2)
+	}
+}
+
+func BenchmarkParseFloat(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		benchFloat, _ = ParseFloat("5,555,555.5555", ParseStrict)
+	}
+}
+
+func BenchmarkParseDecimal(b *testing.B) {
+
+	for i := 0; i < b.N; i++ {
+
+		d, _ := ParseDecimal("5,555,555.5555", ParseStrict)
+		benchString = d.digits
+	}
+}
+
+// Test the hot paths stay within their documented allocation budgets. If a
+// change lowers a count, lower its budget here and in the package docs.
+func TestAllocs(t *testing.T) {
+
+	spec := FormatSpec{Precision: 2, Mode: HalfEven, Grouping: true, Width: 16}
+
+	inputs := []struct {
+		name   string
+		budget float64
+		f      func()
+	}{
+		{"RoundInt", 3, func() { benchInt = RoundInt(5555555555, -3) }},
+		{"RoundFloat", 4, func() { benchFloat = RoundFloat(5555.5555, 2) }},
+		{"RoundFloatExact", 7, func() { benchFloat = RoundFloatExact(5555.5555, 2, HalfEven) }},
+		{"FormatThousands", 2, func() { benchString = FormatThousands(5555555555) }},
+		{"FormatInt", 5, func() { benchString = FormatInt(5555555555, -3) }},
+		{"FormatFloat", 5, func() { benchString = FormatFloat(5555555.5555, 2) }},
+		{"FormatWithSpec", 6, func() { benchString = FormatWithSpec(5555555.5555, spec) }},
+		{"FormatCompact", 5, func() { benchString = FormatCompact(5555555.5555, 1) }},
+		{"ExactPercent", 14, func() { benchString = ExactPercent(1, 3, 2) }},
+		{"ParseFloat", 1, func() { benchFloat, _ = ParseFloat("5,555,555.5555", ParseStrict) }},
+	}
+
+	for _, n := range inputs {
+
+		if allocs := testing.AllocsPerRun(100, n.f); allocs > n.budget {
+
+			t.Errorf("Expected: at most %v allocations but received: %v testing %s",
+				n.budget, allocs, n.name)
+		}
+	}
+}
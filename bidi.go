@@ -0,0 +1,88 @@
+package decimals
+
+import (
+	"strings"
+)
+
+// Unicode formatting characters for laying out numbers in bidirectional text.
+const (
+	LeftToRightMark       = "\u200E"
+	RightToLeftMark       = "\u200F"
+	ArabicLetterMark      = "\u061C"
+	LeftToRightIsolate    = "\u2066"
+	PopDirectionalIsolate = "\u2069"
+)
+
+// Separators used with Eastern Arabic digits.
+const (
+	ArabicDecimalSeparator   = "\u066B"
+	ArabicThousandsSeparator = "\u066C"
+)
+
+// A DigitSet holds the runes used for the digits zero to nine in a script.
+type DigitSet [10]rune
+
+// Digit sets for common scripts
+var (
+	// EasternArabicDigits are the Arabic-Indic digits used in Arabic text.
+	EasternArabicDigits = DigitSet{
+		'٠', '١', '٢', '٣', '٤',
+		'٥', '٦', '٧', '٨', '٩',
+	}
+
+	// PersianDigits are the extended Arabic-Indic digits used in Persian
+	// and Urdu text.
+	PersianDigits = DigitSet{
+		'۰', '۱', '۲', '۳', '۴',
+		'۵', '۶', '۷', '۸', '۹',
+	}
+
+	// DevanagariDigits are the digits used in Hindi and Marathi text.
+	DevanagariDigits = DigitSet{
+		'०', '१', '२', '३', '४',
+		'५', '६', '७', '८', '९',
+	}
+
+	// BengaliDigits are the digits used in Bengali and Assamese text.
+	BengaliDigits = DigitSet{
+		'০', '১', '২', '৩', '৪',
+		'৫', '৬', '৭', '৮', '৯',
+	}
+
+	// ThaiDigits are the digits used in Thai text.
+	ThaiDigits = DigitSet{
+		'๐', '๑', '๒', '๓', '๔',
+		'๕', '๖', '๗', '๘', '๙',
+	}
+)
+
+// SubstituteDigits replaces each ASCII digit in a formatted number with
+// the rune at the same index in digits, leaving other characters unchanged.
+func SubstituteDigits(s string, digits [10]rune) string {
+
+	return strings.Map(func(r rune) rune {
+
+		if r >= '0' && r <= '9' {
+
+			return digits[r-'0']
+		}
+
+		return r
+
+	}, s)
+}
+
+// IsolateLTR wraps a formatted number in a left-to-right isolate, so that
+// its sign, digits and separators are laid out as one left-to-right unit
+// when it is embedded in right-to-left text.
+func IsolateLTR(s string) string {
+
+	return LeftToRightIsolate + s + PopDirectionalIsolate
+}
+
+// MarkLTR surrounds a formatted number with left-to-right marks. It is an
+// alternative to IsolateLTR for renderers without support for isolates.
+func MarkLTR(s string) string {
+
+	return LeftToRightMark + s + LeftToRightMark
+}
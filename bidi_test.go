@@ -0,0 +1,87 @@
+package decimals
+
+import (
+	"testing"
+)
+
+// Test SubstituteDigits with a range of digit sets
+func TestSubstituteDigits(t *testing.T) {
+
+	inputs := []string{"-5,555.12", "-5,555.12", "abc", ""}
+
+	digits := [][10]rune{EasternArabicDigits, PersianDigits, EasternArabicDigits, PersianDigits}
+
+	expected := []string{"-٥,٥٥٥.١٢", "-۵,۵۵۵.۱۲", "abc", ""}
+
+	for i, s := range inputs {
+
+		output := SubstituteDigits(s, digits[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing SubstituteDigits",
+				expected[i], output)
+		}
+	}
+}
+
+// Test a formatter's Digits replace the digits of formatted numbers
+func TestFormatterDigits(t *testing.T) {
+
+	var (
+		fa = Formatter{
+			GroupSeparator:   ArabicThousandsSeparator,
+			DecimalSeparator: ArabicDecimalSeparator,
+			Digits:           PersianDigits,
+		}
+		th = Formatter{GroupSeparator: ",", DecimalSeparator: ".", Digits: ThaiDigits, Template: "฿{}"}
+	)
+
+	inputs := []string{
+		fa.FormatFloat(-1234.5, 2),
+		fa.FormatPercent(0.125, 1),
+		th.FormatFloat(1234.5, 2),
+		th.FormatInt(90, 0),
+		Formatter{Digits: BengaliDigits}.FormatFloat(0.5, 1),
+		FormatFloatOpt(1234.5, WithDigits(DevanagariDigits), WithPrecision(1)),
+	}
+
+	expected := []string{
+		"-۱٬۲۳۴٫۵۰",
+		"۱۲٫۵%",
+		"฿๑,๒๓๔.๕๐",
+		"฿๙๐",
+		"০.৫",
+		"१,२३४.५",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Digits", expected[i], output)
+		}
+	}
+
+	// Numbers formatted with a digit set parse back to the same value
+	if r, err := ParseFloat(inputs[0], ParseStrict); err != nil || r != -1234.5 {
+
+		t.Errorf("Expected: %v but received: %v (%v) parsing %q", -1234.5, r, err, inputs[0])
+	}
+}
+
+// Test IsolateLTR and MarkLTR wrap the number
+func TestDirectionalMarks(t *testing.T) {
+
+	if output := IsolateLTR("-5.5"); output != "\u2066-5.5\u2069" {
+
+		t.Errorf("Expected: %q but received: %q testing IsolateLTR",
+			"\u2066-5.5\u2069", output)
+	}
+
+	if output := MarkLTR("-5.5"); output != "\u200E-5.5\u200E" {
+
+		t.Errorf("Expected: %q but received: %q testing MarkLTR",
+			"\u200E-5.5\u200E", output)
+	}
+}
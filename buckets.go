@@ -0,0 +1,90 @@
+package decimals
+
+import (
+	"math"
+)
+
+// BucketStyle selects how FormatBucketLabels writes a bucket's bounds.
+type BucketStyle int
+
+const (
+	// BucketInterval writes half-open intervals, as in "[1.0, 2.5)".
+	BucketInterval BucketStyle = iota
+
+	// BucketRange writes ranges joined by an en dash, as in "1.0–2.5".
+	BucketRange
+)
+
+// FormatBucketBounds formats the bounds of a histogram bucket as a
+// half-open interval, as in "[1.0, 2.5)", with both bounds rounded to the
+// same precision. An infinite lower or upper bound is written as "-∞" or
+// "∞" and excluded from the interval.
+func FormatBucketBounds(lo, hi float64, precision int) string {
+
+	return formatBucket(lo, hi, precision, BucketInterval)
+}
+
+// FormatBucketLabels formats labels for the buckets between consecutive
+// bounds, which must be in ascending order, so that every label in a set
+// uses the same precision and style. The lowest and highest bounds may be
+// infinite for open-ended buckets, which are written in the range style as
+// "<1.0" and "5.0+". There is one label fewer than there are bounds.
+func FormatBucketLabels(bounds []float64, precision int, style BucketStyle) []string {
+
+	if len(bounds) < 2 {
+
+		return nil
+	}
+
+	labels := make([]string, len(bounds)-1)
+
+	for i := range labels {
+
+		labels[i] = formatBucket(bounds[i], bounds[i+1], precision, style)
+	}
+
+	return labels
+}
+
+// formatBucket formats the bounds of one bucket in the given style.
+func formatBucket(lo, hi float64, precision int, style BucketStyle) string {
+
+	if style == BucketRange {
+
+		switch {
+
+		case math.IsInf(lo, -1):
+
+			return "<" + FormatFloat(hi, precision)
+
+		case math.IsInf(hi, 1):
+
+			return FormatFloat(lo, precision) + "+"
+		}
+
+		return FormatFloat(lo, precision) + "–" + FormatFloat(hi, precision)
+	}
+
+	var (
+		open  = "["
+		close = ")"
+		los   = "-∞"
+		his   = "∞"
+	)
+
+	if math.IsInf(lo, -1) {
+
+		open = "("
+
+	} else {
+
+		los = FormatFloat(lo, precision)
+	}
+
+	if !math.IsInf(hi, 1) {
+
+		his = FormatFloat(hi, precision)
+	}
+
+	return open + los + ", " + his + close
+}
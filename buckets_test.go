@@ -0,0 +1,75 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatBucketBounds with a range of bounds
+func TestFormatBucketBounds(t *testing.T) {
+
+	inputs := [][2]float64{
+		{1, 2.5},
+		{-2.5, 0},
+		{1000, 2500},
+		{math.Inf(-1), 1},
+		{5, math.Inf(1)},
+	}
+
+	expected := []string{
+		"[1.0, 2.5)",
+		"[-2.5, 0.0)",
+		"[1,000.0, 2,500.0)",
+		"(-∞, 1.0)",
+		"[5.0, ∞)",
+	}
+
+	for i, n := range inputs {
+
+		output := FormatBucketBounds(n[0], n[1], 1)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatBucketBounds(%v, %v, 1)",
+				expected[i], output, n[0], n[1])
+		}
+	}
+}
+
+// Test FormatBucketLabels with each style
+func TestFormatBucketLabels(t *testing.T) {
+
+	bounds := []float64{math.Inf(-1), 1, 2.5, 5, math.Inf(1)}
+
+	styles := []BucketStyle{BucketInterval, BucketRange}
+
+	expected := [][]string{
+		{"(-∞, 1.0)", "[1.0, 2.5)", "[2.5, 5.0)", "[5.0, ∞)"},
+		{"<1.0", "1.0–2.5", "2.5–5.0", "5.0+"},
+	}
+
+	for i, style := range styles {
+
+		output := FormatBucketLabels(bounds, 1, style)
+
+		if len(output) != len(expected[i]) {
+
+			t.Fatalf("Expected: %q but received: %q testing FormatBucketLabels",
+				expected[i], output)
+		}
+
+		for j := range output {
+
+			if output[j] != expected[i][j] {
+
+				t.Errorf("Expected: %q but received: %q testing FormatBucketLabels",
+					expected[i][j], output[j])
+			}
+		}
+	}
+
+	if output := FormatBucketLabels([]float64{1}, 1, BucketRange); output != nil {
+
+		t.Errorf("Expected: nil but received: %q testing FormatBucketLabels", output)
+	}
+}
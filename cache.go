@@ -0,0 +1,141 @@
+package decimals
+
+import (
+	"container/list"
+	"math"
+	"sync"
+)
+
+// A CachedFormatter memoizes the output of a Formatter's FormatFloat in a
+// least recently used cache, for workloads that format the same small set
+// of values repeatedly, such as price levels and thresholds. Values are
+// keyed by their exact bits and precision, so a cached string is always
+// the one FormatFloat would return. It is safe for concurrent use.
+type CachedFormatter struct {
+	mu        sync.Mutex
+	formatter Formatter
+	size      int
+	entries   map[cacheKey]*list.Element
+	order     *list.List
+	stats     CacheStats
+}
+
+// cacheKey identifies a formatted value in a CachedFormatter
+type cacheKey struct {
+	bits      uint64
+	precision int
+}
+
+// cacheEntry holds a formatted value in a CachedFormatter's order list
+type cacheEntry struct {
+	key cacheKey
+	s   string
+}
+
+// CacheStats reports the use of a CachedFormatter's cache.
+type CacheStats struct {
+	Hits      uint64 // calls answered from the cache
+	Misses    uint64 // calls that formatted the value
+	Evictions uint64 // entries removed to make room for new ones
+	Len       int    // entries currently cached
+}
+
+// HitRate returns the fraction of calls answered from the cache, or zero
+// if there have been no calls.
+func (s CacheStats) HitRate() float64 {
+
+	if s.Hits+s.Misses == 0 {
+
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// NewCachedFormatter returns a CachedFormatter for f that holds up to size
+// formatted values. A size less than one disables the cache, so every call
+// is formatted and counted as a miss.
+func NewCachedFormatter(f Formatter, size int) *CachedFormatter {
+
+	return &CachedFormatter{
+		formatter: f,
+		size:      size,
+		entries:   make(map[cacheKey]*list.Element),
+		order:     list.New(),
+	}
+}
+
+// FormatFloat returns f.FormatFloat(x, precision) for the cached formatter
+// f, from the cache if it holds the value.
+func (c *CachedFormatter) FormatFloat(x float64, precision int) string {
+
+	key := cacheKey{math.Float64bits(x), precision}
+
+	c.mu.Lock()
+
+	if e, ok := c.entries[key]; ok {
+
+		c.order.MoveToFront(e)
+		c.stats.Hits++
+		c.mu.Unlock()
+
+		return e.Value.(*cacheEntry).s
+	}
+
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	// Format outside the lock so that misses do not serialize
+	s := c.formatter.FormatFloat(x, precision)
+
+	if c.size < 1 {
+
+		return s
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another caller may have added the value meanwhile
+	if e, ok := c.entries[key]; ok {
+
+		c.order.MoveToFront(e)
+		return s
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key, s})
+
+	// Evict the least recently used entry
+	if c.order.Len() > c.size {
+
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+
+	return s
+}
+
+// Stats returns the cache's statistics.
+func (c *CachedFormatter) Stats() CacheStats {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Len = c.order.Len()
+
+	return s
+}
+
+// Reset empties the cache and clears its statistics.
+func (c *CachedFormatter) Reset() {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[cacheKey]*list.Element)
+	c.order.Init()
+	c.stats = CacheStats{}
+}
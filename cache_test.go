@@ -0,0 +1,93 @@
+package decimals
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+// Test CachedFormatter matches FormatFloat and evicts the least recently
+// used value
+func TestCachedFormatter(t *testing.T) {
+
+	var (
+		f = Formatter{GroupSeparator: ",", Template: "${}"}
+		c = NewCachedFormatter(f, 2)
+	)
+
+	inputs := []float64{1234.5, 1234.5, 99.99, 1234.5, 0.5, 99.99, math.Copysign(0, -1), 0}
+
+	for _, x := range inputs {
+
+		if output, expected := c.FormatFloat(x, 2), f.FormatFloat(x, 2); output != expected {
+
+			t.Errorf("Expected: %q but received: %q testing CachedFormatter.FormatFloat(%v, 2)", expected, output, x)
+		}
+	}
+
+	// 1234.5 hits twice before 0.5 evicts 99.99, which is then formatted
+	// again, and -0 and 0 have different bits
+	expected := CacheStats{Hits: 2, Misses: 6, Evictions: 4, Len: 2}
+
+	if stats := c.Stats(); stats != expected || stats.HitRate() != 0.25 {
+
+		t.Errorf("Expected: %+v but received: %+v testing CachedFormatter.Stats", expected, stats)
+	}
+
+	c.Reset()
+
+	if stats := c.Stats(); stats != (CacheStats{}) || stats.HitRate() != 0 {
+
+		t.Errorf("Expected: %+v but received: %+v testing CachedFormatter.Reset", CacheStats{}, stats)
+	}
+}
+
+// Test a CachedFormatter without room caches nothing
+func TestCachedFormatterDisabled(t *testing.T) {
+
+	c := NewCachedFormatter(DefaultFormatter(), 0)
+	c.FormatFloat(1, 0)
+	c.FormatFloat(1, 0)
+
+	if stats := c.Stats(); stats != (CacheStats{Misses: 2}) {
+
+		t.Errorf("Expected: %+v but received: %+v testing CachedFormatter with size 0", CacheStats{Misses: 2}, stats)
+	}
+}
+
+// Test CachedFormatter is safe for concurrent use
+func TestCachedFormatterConcurrency(t *testing.T) {
+
+	var (
+		c  = NewCachedFormatter(DefaultFormatter(), 8)
+		wg sync.WaitGroup
+	)
+
+	for g := 0; g < 8; g++ {
+
+		wg.Add(1)
+
+		go func(g int) {
+
+			defer wg.Done()
+
+			for i := 0; i < 1000; i++ {
+
+				x := float64((g + i) % 16)
+
+				if output, expected := c.FormatFloat(x, 1), FormatFloat(x, 1); output != expected {
+
+					t.Errorf("Expected: %q but received: %q testing concurrent CachedFormatter", expected, output)
+					return
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	if stats := c.Stats(); stats.Hits+stats.Misses != 8000 || stats.Len > 8 {
+
+		t.Errorf("Expected: 8000 calls and at most 8 entries but received: %+v testing concurrent CachedFormatter", stats)
+	}
+}
@@ -0,0 +1,85 @@
+package decimals
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A CheckStyle describes how amounts are written in words on checks and
+// contracts in a jurisdiction. The major units are written in words and
+// the minor units as a fraction, such as "25/100".
+type CheckStyle struct {
+	HundredsAnd bool   // write "one hundred and five", as in British usage
+	UnitFirst   bool   // name the currency before the fraction
+	Suffix      string // text appended to the amount, such as " only"
+}
+
+var (
+	// USCheckStyle writes amounts as on checks in the United States, as in
+	// "One thousand and 25/100 dollars".
+	USCheckStyle = CheckStyle{}
+
+	// UKCheckStyle writes amounts as on cheques in the United Kingdom, as in
+	// "One thousand and five pounds and 25/100 only".
+	UKCheckStyle = CheckStyle{HundredsAnd: true, UnitFirst: true, Suffix: " only"}
+)
+
+// FormatCheckAmount writes an amount in words using USCheckStyle. See
+// CheckStyle.FormatCheckAmount.
+func FormatCheckAmount(amount float64, currency string) (string, error) {
+
+	return USCheckStyle.FormatCheckAmount(amount, currency)
+}
+
+// FormatCheckAmount writes an amount in the currency with the given ISO
+// 4217 code in words, for checks and contracts. The amount is rounded half
+// up to the currency's minor unit, the major units are written in words
+// with an initial capital and the minor units as a fraction of the major
+// unit, so 1000.25 in USD is "One thousand and 25/100 dollars". Currencies
+// with no minor unit have no fraction. An error is returned if the code is
+// not known to LookupCurrency, and an error wrapping ErrRange if the
+// amount is negative, NaN, infinite or too large to write in words.
+func (s CheckStyle) FormatCheckAmount(amount float64, currency string) (string, error) {
+
+	c, ok := LookupCurrency(currency)
+
+	if !ok {
+
+		return "", fmt.Errorf("decimals: unknown currency %q", currency)
+	}
+
+	d, err := DecimalFromFloat(amount)
+
+	if err != nil || d.Sign() < 0 {
+
+		return "", &NumError{"FormatCheckAmount", strconv.FormatFloat(amount, 'g', -1, 64), ErrRange}
+	}
+
+	d = d.Round(c.Exponent, HalfUp)
+	is, fs := d.parts(c.Exponent)
+	major, err := strconv.ParseUint(is, 10, 64)
+
+	if err != nil {
+
+		return "", &NumError{"FormatCheckAmount", strconv.FormatFloat(amount, 'g', -1, 64), ErrRange}
+	}
+
+	// Capitalize the words for the major units
+	words := intWords(major, s.HundredsAnd)
+	words = strings.ToUpper(words[:1]) + words[1:]
+
+	if c.Exponent == 0 {
+
+		return words + " " + c.Name + s.Suffix, nil
+	}
+
+	fraction := fs + "/1" + strings.Repeat("0", c.Exponent)
+
+	if s.UnitFirst {
+
+		return words + " " + c.Name + " and " + fraction + s.Suffix, nil
+	}
+
+	return words + " and " + fraction + " " + c.Name + s.Suffix, nil
+}
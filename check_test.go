@@ -0,0 +1,65 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Test FormatCheckAmount in each style and currency
+func TestFormatCheckAmount(t *testing.T) {
+
+	type checkInput struct {
+		style    CheckStyle
+		amount   float64
+		currency string
+	}
+
+	inputs := []checkInput{
+		{USCheckStyle, 1000.25, "USD"},
+		{USCheckStyle, 0.5, "usd"},
+		{USCheckStyle, 42.999, "EUR"},
+		{USCheckStyle, 1500, "JPY"},
+		{USCheckStyle, 12.5, "BHD"},
+		{UKCheckStyle, 1105.07, "GBP"},
+		{UKCheckStyle, 3000, "JPY"},
+	}
+
+	expected := []string{
+		"One thousand and 25/100 dollars",
+		"Zero and 50/100 dollars",
+		"Forty-three and 00/100 euros",
+		"One thousand five hundred yen",
+		"Twelve and 500/1000 dinars",
+		"One thousand one hundred and five pounds and 07/100 only",
+		"Three thousand yen only",
+	}
+
+	for i, input := range inputs {
+
+		output, err := input.style.FormatCheckAmount(input.amount, input.currency)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing FormatCheckAmount(%v, %q)",
+				expected[i], output, err, input.amount, input.currency)
+		}
+	}
+}
+
+// Test FormatCheckAmount rejects unknown currencies and invalid amounts
+func TestFormatCheckAmountErrors(t *testing.T) {
+
+	if _, err := FormatCheckAmount(1, "XYZ"); err == nil {
+
+		t.Errorf("Expected: error but received: nil testing FormatCheckAmount(1, \"XYZ\")")
+	}
+
+	for _, amount := range []float64{-1, math.NaN(), math.Inf(1), 1e20} {
+
+		if _, err := FormatCheckAmount(amount, "USD"); !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: ErrRange but received: %v testing FormatCheckAmount(%v, \"USD\")", err, amount)
+		}
+	}
+}
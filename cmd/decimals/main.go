@@ -0,0 +1,139 @@
+/*
+Command decimals rounds and formats numbers read from standard input, one
+per line, so the behaviour of the decimals package can be used in shell
+pipelines and checked interactively.
+
+Usage:
+
+	decimals [flags] < numbers
+
+The flags are:
+
+	-precision n
+		the precision to round to, as for decimals.RoundFloat (default 2)
+	-mode name
+		the rounding mode, such as half-even (default half-up)
+	-locale tag
+		format with the separators of a locale, such as de-DE
+	-compact
+		format in compact notation, such as 1.2M
+	-round
+		print the rounded number without separators
+
+Input is parsed leniently, so it may contain thousands separators,
+underscores, exponents and surrounding whitespace. Numbers are read and
+formatted as decimals, so every digit is kept. Blank lines are skipped.
+Lines that are not numbers are reported on standard error and the
+command exits with status 1 once the input has been read.
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/olihawkins/decimals"
+)
+
+func main() {
+
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments and streams, and
+// returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	var (
+		flags     = flag.NewFlagSet("decimals", flag.ContinueOnError)
+		precision = flags.Int("precision", 2, "the precision to round to")
+		modeName  = flags.String("mode", "half-up", "the rounding mode")
+		locale    = flags.String("locale", "", "the locale whose separators are used")
+		compact   = flags.Bool("compact", false, "format in compact notation")
+		round     = flags.Bool("round", false, "print the rounded number without separators")
+		mode      decimals.RoundingMode
+		f         = decimals.DefaultFormatter()
+		status    int
+	)
+
+	flags.SetOutput(stderr)
+
+	if err := flags.Parse(args); err != nil {
+
+		return 2
+	}
+
+	if err := mode.UnmarshalText([]byte(*modeName)); err != nil {
+
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if *locale != "" {
+
+		var err error
+
+		if f, err = decimals.NewFormatter(*locale); err != nil {
+
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+
+	if *compact && mode != decimals.HalfUp {
+
+		fmt.Fprintln(stderr, "decimals: -compact supports only the half-up mode")
+		return 2
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	scanner := bufio.NewScanner(stdin)
+
+	for scanner.Scan() {
+
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+
+			continue
+		}
+
+		d, err := decimals.ParseDecimal(line, decimals.ParseLenient)
+
+		if err != nil {
+
+			fmt.Fprintln(stderr, err)
+			status = 1
+			continue
+		}
+
+		switch {
+
+		case *round:
+
+			fmt.Fprintln(out, d.Round(*precision, mode))
+
+		case *compact:
+
+			fmt.Fprintln(out, f.FormatCompactDecimal(d, *precision))
+
+		default:
+
+			fmt.Fprintln(out, f.FormatDecimalMode(d, *precision, mode))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return status
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Test run with a range of flags
+func TestRun(t *testing.T) {
+
+	input := "1234.565\n\n-2.5\n 1_000_000 \n"
+
+	inputs := [][]string{
+		{},
+		{"-precision", "0", "-mode", "half-even"},
+		{"-locale", "de-DE", "-precision", "1"},
+		{"-compact", "-precision", "1"},
+		{"-round", "-precision", "-2"},
+	}
+
+	expected := []string{
+		"1,234.57\n-2.50\n1,000,000.00\n",
+		"1,235\n-2\n1,000,000\n",
+		"1.234,6\n-2,5\n1.000.000,0\n",
+		"1.2K\n-2.5\n1.0M\n",
+		"1200\n0\n1000000\n",
+	}
+
+	for i, args := range inputs {
+
+		var stdout, stderr bytes.Buffer
+
+		status := run(args, strings.NewReader(input), &stdout, &stderr)
+
+		if status != 0 || stdout.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%d, %q) testing run(%q)",
+				expected[i], stdout.String(), status, stderr.String(), args)
+		}
+	}
+}
+
+// Test run keeps every digit of numbers too long for a float64 in each
+// mode
+func TestRunLongNumbers(t *testing.T) {
+
+	input := "12345678901234567.89\n-98765432109876543210.5\n"
+
+	inputs := [][]string{
+		{},
+		{"-mode", "down", "-precision", "0"},
+		{"-compact", "-precision", "3"},
+		{"-round"},
+	}
+
+	expected := []string{
+		"12,345,678,901,234,567.89\n-98,765,432,109,876,543,210.50\n",
+		"12,345,678,901,234,567\n-98,765,432,109,876,543,210\n",
+		"12,345.679T\n-98,765,432.110T\n",
+		"12345678901234567.89\n-98765432109876543210.5\n",
+	}
+
+	for i, args := range inputs {
+
+		var stdout, stderr bytes.Buffer
+
+		status := run(args, strings.NewReader(input), &stdout, &stderr)
+
+		if status != 0 || stdout.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%d, %q) testing run(%q)",
+				expected[i], stdout.String(), status, stderr.String(), args)
+		}
+	}
+}
+
+// Test run reports invalid input and flags
+func TestRunErrors(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+
+	if status := run(nil, strings.NewReader("5\nabc\n6\n"), &stdout, &stderr); status != 1 ||
+		stdout.String() != "5.00\n6.00\n" || !strings.Contains(stderr.String(), "abc") {
+
+		t.Errorf("Expected: status 1 but received: %d (%q, %q) testing run with invalid input",
+			status, stdout.String(), stderr.String())
+	}
+
+	inputs := [][]string{
+		{"-mode", "sideways"},
+		{"-locale", "xx-XX"},
+		{"-compact", "-mode", "floor"},
+		{"-unknown"},
+	}
+
+	for _, args := range inputs {
+
+		if status := run(args, strings.NewReader(""), &stdout, &stderr); status != 2 {
+
+			t.Errorf("Expected: status 2 but received: %d testing run(%q)", status, args)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package decimals
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A RoundingVector is a test of rounding an operand to a number of
+// significant digits, from a file in the decTest format of the General
+// Decimal Arithmetic test suite, whose rounding rules are those of IEEE
+// 754 decimal arithmetic.
+type RoundingVector struct {
+	ID        string       // the test identifier, such as "rounx101"
+	Operand   string       // the number to round, such as "12345.5"
+	Precision int          // the number of significant digits to keep
+	Mode      RoundingMode // the rounding mode
+	Expected  string       // the result in scientific string form
+}
+
+// A RoundingMismatch is a rounding vector whose result differs from the
+// result of this package.
+type RoundingMismatch struct {
+	Vector   RoundingVector
+	Received string
+}
+
+// ReadRoundingVectors reads the rounding vectors of a file in the decTest
+// format, such as rounding.decTest from the General Decimal Arithmetic
+// test suite, for checking with VerifyRoundingVectors. The precision: and
+// rounding: directives set the context of the tests that follow them, and
+// tests of the toSci and plus operations are read. Other operations and
+// directives, and tests that a Decimal cannot represent, are skipped:
+// those in the 05up rounding mode, of NaNs and infinities, of signed zeros
+// and zeros with positive exponents, and those whose conditions include
+// overflow, underflow, clamping or subnormal results. Conditions are
+// otherwise ignored. An error is returned if a line cannot be parsed.
+func ReadRoundingVectors(r io.Reader) ([]RoundingVector, error) {
+
+	var (
+		vectors   []RoundingVector
+		precision = 9
+		mode      = HalfUp
+		supported = true
+		scanner   = bufio.NewScanner(r)
+	)
+
+	for scanner.Scan() {
+
+		line := scanner.Text()
+
+		// Remove comments
+		if i := strings.Index(line, "--"); i >= 0 {
+
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+
+		if len(fields) == 0 {
+
+			continue
+		}
+
+		// Directives set the context for the tests that follow
+		if strings.HasSuffix(fields[0], ":") {
+
+			if len(fields) != 2 {
+
+				return nil, fmt.Errorf("decimals: invalid decTest directive %q", line)
+			}
+
+			switch strings.ToLower(strings.TrimSuffix(fields[0], ":")) {
+
+			case "precision":
+
+				p, err := strconv.Atoi(fields[1])
+
+				if err != nil || p < 1 {
+
+					return nil, fmt.Errorf("decimals: invalid decTest precision %q", line)
+				}
+
+				precision = p
+
+			case "rounding":
+
+				name := strings.ReplaceAll(fields[1], "_", "-")
+				supported = mode.UnmarshalText([]byte(name)) == nil
+			}
+
+			continue
+		}
+
+		// Tests are written as "id operation operand -> result conditions"
+		if len(fields) < 5 || fields[3] != "->" {
+
+			continue
+		}
+
+		operation := strings.ToLower(fields[1])
+
+		if !supported || operation != "tosci" && operation != "plus" {
+
+			continue
+		}
+
+		v := RoundingVector{
+			ID:        fields[0],
+			Operand:   unquoteDecTest(fields[2]),
+			Precision: precision,
+			Mode:      mode,
+			Expected:  unquoteDecTest(fields[4]),
+		}
+
+		if representable(v.Operand) && representable(v.Expected) && !exceptional(fields[5:]) {
+
+			vectors = append(vectors, v)
+		}
+	}
+
+	return vectors, scanner.Err()
+}
+
+// VerifyRoundingVectors rounds the operand of each vector to its precision
+// in its mode and returns the vectors whose result, written in scientific
+// string form, differs from the expected result, in order. Operands that
+// cannot be parsed are reported with an empty result.
+func VerifyRoundingVectors(vectors []RoundingVector) []RoundingMismatch {
+
+	var mismatches []RoundingMismatch
+
+	for _, v := range vectors {
+
+		var received string
+
+		if d, err := ParseDecimal(v.Operand, ParseExponent|ParsePlus); err == nil {
+
+			received = toScientificString(roundDigitCount(d, v.Precision, v.Mode))
+		}
+
+		if received != v.Expected {
+
+			mismatches = append(mismatches, RoundingMismatch{v, received})
+		}
+	}
+
+	return mismatches
+}
+
+// roundDigitCount returns d rounded to no more than the given number of
+// significant digits using the given rounding mode, keeping trailing zeros
+// as IEEE 754 decimal arithmetic does.
+func roundDigitCount(d Decimal, precision int, mode RoundingMode) Decimal {
+
+	if len(d.digits) <= precision {
+
+		return d
+	}
+
+	adjusted := len(d.digits) + d.exponent - 1
+	r := d.Round(precision-1-adjusted, mode)
+
+	// Drop the zero added if rounding carried into a new digit
+	if extra := len(r.digits) - precision; extra > 0 {
+
+		r = newDecimal(r.negative, r.digits[:precision], r.exponent+extra)
+	}
+
+	return r
+}
+
+// toScientificString returns d in the scientific string form of the
+// General Decimal Arithmetic specification, in which exponents above zero
+// or adjusted exponents below -6 are written in E notation, as in
+// "1.2345E+5".
+func toScientificString(d Decimal) string {
+
+	digits := d.digits
+
+	if digits == "" {
+
+		digits = "0"
+	}
+
+	adjusted := d.exponent + len(digits) - 1
+
+	if d.exponent <= 0 && adjusted >= -6 {
+
+		return d.String()
+	}
+
+	s := digits[:1]
+
+	if len(digits) > 1 {
+
+		s += "." + digits[1:]
+	}
+
+	if adjusted >= 0 {
+
+		s += "E+" + strconv.Itoa(adjusted)
+
+	} else {
+
+		s += "E" + strconv.Itoa(adjusted)
+	}
+
+	if d.negative {
+
+		return "-" + s
+	}
+
+	return s
+}
+
+// unquoteDecTest removes the quotes around a decTest operand, if any.
+func unquoteDecTest(s string) string {
+
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+// representable reports whether a decTest number is one that a Decimal
+// holds as it is written.
+func representable(s string) bool {
+
+	d, err := ParseDecimal(s, ParseExponent|ParsePlus)
+
+	if err != nil {
+
+		return false
+	}
+
+	// Zeros lose their sign and any positive exponent
+	return d.digits != "" || !strings.HasPrefix(s, "-") && toScientificString(d) == strings.TrimPrefix(s, "+")
+}
+
+// exceptional reports whether the conditions of a decTest test include one
+// that depends on the exponent limits of the context.
+func exceptional(conditions []string) bool {
+
+	for _, c := range conditions {
+
+		switch strings.ToLower(c) {
+
+		case "overflow", "underflow", "clamped", "subnormal":
+
+			return true
+		}
+	}
+
+	return false
+}
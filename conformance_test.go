@@ -0,0 +1,68 @@
+package decimals
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test rounding matches the vectors in testdata
+func TestRoundingVectors(t *testing.T) {
+
+	f, err := os.Open(filepath.Join("testdata", "rounding.decTest"))
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	vectors, err := ReadRoundingVectors(f)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	// The tests from rouv801 on are skipped
+	if len(vectors) != 38 || vectors[len(vectors)-1].ID != "rouv707" {
+
+		t.Errorf("Expected: 38 vectors but received: %d testing ReadRoundingVectors", len(vectors))
+	}
+
+	for _, m := range VerifyRoundingVectors(vectors) {
+
+		t.Errorf("Expected: %q but received: %q testing rounding vector %s", m.Vector.Expected, m.Received, m.Vector.ID)
+	}
+}
+
+// Test VerifyRoundingVectors reports mismatches and ReadRoundingVectors
+// reports invalid directives
+func TestVerifyRoundingVectors(t *testing.T) {
+
+	saved := "precision: 2\nrounding: half_up\nt1 toSci 2.55 -> 2.5\nt2 plus 2.45 -> 2.5\nt3 toSci 1x -> 1x\n"
+	vectors, err := ReadRoundingVectors(strings.NewReader(saved))
+
+	if err != nil || len(vectors) != 2 {
+
+		t.Fatalf("Expected: 2 vectors but received: %v (%v) testing ReadRoundingVectors", vectors, err)
+	}
+
+	expected := []RoundingMismatch{{RoundingVector{"t1", "2.55", 2, HalfUp, "2.5"}, "2.6"}}
+	mismatches := VerifyRoundingVectors(vectors)
+
+	if len(mismatches) != len(expected) || mismatches[0] != expected[0] {
+
+		t.Errorf("Expected: %v but received: %v testing VerifyRoundingVectors", expected, mismatches)
+	}
+
+	for _, s := range []string{"precision: x\n", "precision: 0\n", "rounding:\n"} {
+
+		if _, err := ReadRoundingVectors(strings.NewReader(s)); err == nil {
+
+			t.Errorf("Expected: an error but received: nil testing ReadRoundingVectors(%q)", s)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package decimals
+
+import (
+	"strings"
+)
+
+// A UnitConversion converts a value to another unit by a fixed ratio.
+type UnitConversion struct {
+
+	// Unit is the unit converted to, placed as Quantity.Unit is.
+	Unit string
+
+	// Factor is the number of these units in one unit of the value, such
+	// as 1.609344 for kilometres from miles.
+	Factor float64
+}
+
+// A ConversionTable describes a readout of a value in its own unit and a
+// set of others, such as "2.5 mi = 4.02 km = 4,023 m", for measurement
+// apps and documentation.
+type ConversionTable struct {
+
+	// Unit is the unit of the value.
+	Unit string
+
+	// Conversions are the units the value is converted to, in the order
+	// they are shown.
+	Conversions []UnitConversion
+
+	// Breakpoints and SignificantDigits are the precision policy shared by
+	// every converted value, as used by FormatAdaptiveWith. If Breakpoints
+	// is nil DefaultBreakpoints are used, and if SignificantDigits is zero
+	// DefaultSignificantDigits is used.
+	Breakpoints       []Breakpoint
+	SignificantDigits int
+}
+
+// Format formats the readout of x using the default formatter. See
+// Formatter.FormatConversions.
+func (t ConversionTable) Format(x float64) string {
+
+	return DefaultFormatter().FormatConversions(x, t)
+}
+
+// FormatConversions formats x in the table's unit followed by x converted
+// to each of its units, joined by " = ". The value itself is shown with
+// all of its shortest decimal digits, and each converted value is rounded
+// to the precision chosen by the table's policy for its magnitude, so
+// with the default policy 2.5 mi is "2.5 mi = 4.02 km = 4,023 m".
+func (f Formatter) FormatConversions(x float64, t ConversionTable) string {
+
+	var (
+		breakpoints = t.Breakpoints
+		significant = t.SignificantDigits
+		places      int
+	)
+
+	if breakpoints == nil {
+
+		breakpoints = DefaultBreakpoints
+	}
+
+	if significant == 0 {
+
+		significant = DefaultSignificantDigits
+	}
+
+	if d, err := DecimalFromFloat(x); err == nil && d.exponent < 0 {
+
+		places = -d.exponent
+	}
+
+	readout := []string{f.FormatQuantity(Quantity{Value: x, Unit: t.Unit}, places)}
+
+	for _, c := range t.Conversions {
+
+		y := x * c.Factor
+		q := Quantity{Value: y, Unit: c.Unit}
+		readout = append(readout, f.FormatQuantity(q, adaptivePrecision(y, breakpoints, significant)))
+	}
+
+	return strings.Join(readout, expressionEquals)
+}
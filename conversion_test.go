@@ -0,0 +1,57 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test ConversionTable.Format with a range of values and policies
+func TestConversionTableFormat(t *testing.T) {
+
+	var (
+		distance = ConversionTable{
+			Unit: "mi",
+			Conversions: []UnitConversion{
+				{"km", 1.609344},
+				{"m", 1609.344},
+			},
+		}
+		mass = ConversionTable{
+			Unit: "kg",
+			Conversions: []UnitConversion{
+				{"lb", 2.20462262},
+				{"{} g", 1000},
+			},
+			Breakpoints:       []Breakpoint{{100, 0}},
+			SignificantDigits: 2,
+		}
+	)
+
+	inputs := []string{
+		distance.Format(2.5),
+		distance.Format(-1),
+		distance.Format(0.0001),
+		distance.Format(math.Inf(1)),
+		mass.Format(0.25),
+		mass.Format(12),
+		ConversionTable{Unit: "m"}.Format(1000),
+	}
+
+	expected := []string{
+		"2.5 mi = 4.02 km = 4,023 m",
+		"-1 mi = -1.61 km = -1,609 m",
+		"0.0001 mi = 0.000161 km = 0.161 m",
+		"Inf mi = Inf km = Inf m",
+		"0.25 kg = 0.55 lb = 250 g",
+		"12 kg = 26 lb = 12,000 g",
+		"1,000 m",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing ConversionTable.Format", expected[i], output)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package decimals
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A Currency describes how amounts in a currency are displayed.
+type Currency struct {
+	Code     string // the ISO 4217 code, such as "USD"
+	Symbol   string // the display symbol, such as "$"
+	Exponent int    // the number of decimal places in the minor unit
+	Name     string // the English name of the major unit, such as "dollars"
+
+	// CompactThreshold is the smallest amount abbreviated by
+	// FormatCompactAmount, such as 10,000 for yen. If it is zero amounts of
+	// a thousand or more are abbreviated.
+	CompactThreshold float64
+}
+
+// Currencies known to LookupCurrency, keyed by ISO 4217 code
+var currencies = map[string]Currency{
+	"USD": {"USD", "$", 2, "dollars", 0},
+	"EUR": {"EUR", "€", 2, "euros", 0},
+	"GBP": {"GBP", "£", 2, "pounds", 0},
+	"JPY": {"JPY", "¥", 0, "yen", 1e4},
+	"CNY": {"CNY", "CN¥", 2, "yuan", 0},
+	"AUD": {"AUD", "A$", 2, "dollars", 0},
+	"CAD": {"CAD", "CA$", 2, "dollars", 0},
+	"CHF": {"CHF", "CHF", 2, "francs", 0},
+	"INR": {"INR", "₹", 2, "rupees", 0},
+	"KRW": {"KRW", "₩", 0, "won", 1e4},
+	"BRL": {"BRL", "R$", 2, "reais", 0},
+	"MXN": {"MXN", "MX$", 2, "pesos", 0},
+	"IDR": {"IDR", "Rp", 2, "rupiah", 1e6},
+	"BHD": {"BHD", "BHD", 3, "dinars", 0},
+	"KWD": {"KWD", "KWD", 3, "dinars", 0},
+}
+
+// LookupCurrency returns the currency with the given ISO 4217 code, matched
+// without regard to case, and reports whether it is known.
+func LookupCurrency(code string) (Currency, bool) {
+
+	c, ok := currencies[strings.ToUpper(code)]
+
+	return c, ok
+}
+
+// FormatAmount formats an amount in a currency using the default
+// formatter. See Formatter.FormatAmount.
+func FormatAmount(x float64, currency string) (string, error) {
+
+	return DefaultFormatter().FormatAmount(x, currency)
+}
+
+// FormatAmount formats an amount in the currency with the given ISO 4217
+// code, rounded half up and padded with zeros to the number of decimal
+// places in its minor unit, so 5 is formatted as "5.00" in USD and "5" in
+// JPY. The currency symbol is not included. An error is returned if the
+// code is not known to LookupCurrency.
+func (f Formatter) FormatAmount(x float64, currency string) (string, error) {
+
+	c, ok := LookupCurrency(currency)
+
+	if !ok {
+
+		return "", fmt.Errorf("decimals: unknown currency %q", currency)
+	}
+
+	return f.FormatFloat(x, c.Exponent), nil
+}
+
+// FormatCents formats an amount in minor units using the default formatter.
+// See Formatter.FormatCents.
+func FormatCents(cents int64, currency string) (string, error) {
+
+	return DefaultFormatter().FormatCents(cents, currency)
+}
+
+// FormatCents formats an amount given as an integer number of minor units,
+// as payment APIs such as Stripe's return amounts, in the currency with
+// the given ISO 4217 code, placing the decimal separator by the exponent
+// of its minor unit, so 1234 is formatted as "12.34" in USD, "1,234" in JPY
+// and "1.234" in BHD. The amount is converted exactly, without a float.
+// The currency symbol is not included. An error is returned if the code is
+// not known to LookupCurrency.
+func (f Formatter) FormatCents(cents int64, currency string) (string, error) {
+
+	c, ok := LookupCurrency(currency)
+
+	if !ok {
+
+		return "", fmt.Errorf("decimals: unknown currency %q", currency)
+	}
+
+	d := newDecimal(cents < 0, strconv.FormatUint(absUint64(cents), 10), -c.Exponent)
+	r, places := f.roundSignificant(d, c.Exponent, HalfUp)
+
+	return f.applyTemplate(f.formatDecimal(r, places)), nil
+}
+
+// FormatDualCurrency formats an amount alongside its conversion at the
+// given rate using the default formatter. See Formatter.FormatDualCurrency.
+func FormatDualCurrency(amount float64, from, to string, rate float64) (string, error) {
+
+	return DefaultFormatter().FormatDualCurrency(amount, from, to, rate)
+}
+
+// FormatDualCurrency formats an amount in the currency from, followed by
+// its approximate value in the currency to at the given exchange rate, as
+// in "€100.00 (≈ $108.45)". Each amount is rounded half up to the exponent
+// of its own currency, and marked by the formatter's sign style, digits
+// and template as FormatCompactAmount does. An error is returned if either
+// currency code is not known to LookupCurrency.
+func (f Formatter) FormatDualCurrency(amount float64, from, to string, rate float64) (string, error) {
+
+	fc, ok := LookupCurrency(from)
+
+	if !ok {
+
+		return "", fmt.Errorf("decimals: unknown currency %q", from)
+	}
+
+	tc, ok := LookupCurrency(to)
+
+	if !ok {
+
+		return "", fmt.Errorf("decimals: unknown currency %q", to)
+	}
+
+	var (
+		a = f.applyTemplate(f.formatCurrency(amount, fc))
+		b = f.applyTemplate(f.formatCurrency(amount*rate, tc))
+	)
+
+	return a + " (≈ " + b + ")", nil
+}
+
+// FormatCompactCurrency formats an amount in compact notation with a
+// currency symbol using the default formatter. See
+// Formatter.FormatCompactCurrency.
+func FormatCompactCurrency(x float64, currency string, precision int) (string, error) {
+
+	return DefaultFormatter().FormatCompactCurrency(x, currency, precision)
+}
+
+// FormatCompactCurrency formats an amount in the currency with the given
+// ISO 4217 code as FormatCompactAmount does. An error is returned if the
+// code is not known to LookupCurrency.
+func (f Formatter) FormatCompactCurrency(x float64, currency string, precision int) (string, error) {
+
+	c, ok := LookupCurrency(currency)
+
+	if !ok {
+
+		return "", fmt.Errorf("decimals: unknown currency %q", currency)
+	}
+
+	return f.FormatCompactAmount(x, c, precision), nil
+}
+
+// FormatCompactAmount formats an amount in compact notation preceded by
+// the currency's symbol, as finance dashboards abbreviate large figures,
+// so 1,234,567 dollars is formatted as "$1.2M" with a precision of one.
+// Amounts are scaled as by FormatCompact and rounded to the precision.
+// Amounts smaller in magnitude than the currency's CompactThreshold are
+// formatted in full, rounded to the currency's minor unit, as in "$950.00".
+func (f Formatter) FormatCompactAmount(x float64, c Currency, precision int) string {
+
+	if p, ok := f.placeholder(x); ok {
+
+		return p
+	}
+
+	threshold := c.CompactThreshold
+
+	if threshold == 0 {
+
+		threshold = 1000
+	}
+
+	if math.IsNaN(x) || math.IsInf(x, 0) || math.Abs(x) < threshold {
+
+		return f.applyTemplate(f.formatCurrency(x, c))
+	}
+
+	r, m := f.scaleMagnitude(x, precision)
+	s := f.formatDecimal(r, precision)
+
+	if m != nil {
+
+		s += m.Symbol
+	}
+
+	return f.applyTemplate(placeSymbol(s, c.Symbol))
+}
+
+// formatCurrency formats an amount rounded to the currency's exponent and
+// preceded by its symbol.
+func (f Formatter) formatCurrency(amount float64, c Currency) string {
+
+	return placeSymbol(f.formatFloat(amount, c.Exponent), c.Symbol)
+}
+
+// placeSymbol places a currency symbol before a formatted number, after
+// any minus sign. Symbols that end in a letter, such as "CHF", are
+// separated from the number by a space.
+func placeSymbol(s string, symbol string) string {
+
+	var sign string
+
+	if strings.HasPrefix(s, "-") {
+
+		s, sign = s[1:], "-"
+	}
+
+	if r, _ := utf8.DecodeLastRuneInString(symbol); unicode.IsLetter(r) {
+
+		symbol += " "
+	}
+
+	return sign + symbol + s
+}
@@ -0,0 +1,210 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test LookupCurrency with known and unknown codes
+func TestLookupCurrency(t *testing.T) {
+
+	if c, ok := LookupCurrency("jpy"); !ok || c.Symbol != "¥" || c.Exponent != 0 {
+
+		t.Errorf("Expected: JPY but received: %+v (%v) testing LookupCurrency", c, ok)
+	}
+
+	if _, ok := LookupCurrency("XYZ"); ok {
+
+		t.Errorf("Expected: false but received: true testing LookupCurrency(\"XYZ\")")
+	}
+}
+
+// Test FormatDualCurrency with a range of currencies and rates
+func TestFormatDualCurrency(t *testing.T) {
+
+	inputs := []struct {
+		amount   float64
+		from, to string
+		rate     float64
+	}{
+		{100, "EUR", "USD", 1.0845},
+		{100, "usd", "JPY", 149.876},
+		{-12.5, "GBP", "CHF", 1.1},
+		{1234.5, "USD", "BHD", 0.377},
+	}
+
+	expected := []string{
+		"€100.00 (≈ $108.45)",
+		"$100.00 (≈ ¥14,988)",
+		"-£12.50 (≈ -CHF 13.75)",
+		"$1,234.50 (≈ BHD 465.407)",
+	}
+
+	for i, n := range inputs {
+
+		output, err := FormatDualCurrency(n.amount, n.from, n.to, n.rate)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing FormatDualCurrency",
+				expected[i], output, err)
+		}
+	}
+
+	if _, err := FormatDualCurrency(1, "EUR", "XYZ", 1); err == nil {
+
+		t.Errorf("Expected: an error but received: nil testing FormatDualCurrency")
+	}
+
+	// Each amount is marked by the formatter's sign style and digits
+	var (
+		f    = Formatter{GroupSeparator: ",", DecimalSeparator: ".", SignStyle: Parentheses, Digits: PersianDigits}
+		dual = "(€۱,۲۳۴.۵۷) (≈ ($۱,۳۵۸.۰۲))"
+	)
+
+	if output, err := f.FormatDualCurrency(-1234.567, "EUR", "USD", 1.1); err != nil || output != dual {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing Formatter.FormatDualCurrency", dual, output, err)
+	}
+}
+
+// Test FormatAmount pads to each currency's exponent
+func TestFormatAmount(t *testing.T) {
+
+	inputs := []float64{5, 5, 1234.5, 0.0005, -12.3456}
+
+	codes := []string{"USD", "JPY", "eur", "BHD", "KWD"}
+
+	expected := []string{"5.00", "5", "1,234.50", "0.001", "-12.346"}
+
+	for i, n := range inputs {
+
+		output, err := FormatAmount(n, codes[i])
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing FormatAmount(%v, %q)",
+				expected[i], output, err, n, codes[i])
+		}
+	}
+
+	if _, err := FormatAmount(5, "XYZ"); err == nil {
+
+		t.Errorf("Expected: an error but received: nil testing FormatAmount(5, \"XYZ\")")
+	}
+}
+
+// Test FormatCents places the decimal separator by each currency's exponent
+func TestFormatCents(t *testing.T) {
+
+	inputs := []int64{1234, 1234, 1234, 5, -5, 0, 123456789, math.MinInt64}
+
+	codes := []string{"USD", "JPY", "BHD", "eur", "USD", "GBP", "KRW", "USD"}
+
+	expected := []string{"12.34", "1,234", "1.234", "0.05", "-0.05", "0.00", "123,456,789", "-92,233,720,368,547,758.08"}
+
+	for i, n := range inputs {
+
+		output, err := FormatCents(n, codes[i])
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing FormatCents(%d, %q)",
+				expected[i], output, err, n, codes[i])
+		}
+	}
+
+	f := Formatter{GroupSeparator: ".", DecimalSeparator: ",", Template: "{} €"}
+
+	if output, _ := f.FormatCents(123456, "EUR"); output != "1.234,56 €" {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.FormatCents", "1.234,56 €", output)
+	}
+
+	if _, err := FormatCents(5, "XYZ"); err == nil {
+
+		t.Errorf("Expected: an error but received: nil testing FormatCents(5, \"XYZ\")")
+	}
+}
+
+// Test FormatCompactCurrency abbreviates amounts above each currency's
+// threshold
+func TestFormatCompactCurrency(t *testing.T) {
+
+	inputs := []struct {
+		amount    float64
+		currency  string
+		precision int
+	}{
+		{1234567, "USD", 1},
+		{950000, "usd", 0},
+		{999999, "USD", 1},
+		{950, "USD", 1},
+		{-2500000, "EUR", 1},
+		{5000, "JPY", 1},
+		{25000, "JPY", 0},
+		{1500000, "CHF", 2},
+		{-999.5, "GBP", 0},
+	}
+
+	expected := []string{
+		"$1.2M",
+		"$950K",
+		"$1.0M",
+		"$950.00",
+		"-€2.5M",
+		"¥5,000",
+		"¥25K",
+		"CHF 1.50M",
+		"-£999.50",
+	}
+
+	for i, n := range inputs {
+
+		output, err := FormatCompactCurrency(n.amount, n.currency, n.precision)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing FormatCompactCurrency(%v, %q, %d)",
+				expected[i], output, err, n.amount, n.currency, n.precision)
+		}
+	}
+
+	if _, err := FormatCompactCurrency(1, "XYZ", 1); err == nil {
+
+		t.Errorf("Expected: an error but received: nil testing FormatCompactCurrency")
+	}
+}
+
+// Test FormatCompactAmount uses a configured threshold and the formatter
+func TestFormatCompactAmount(t *testing.T) {
+
+	var (
+		f, _ = NewFormatter("de-DE")
+		c, _ = LookupCurrency("EUR")
+	)
+
+	c.CompactThreshold = 1e6
+
+	inputs := []string{
+		f.FormatCompactAmount(950000, c, 1),
+		f.FormatCompactAmount(2500000, c, 1),
+		f.FormatCompactAmount(3e9, c, 0),
+		Formatter{SignStyle: Parentheses}.FormatCompactAmount(-2500000, c, 1),
+	}
+
+	expected := []string{
+		"€950.000,00",
+		"€2,5M",
+		"€3Md",
+		"(€2.5M)",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatCompactAmount", expected[i], output)
+		}
+	}
+}
@@ -0,0 +1,590 @@
+package decimals
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// A Decimal is a base ten number of arbitrary length, held as a sign, a
+// string of digits and an exponent so that its value is digits × 10^exponent.
+// It is the representation used internally by the Round and Format
+// functions, and can be used directly for numbers that do not fit in an
+// int64 or float64. Trailing zeros are kept, so the Decimal parsed from
+// "1.50" is formatted with two decimal places. The zero value is zero.
+type Decimal struct {
+	negative bool
+	digits   string
+	exponent int
+}
+
+// MaxDecimalExponent is the largest exponent, positive or negative, of a
+// parsed Decimal, so that parsing a short string such as "1e300000000"
+// cannot make formatting allocate gigabytes of zeros.
+const MaxDecimalExponent = 1000000
+
+// MustParseDecimal is like ParseDecimal with the ParseExponent flag but
+// panics if s cannot be parsed. It simplifies initializing variables
+// holding decimal constants.
+func MustParseDecimal(s string) Decimal {
+
+	d, err := ParseDecimal(s, ParseExponent)
+
+	if err != nil {
+
+		panic(err)
+	}
+
+	return d
+}
+
+// ParseDecimal converts a formatted string into a Decimal. It accepts the
+// same syntax as ParseFloat with the same flags, but keeps every digit, so
+// numbers of any length are represented exactly. An error wrapping
+// ErrRange is returned if the exponent of the last digit, once the point
+// is removed, is beyond ±MaxDecimalExponent.
+func ParseDecimal(s string, flags ParseFlag) (Decimal, error) {
+
+	clean, ok := cleanNumber(s, flags)
+
+	if !ok {
+
+		return Decimal{}, &NumError{"ParseDecimal", s, ErrSyntax}
+	}
+
+	d, ok := decimalFromString(clean)
+
+	if !ok {
+
+		return Decimal{}, &NumError{"ParseDecimal", s, ErrRange}
+	}
+
+	return d, nil
+}
+
+// DecimalFromInt returns the Decimal with the value of x.
+func DecimalFromInt(x int64) Decimal {
+
+	return newDecimal(x < 0, strconv.FormatUint(absUint64(x), 10), 0)
+}
+
+// DecimalFromFloat returns the Decimal holding the shortest decimal
+// representation of x, the digits printed by strconv.FormatFloat(x, 'f',
+// -1, 64). Negative zero is returned as zero. An error wrapping ErrRange is
+// returned if x is NaN or infinite.
+func DecimalFromFloat(x float64) (Decimal, error) {
+
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+
+		return Decimal{}, &NumError{"DecimalFromFloat", strconv.FormatFloat(x, 'g', -1, 64), ErrRange}
+	}
+
+	sign, digits, exponent := FloatToDecimal(x)
+
+	return newDecimal(sign < 0, digits, exponent), nil
+}
+
+// newDecimal returns the Decimal with the given sign, unsigned digits and
+// exponent, removing any leading zeros. Zero is never negative, and has no
+// zeros before the point.
+func newDecimal(negative bool, digits string, exponent int) Decimal {
+
+	digits = strings.TrimLeft(digits, "0")
+
+	if digits == "" && exponent > 0 {
+
+		exponent = 0
+	}
+
+	return Decimal{negative && digits != "", digits, exponent}
+}
+
+// decimalFromString converts a number in the form produced by cleanNumber
+// into a Decimal. It reports false if the exponent is beyond
+// ±MaxDecimalExponent.
+func decimalFromString(s string) (Decimal, bool) {
+
+	var (
+		negative bool
+		exponent int
+		err      error
+	)
+
+	if s[0] == '-' || s[0] == '+' {
+
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	// Split off the exponent
+	if e := strings.IndexAny(s, "eE"); e >= 0 {
+
+		exponent, err = strconv.Atoi(s[e+1:])
+
+		// Reject exponents that moving the point could overflow
+		if err != nil || exponent < -2*MaxDecimalExponent || exponent > 2*MaxDecimalExponent {
+
+			return Decimal{}, false
+		}
+
+		s = s[:e]
+	}
+
+	// Remove the point, moving the exponent to the last digit
+	if point := strings.IndexByte(s, '.'); point >= 0 {
+
+		exponent -= len(s) - point - 1
+		s = s[:point] + s[point+1:]
+	}
+
+	if exponent < -MaxDecimalExponent || exponent > MaxDecimalExponent {
+
+		return Decimal{}, false
+	}
+
+	return newDecimal(negative, s, exponent), true
+}
+
+// Sign returns -1 if d is negative, 0 if it is zero and 1 if it is positive.
+func (d Decimal) Sign() int {
+
+	switch {
+
+	case d.digits == "":
+
+		return 0
+
+	case d.negative:
+
+		return -1
+	}
+
+	return 1
+}
+
+// Neg returns d with its sign reversed.
+func (d Decimal) Neg() Decimal {
+
+	return newDecimal(!d.negative, d.digits, d.exponent)
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal) Abs() Decimal {
+
+	d.negative = false
+
+	return d
+}
+
+// Cmp compares d and y and returns -1 if d is less than y, 0 if they are
+// equal and 1 if d is greater than y. Trailing zeros are not significant,
+// so 1.5 and 1.50 are equal.
+func (d Decimal) Cmp(y Decimal) int {
+
+	if ds, ys := d.Sign(), y.Sign(); ds != ys || ds == 0 {
+
+		switch {
+
+		case ds < ys:
+
+			return -1
+
+		case ds > ys:
+
+			return 1
+		}
+
+		return 0
+	}
+
+	c := compareDigits(d, y)
+
+	if d.negative {
+
+		return -c
+	}
+
+	return c
+}
+
+// compareDigits compares the absolute values of the non-zero decimals d
+// and y.
+func compareDigits(d, y Decimal) int {
+
+	// The number with the leading digit in the higher place is larger
+	if c := comparePlaces(d, y); c != 0 {
+
+		return c
+	}
+
+	// Otherwise compare the digits padded to the same length
+	dd, yd := d.digits, y.digits
+
+	if len(dd) < len(yd) {
+
+		dd += strings.Repeat("0", len(yd)-len(dd))
+
+	} else {
+
+		yd += strings.Repeat("0", len(dd)-len(yd))
+	}
+
+	return strings.Compare(dd, yd)
+}
+
+// comparePlaces compares the places of the leading digits of d and y,
+// len(digits) + exponent, without forming the sums, which can overflow
+// for decimals with extreme exponents from arithmetic.
+func comparePlaces(d, y Decimal) int {
+
+	var (
+		de, ye = d.exponent, y.exponent
+		diff   = len(y.digits) - len(d.digits)
+	)
+
+	// The difference of exponents of opposite signs can overflow, but it
+	// then exceeds any difference of lengths
+	switch {
+
+	case de >= 0 && ye < 0 && de > math.MaxInt+ye:
+
+		return 1
+
+	case de < 0 && ye >= 0 && ye > math.MaxInt+de:
+
+		return -1
+	}
+
+	switch x := de - ye; {
+
+	case x < diff:
+
+		return -1
+
+	case x > diff:
+
+		return 1
+	}
+
+	return 0
+}
+
+// Round returns d rounded to the given precision using the given rounding
+// mode. Precision is interpreted as for RoundFloat: a positive precision
+// is a number of decimal places and a negative precision the power of ten
+// to round to. A decimal that already has no more decimal places than the
+// precision is returned unchanged.
+func (d Decimal) Round(precision int, mode RoundingMode) Decimal {
+
+	var (
+		keep     = d.keep(precision)
+		exponent = math.MaxInt
+	)
+
+	if d.digits == "" || keep >= len(d.digits) {
+
+		return d
+	}
+
+	// The exponent of the result, one place short for math.MinInt
+	if precision > math.MinInt {
+
+		exponent = -precision
+	}
+
+	// If rounding to more than one order of magnitude larger than d the
+	// rounding digit is a leading zero and the result is either zero or
+	// one unit at the precision, found without padding the digits
+	if keep < 0 {
+
+		if roundsUp(mode, d.negative, 0, true, false) {
+
+			return newDecimal(d.negative, "1", exponent)
+		}
+
+		return newDecimal(d.negative, "", exponent)
+	}
+
+	digits := []byte(d.digits)
+	lenDigits := len(digits)
+	digits = roundDigits(digits, keep, d.negative, mode)
+
+	// Keep one more digit if rounding carried into a new digit
+	keep += len(digits) - lenDigits
+
+	return newDecimal(d.negative, string(digits[:keep]), exponent)
+}
+
+// keep returns the number of digits of d kept when rounding to the given
+// precision, which is negative if every digit is discarded. It does not
+// overflow for extreme precisions.
+func (d Decimal) keep(precision int) int {
+
+	keep := len(d.digits) + d.exponent
+
+	switch {
+
+	case precision < 0 && keep < math.MinInt-precision:
+
+		return -1
+
+	case precision > 0 && keep > math.MaxInt-precision:
+
+		return math.MaxInt
+	}
+
+	return keep + precision
+}
+
+// String returns d in plain decimal notation, such as "-1234.50", with as
+// many decimal places as d has.
+func (d Decimal) String() string {
+
+	places := -d.exponent
+
+	if places < 0 {
+
+		places = 0
+	}
+
+	is, fs := d.parts(places)
+
+	if fs != "" {
+
+		is += "." + fs
+	}
+
+	if d.negative {
+
+		return "-" + is
+	}
+
+	return is
+}
+
+// Format implements fmt.Formatter. The verbs %v, %s and %f format d in
+// plain decimal notation, rounded half up to the precision if one is given,
+// so fmt.Sprintf("%.2f", d) gives "1234.50". The '+' flag shows a plus sign
+// for positive numbers and the ' ' flag a space. The '#' flag formats d
+// with the default formatter's separators, grouping thousands. A width,
+// measured in columns by DisplayWidth, pads with spaces on the left, or on
+// the right with the '-' flag, or with zeros after the sign with the '0'
+// flag. The %q verb quotes the output of %s, %#v gives the output of
+// GoString, and other verbs are reported as bad verbs as by the fmt
+// package.
+func (d Decimal) Format(s fmt.State, verb rune) {
+
+	var (
+		sign   string
+		digits string
+	)
+
+	switch verb {
+
+	case 'v', 's', 'f', 'q':
+
+	default:
+
+		fmt.Fprintf(s, "%%!%c(decimals.Decimal=%s)", verb, d.String())
+		return
+	}
+
+	if verb == 'q' {
+
+		fmt.Fprintf(s, "%q", d.String())
+		return
+	}
+
+	if verb == 'v' && s.Flag('#') {
+
+		io.WriteString(s, d.GoString())
+		return
+	}
+
+	// Round to the precision, or keep every decimal place
+	places, ok := s.Precision()
+
+	if ok {
+
+		d = d.Round(places, HalfUp)
+
+	} else if places = -d.exponent; places < 0 {
+
+		places = 0
+	}
+
+	if s.Flag('#') {
+
+		digits = DefaultFormatter().formatDigits(d, places, DefaultFormatter().GroupSeparator)
+
+	} else {
+
+		digits = Formatter{}.formatDigits(d, places, "")
+	}
+
+	switch {
+
+	case d.Sign() < 0:
+
+		sign = "-"
+
+	case s.Flag('+'):
+
+		sign = "+"
+
+	case s.Flag(' '):
+
+		sign = " "
+	}
+
+	// Pad to the width
+	width, _ := s.Width()
+	pad := width - DisplayWidth(sign+digits)
+
+	switch {
+
+	case pad <= 0:
+
+		io.WriteString(s, sign+digits)
+
+	case s.Flag('-'):
+
+		io.WriteString(s, sign+digits+strings.Repeat(" ", pad))
+
+	case s.Flag('0'):
+
+		io.WriteString(s, sign+strings.Repeat("0", pad)+digits)
+
+	default:
+
+		io.WriteString(s, strings.Repeat(" ", pad)+sign+digits)
+	}
+}
+
+// GoString implements fmt.GoStringer, returning Go syntax for d, such as
+// decimals.MustParseDecimal("-1.50"), as printed by the %#v verb.
+func (d Decimal) GoString() string {
+
+	return "decimals.MustParseDecimal(" + strconv.Quote(d.String()) + ")"
+}
+
+// DebugString returns the internal representation of d, its sign, digits
+// and exponent, for debugging rounding discrepancies. For example, the
+// Decimal parsed from "-1.50" is described as
+// Decimal{sign: -1, digits: "15", exponent: -1} once rounded to one place.
+// The format is intended for people and may change.
+func (d Decimal) DebugString() string {
+
+	return fmt.Sprintf("Decimal{sign: %d, digits: %q, exponent: %d}", d.Sign(), d.digits, d.exponent)
+}
+
+// Float64 returns the float64 nearest to d. If d is too large for a float64
+// the signed infinity is returned.
+func (d Decimal) Float64() float64 {
+
+	if d.digits == "" {
+
+		return 0
+	}
+
+	r, _ := strconv.ParseFloat(d.digits+"e"+strconv.Itoa(d.exponent), 64)
+
+	if d.negative {
+
+		return -r
+	}
+
+	return r
+}
+
+// Int64 returns the integer part of d, truncating any fraction toward zero.
+// If it does not fit in an int64 the minimum or maximum int64 is returned
+// with an error wrapping ErrRange.
+func (d Decimal) Int64() (int64, error) {
+
+	// Decide extreme exponents without writing out their zeros
+	switch {
+
+	case d.digits == "" || d.exponent < -len(d.digits):
+
+		return 0, nil
+
+	case d.exponent > 18:
+
+		s := d.digits + "e" + strconv.Itoa(d.exponent)
+
+		if d.negative {
+
+			return math.MinInt64, &NumError{"Decimal.Int64", "-" + s, ErrRange}
+		}
+
+		return math.MaxInt64, &NumError{"Decimal.Int64", s, ErrRange}
+	}
+
+	is, _ := d.parts(0)
+
+	if d.negative {
+
+		is = "-" + is
+	}
+
+	r, err := strconv.ParseInt(is, 10, 64)
+
+	if err != nil {
+
+		return r, &NumError{"Decimal.Int64", d.String(), ErrRange}
+	}
+
+	return r, nil
+}
+
+// parts returns the unsigned integer digits of d, with no leading zeros
+// except a single zero for a number less than one, and its fractional
+// digits padded with zeros or truncated to the given number of places.
+func (d Decimal) parts(places int) (string, string) {
+
+	digits, exponent := d.digits, d.exponent
+
+	// Append any zeros before the point
+	if exponent > 0 {
+
+		digits += strings.Repeat("0", exponent)
+		exponent = 0
+	}
+
+	// Pad with leading zeros so there is at least one integer digit
+	if len(digits) <= -exponent {
+
+		digits = strings.Repeat("0", -exponent-len(digits)+1) + digits
+	}
+
+	point := len(digits) + exponent
+	is, fs := digits[:point], digits[point:]
+
+	if len(fs) < places {
+
+		fs += strings.Repeat("0", places-len(fs))
+
+	} else {
+
+		fs = fs[:places]
+	}
+
+	return is, fs
+}
+
+// roundedFloat converts the rounded decimal d back to a float64, keeping
+// the sign of x if d was rounded to zero.
+func roundedFloat(x float64, d Decimal) float64 {
+
+	r := d.Float64()
+
+	if r == 0 && x < 0 {
+
+		return math.Copysign(0, -1)
+	}
+
+	return r
+}
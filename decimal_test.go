@@ -0,0 +1,372 @@
+package decimals
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Test ParseDecimal and String round trip a range of inputs
+func TestParseDecimal(t *testing.T) {
+
+	inputs := []string{
+		"0",
+		"-0",
+		"0.00",
+		"1.50",
+		"-1,234.5",
+		"007",
+		"123456789012345678901234567890.123456789",
+		"1.2e3",
+		"-1.2e-3",
+	}
+
+	expected := []string{
+		"0",
+		"0",
+		"0.00",
+		"1.50",
+		"-1234.5",
+		"7",
+		"123456789012345678901234567890.123456789",
+		"1200",
+		"-0.0012",
+	}
+
+	for i, s := range inputs {
+
+		d, err := ParseDecimal(s, ParseExponent)
+
+		if err != nil || d.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing ParseDecimal(%q)",
+				expected[i], d.String(), err, s)
+		}
+	}
+
+	if _, err := ParseDecimal("1.2.3", ParseStrict); !errors.Is(err, ErrSyntax) {
+
+		t.Errorf("Expected: ErrSyntax but received: %v testing ParseDecimal", err)
+	}
+
+	// Exponents beyond MaxDecimalExponent once the point is removed
+	for _, s := range []string{"1e99999999999999999999", "1e9223372036854775807", "1e300000000", "1e1000001", "0.1e-1000000", "-1e-9223372036854775808"} {
+
+		if _, err := ParseDecimal(s, ParseExponent); !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: ErrRange but received: %v testing ParseDecimal(%q)", err, s)
+		}
+	}
+
+	for _, s := range []string{"1e1000000", "-1e-1000000", "123456.7e-999999"} {
+
+		if _, err := ParseDecimal(s, ParseExponent); err != nil {
+
+			t.Errorf("Expected: no error but received: %v testing ParseDecimal(%q)", err, s)
+		}
+	}
+}
+
+// Test Decimal.Round with a range of precisions and modes
+func TestDecimalRound(t *testing.T) {
+
+	inputs := []string{
+		"2.675",
+		"-2.675",
+		"2.665",
+		"999.99",
+		"123456789012345678901234567890.5",
+		"1234",
+		"-0.001",
+		"1.5",
+	}
+
+	precisions := []int{2, 2, 2, 1, 0, -2, 2, 3}
+
+	modes := []RoundingMode{HalfUp, Floor, HalfEven, HalfUp, HalfUp, Ceiling, HalfUp, HalfUp}
+
+	expected := []string{
+		"2.68",
+		"-2.68",
+		"2.66",
+		"1000.0",
+		"123456789012345678901234567891",
+		"1300",
+		"0.00",
+		"1.5",
+	}
+
+	for i, s := range inputs {
+
+		d, _ := ParseDecimal(s, ParseStrict)
+		output := d.Round(precisions[i], modes[i]).String()
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing %s.Round(%d, %v)",
+				expected[i], output, s, precisions[i], modes[i])
+		}
+	}
+
+	// Precisions far beyond the digits give zero or one unit at the
+	// precision without writing out the zeros
+	extremes := []struct {
+		s         string
+		precision int
+		mode      RoundingMode
+		sign      int
+	}{
+		{"12345", math.MinInt, HalfUp, 0},
+		{"12345", -1 << 40, HalfUp, 0},
+		{"12345", math.MinInt, Up, 1},
+		{"12345", -1 << 40, Up, 1},
+		{"-0.5", -1 << 40, Floor, -1},
+		{"-0.5", -1 << 40, Ceiling, 0},
+		{"0.001", math.MinInt, Ceiling, 1},
+		{"0.001", math.MinInt, Down, 0},
+	}
+
+	for _, e := range extremes {
+
+		d := MustParseDecimal(e.s).Round(e.precision, e.mode)
+
+		if output := d.Sign(); output != e.sign {
+
+			t.Errorf("Expected: sign %d but received: %d testing %s.Round(%d, %v)",
+				e.sign, output, e.s, e.precision, e.mode)
+		}
+
+		if e.sign != 0 && e.precision == -1<<40 && d.Cmp(newDecimal(e.sign < 0, "1", 1<<40)) != 0 {
+
+			t.Errorf("Expected: 1e%d but received: %s testing %s.Round(%d, %v)",
+				1<<40, d.DebugString(), e.s, e.precision, e.mode)
+		}
+	}
+}
+
+// Test Decimal.Cmp, Sign, Neg and Abs
+func TestDecimalCmp(t *testing.T) {
+
+	inputs := [][2]string{
+		{"1.5", "1.50"},
+		{"-1", "1"},
+		{"0", "-0.00"},
+		{"100", "99.999"},
+		{"-100", "-99.999"},
+		{"0.001", "0.0009"},
+	}
+
+	expected := []int{0, -1, 0, 1, -1, 1}
+
+	for i, n := range inputs {
+
+		a, _ := ParseDecimal(n[0], ParseStrict)
+		b, _ := ParseDecimal(n[1], ParseStrict)
+
+		if output := a.Cmp(b); output != expected[i] {
+
+			t.Errorf("Expected: %d but received: %d testing %s.Cmp(%s)",
+				expected[i], output, n[0], n[1])
+		}
+	}
+
+	// Decimals with extreme exponents, as arithmetic can produce
+	extremes := [][2]Decimal{
+		{{false, "1", math.MaxInt}, {false, "1", 0}},
+		{{false, "12", math.MinInt + 1}, {false, "1", 0}},
+		{{false, "1", math.MaxInt - 1}, {false, "1", math.MinInt}},
+		{{true, "5", math.MaxInt}, {true, "5", math.MaxInt}},
+	}
+
+	for i, c := range []int{1, -1, 1, 0} {
+
+		if output := extremes[i][0].Cmp(extremes[i][1]); output != c {
+
+			t.Errorf("Expected: %d but received: %d testing %#v.Cmp(%#v)", c, output, extremes[i][0], extremes[i][1])
+		}
+	}
+
+	d := DecimalFromInt(-42)
+
+	if d.Sign() != -1 || d.Neg().String() != "42" || d.Abs().String() != "42" || (Decimal{}).Sign() != 0 {
+
+		t.Errorf("Expected: -1, 42, 42, 0 but received: %d, %s, %s, %d testing Decimal methods",
+			d.Sign(), d.Neg(), d.Abs(), (Decimal{}).Sign())
+	}
+}
+
+// Test conversions between Decimal, int64 and float64
+func TestDecimalConversions(t *testing.T) {
+
+	if d := DecimalFromInt(math.MinInt64); d.String() != "-9223372036854775808" {
+
+		t.Errorf("Expected: %q but received: %q testing DecimalFromInt", "-9223372036854775808", d)
+	}
+
+	d, err := DecimalFromFloat(-1234.5)
+
+	if err != nil || d.String() != "-1234.5" || d.Float64() != -1234.5 {
+
+		t.Errorf("Expected: -1234.5 but received: %s (%v) testing DecimalFromFloat", d, err)
+	}
+
+	if _, err := DecimalFromFloat(math.NaN()); !errors.Is(err, ErrRange) {
+
+		t.Errorf("Expected: ErrRange but received: %v testing DecimalFromFloat(NaN)", err)
+	}
+
+	if output, err := d.Int64(); err != nil || output != -1234 {
+
+		t.Errorf("Expected: -1234 but received: %d (%v) testing Decimal.Int64", output, err)
+	}
+
+	big, _ := ParseDecimal("-99999999999999999999", ParseStrict)
+
+	if output, err := big.Int64(); !errors.Is(err, ErrRange) || output != math.MinInt64 {
+
+		t.Errorf("Expected: MinInt64 and ErrRange but received: %d (%v) testing Decimal.Int64",
+			output, err)
+	}
+}
+
+// Test FormatDecimal formats numbers of any length
+func TestFormatDecimal(t *testing.T) {
+
+	inputs := []string{
+		"123456789012345678901234567890.125",
+		"-0.0456",
+		"15",
+	}
+
+	precisions := []int{2, 3, -1}
+
+	expected := []string{
+		"123,456,789,012,345,678,901,234,567,890.13",
+		"-0.046",
+		"20",
+	}
+
+	for i, s := range inputs {
+
+		d, _ := ParseDecimal(s, ParseStrict)
+		output := FormatDecimal(d, precisions[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatDecimal(%s, %d)",
+				expected[i], output, s, precisions[i])
+		}
+	}
+}
+
+// Test FormatDecimalMode rounds with each mode
+func TestFormatDecimalMode(t *testing.T) {
+
+	d, _ := ParseDecimal("-1234567890123456789.125", ParseStrict)
+
+	modes := []RoundingMode{HalfUp, HalfEven, Down, Ceiling, Floor}
+
+	expected := []string{
+		"-1,234,567,890,123,456,789.13",
+		"-1,234,567,890,123,456,789.12",
+		"-1,234,567,890,123,456,789.12",
+		"-1,234,567,890,123,456,789.12",
+		"-1,234,567,890,123,456,789.13",
+	}
+
+	for i, mode := range modes {
+
+		if output := FormatDecimalMode(d, 2, mode); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatDecimalMode(%s, 2, %v)",
+				expected[i], output, d, mode)
+		}
+	}
+}
+
+// Test Decimal implements fmt.Formatter with widths, precisions and flags
+func TestDecimalFormat(t *testing.T) {
+
+	d, _ := ParseDecimal("1234.5", ParseStrict)
+	n := d.Neg()
+
+	inputs := []string{
+		fmt.Sprintf("%v", d),
+		fmt.Sprintf("%s", n),
+		fmt.Sprintf("%.2f", d),
+		fmt.Sprintf("%10.2f", d),
+		fmt.Sprintf("%-10.0f|", n),
+		fmt.Sprintf("%+#.2f", d),
+		fmt.Sprintf("%#12.1f", n),
+		fmt.Sprintf("%08.1f", n),
+		fmt.Sprintf("% .1f", d),
+		fmt.Sprintf("%q", d),
+		fmt.Sprintf("%d", d),
+	}
+
+	expected := []string{
+		"1234.5",
+		"-1234.5",
+		"1234.50",
+		"   1234.50",
+		"-1235     |",
+		"+1,234.50",
+		"    -1,234.5",
+		"-01234.5",
+		" 1234.5",
+		`"1234.5"`,
+		"%!d(decimals.Decimal=1234.5)",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Decimal.Format",
+				expected[i], output)
+		}
+	}
+}
+
+// Test GoString, DebugString and MustParseDecimal
+func TestDecimalDebug(t *testing.T) {
+
+	d := MustParseDecimal("-1.50")
+
+	inputs := []string{
+		d.GoString(),
+		fmt.Sprintf("%#v", d),
+		d.DebugString(),
+		d.Round(1, HalfUp).DebugString(),
+		Decimal{}.DebugString(),
+		MustParseDecimal("1.2e5").DebugString(),
+	}
+
+	expected := []string{
+		`decimals.MustParseDecimal("-1.50")`,
+		`decimals.MustParseDecimal("-1.50")`,
+		`Decimal{sign: -1, digits: "150", exponent: -2}`,
+		`Decimal{sign: -1, digits: "15", exponent: -1}`,
+		`Decimal{sign: 0, digits: "", exponent: 0}`,
+		`Decimal{sign: 1, digits: "12", exponent: 4}`,
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Decimal debugging", expected[i], output)
+		}
+	}
+
+	defer func() {
+
+		if recover() == nil {
+
+			t.Errorf("Expected: a panic but received: none testing MustParseDecimal(\"x\")")
+		}
+	}()
+
+	MustParseDecimal("x")
+}
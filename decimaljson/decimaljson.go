@@ -0,0 +1,435 @@
+/*
+Package decimaljson encodes values as JSON with numbers formatted for
+display, so that API responses can carry strings such as "1,234.50"
+without a bespoke MarshalJSON method for every response type.
+
+Numeric fields are formatted when they have a decimals struct tag, which
+holds a comma separated list of options:
+
+	type Invoice struct {
+		Total float64 `json:"total" decimals:"precision=2,group"`
+		Rate  float64 `json:"rate" decimals:"precision=4,mode=half-even"`
+		Count int     `json:"count"`
+	}
+
+encodes as {"total":"1,234.50","rate":"0.0125","count":3}. The options are:
+
+	precision=n
+		the precision, as for decimals.RoundFloat (default 0)
+	mode=name
+		the rounding mode, such as half-even (default half-up)
+	group
+		group thousands with the formatter's separator
+	locale=tag
+		use the separators of a locale, such as de-DE
+	percent
+		multiply by 100 and add a percent sign
+	currency=code
+		round to the currency's minor unit, unless a precision is given,
+		and prefix the currency's symbol
+
+Tags are parsed by decimals.ParseTag, so a tagged number is encoded as
+the same string that decimals.FormatStruct gives, with the formatter's
+template applied. Tags apply to integers, floats and decimals.Decimal
+values, to pointers to them and to each element of slices and arrays of
+them. Other fields are encoded as by encoding/json, honouring json tags
+and the omitempty option, and untagged decimals.Decimal values are
+encoded as strings in plain decimal notation.
+*/
+package decimaljson
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/olihawkins/decimals"
+)
+
+var (
+	decimalType       = reflect.TypeOf(decimals.Decimal{})
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Marshal returns the JSON encoding of v, with tagged numbers formatted
+// using the default formatter's separators.
+func Marshal(v interface{}) ([]byte, error) {
+
+	var buf bytes.Buffer
+
+	if err := encodeValue(&buf, reflect.ValueOf(v), decimals.DefaultFormatter(), nil); err != nil {
+
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// An Encoder writes JSON values with formatted numbers to an output stream.
+type Encoder struct {
+	w         io.Writer
+	formatter decimals.Formatter
+}
+
+// NewEncoder returns an encoder that writes to w, formatting tagged numbers
+// with the default formatter's separators.
+func NewEncoder(w io.Writer) *Encoder {
+
+	return &Encoder{w, decimals.DefaultFormatter()}
+}
+
+// SetFormatter sets the formatter whose separators are used for tagged
+// numbers without a locale option.
+func (e *Encoder) SetFormatter(f decimals.Formatter) {
+
+	e.formatter = f
+}
+
+// Encode writes the JSON encoding of v to the stream, followed by a newline.
+// Nothing is written if v cannot be encoded.
+func (e *Encoder) Encode(v interface{}) error {
+
+	var buf bytes.Buffer
+
+	if err := encodeValue(&buf, reflect.ValueOf(v), e.formatter, nil); err != nil {
+
+		return err
+	}
+
+	buf.WriteByte('\n')
+	_, err := e.w.Write(buf.Bytes())
+
+	return err
+}
+
+// WriteResponse writes v as the JSON body of an HTTP response with the
+// given status code, for use in handlers. The body is encoded before the
+// header is written, so an encoding error can still be reported to the
+// client with a different status.
+func WriteResponse(w http.ResponseWriter, status int, v interface{}) error {
+
+	body, err := Marshal(v)
+
+	if err != nil {
+
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(append(body, '\n'))
+
+	return err
+}
+
+// encodeValue writes the JSON encoding of v to buf. If tf is not nil v is
+// a tagged field, and numbers within it are formatted.
+func encodeValue(buf *bytes.Buffer, v reflect.Value, f decimals.Formatter, tf *decimals.TagFormat) error {
+
+	if !v.IsValid() {
+
+		buf.WriteString("null")
+		return nil
+	}
+
+	// Format tagged numbers
+	if tf != nil {
+
+		if s, ok := tf.Format(v); ok {
+
+			return encodeJSON(buf, s)
+		}
+
+		switch v.Kind() {
+
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Array:
+
+		default:
+
+			return fmt.Errorf("decimaljson: decimals tag on non-numeric type %s", v.Type())
+		}
+	}
+
+	if v.Type() == decimalType {
+
+		return encodeJSON(buf, v.Interface().(decimals.Decimal).String())
+	}
+
+	// Leave types with their own encoding to encoding/json
+	if tf == nil && (v.Type().Implements(marshalerType) || v.Type().Implements(textMarshalerType)) {
+
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+
+			buf.WriteString("null")
+			return nil
+		}
+
+		return encodeJSON(buf, v.Interface())
+	}
+
+	switch v.Kind() {
+
+	case reflect.Ptr, reflect.Interface:
+
+		if v.IsNil() {
+
+			buf.WriteString("null")
+			return nil
+		}
+
+		return encodeValue(buf, v.Elem(), f, tf)
+
+	case reflect.Struct:
+
+		return encodeStruct(buf, v, f)
+
+	case reflect.Slice, reflect.Array:
+
+		if v.Kind() == reflect.Slice && v.IsNil() {
+
+			buf.WriteString("null")
+			return nil
+		}
+
+		if tf == nil && v.Type().Elem().Kind() == reflect.Uint8 {
+
+			return encodeJSON(buf, v.Interface())
+		}
+
+		buf.WriteByte('[')
+
+		for i := 0; i < v.Len(); i++ {
+
+			if i > 0 {
+
+				buf.WriteByte(',')
+			}
+
+			if err := encodeValue(buf, v.Index(i), f, tf); err != nil {
+
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+		return nil
+
+	case reflect.Map:
+
+		if v.IsNil() {
+
+			buf.WriteString("null")
+			return nil
+		}
+
+		if v.Type().Key().Kind() != reflect.String {
+
+			return encodeJSON(buf, v.Interface())
+		}
+
+		return encodeMap(buf, v, f)
+	}
+
+	return encodeJSON(buf, v.Interface())
+}
+
+// encodeStruct writes the JSON encoding of the struct v to buf.
+func encodeStruct(buf *bytes.Buffer, v reflect.Value, f decimals.Formatter) error {
+
+	buf.WriteByte('{')
+	_, err := encodeFields(buf, v, f, true)
+	buf.WriteByte('}')
+
+	return err
+}
+
+// encodeFields writes the exported fields of the struct v to buf as JSON
+// object members, flattening embedded structs as encoding/json does. It
+// returns whether the next member is the first in the object.
+func encodeFields(buf *bytes.Buffer, v reflect.Value, f decimals.Formatter, first bool) (bool, error) {
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+
+		field := t.Field(i)
+		name, omitEmpty := field.Name, false
+
+		if field.PkgPath != "" && !field.Anonymous {
+
+			continue
+		}
+
+		// Apply the json tag
+		if tag, ok := field.Tag.Lookup("json"); ok {
+
+			if tag == "-" {
+
+				continue
+			}
+
+			opts := strings.Split(tag, ",")
+
+			if opts[0] != "" {
+
+				name = opts[0]
+			}
+
+			for _, opt := range opts[1:] {
+
+				omitEmpty = omitEmpty || opt == "omitempty"
+			}
+		}
+
+		fv := v.Field(i)
+
+		// Flatten embedded structs without a json name
+		if field.Anonymous && name == field.Name {
+
+			if fv.Kind() == reflect.Ptr {
+
+				if fv.IsNil() {
+
+					continue
+				}
+
+				fv = fv.Elem()
+			}
+
+			if fv.Kind() == reflect.Struct && fv.Type() != decimalType {
+
+				var err error
+
+				if first, err = encodeFields(buf, fv, f, first); err != nil {
+
+					return first, err
+				}
+
+				continue
+			}
+
+			if field.PkgPath != "" {
+
+				continue
+			}
+		}
+
+		if omitEmpty && isEmpty(fv) {
+
+			continue
+		}
+
+		var tf *decimals.TagFormat
+
+		if tag, ok := field.Tag.Lookup("decimals"); ok {
+
+			parsed, err := f.ParseTag(tag)
+
+			if err != nil {
+
+				return first, err
+			}
+
+			tf = &parsed
+		}
+
+		if !first {
+
+			buf.WriteByte(',')
+		}
+
+		first = false
+
+		if err := encodeJSON(buf, name); err != nil {
+
+			return first, err
+		}
+
+		buf.WriteByte(':')
+
+		if err := encodeValue(buf, fv, f, tf); err != nil {
+
+			return first, err
+		}
+	}
+
+	return first, nil
+}
+
+// encodeMap writes the JSON encoding of the map v with string keys to buf,
+// with the keys sorted as by encoding/json.
+func encodeMap(buf *bytes.Buffer, v reflect.Value, f decimals.Formatter) error {
+
+	keys := v.MapKeys()
+
+	sort.Slice(keys, func(i, j int) bool {
+
+		return keys[i].String() < keys[j].String()
+	})
+
+	buf.WriteByte('{')
+
+	for i, key := range keys {
+
+		if i > 0 {
+
+			buf.WriteByte(',')
+		}
+
+		if err := encodeJSON(buf, key.String()); err != nil {
+
+			return err
+		}
+
+		buf.WriteByte(':')
+
+		if err := encodeValue(buf, v.MapIndex(key), f, nil); err != nil {
+
+			return err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return nil
+}
+
+// encodeJSON writes the encoding/json encoding of v to buf.
+func encodeJSON(buf *bytes.Buffer, v interface{}) error {
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+
+		return err
+	}
+
+	buf.Write(b)
+
+	return nil
+}
+
+// isEmpty reports whether v is empty for the omitempty option, as defined
+// by encoding/json.
+func isEmpty(v reflect.Value) bool {
+
+	switch v.Kind() {
+
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+
+		return v.Len() == 0
+
+	case reflect.Ptr, reflect.Interface:
+
+		return v.IsNil()
+	}
+
+	return v.IsZero() && v.Kind() != reflect.Struct
+}
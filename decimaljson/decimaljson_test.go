@@ -0,0 +1,189 @@
+package decimaljson
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/olihawkins/decimals"
+)
+
+type line struct {
+	Item   string    `json:"item"`
+	Prices []float64 `json:"prices" decimals:"precision=2"`
+}
+
+type base struct {
+	ID int `json:"id"`
+}
+
+type invoice struct {
+	base
+	Total    float64          `json:"total" decimals:"precision=2,group"`
+	Rate     float64          `json:"rate" decimals:"precision=1,mode=half-even"`
+	Big      int64            `json:"big" decimals:"precision=-3,group,locale=de-DE"`
+	Units    uint64           `json:"units" decimals:"group"`
+	Exact    decimals.Decimal `json:"exact" decimals:"precision=3"`
+	Plain    decimals.Decimal `json:"plain"`
+	Discount *float64         `json:"discount,omitempty" decimals:"precision=2"`
+	Count    int              `json:"count"`
+	Lines    []line           `json:"lines"`
+	Due      time.Time        `json:"due"`
+	Notes    map[string]int   `json:"notes"`
+	Skipped  string           `json:"-"`
+	internal int
+}
+
+// Test Marshal formats tagged numbers and encodes other fields as
+// encoding/json does
+func TestMarshal(t *testing.T) {
+
+	var (
+		discount = 5.0
+		inputs   = []invoice{
+			{
+				base:  base{7},
+				Total: 1234.5, Rate: 0.25, Big: 9223372036854775807, Units: 18446744073709551615,
+				Exact: decimals.MustParseDecimal("1.0005"), Plain: decimals.MustParseDecimal("-1.50"),
+				Count: 3, Lines: []line{{"a", []float64{1, 2.345}}},
+				Due: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Notes: map[string]int{"b": 2, "a": 1},
+			},
+			{
+				Total: math.Inf(1), Discount: &discount,
+			},
+		}
+	)
+
+	expected := []string{
+		`{"id":7,"total":"1,234.50","rate":"0.2","big":"9.223.372.036.854.776.000",` +
+			`"units":"18,446,744,073,709,551,615","exact":"1.001","plain":"-1.50","count":3,` +
+			`"lines":[{"item":"a","prices":["1.00","2.35"]}],"due":"2024-01-02T00:00:00Z","notes":{"a":1,"b":2}}`,
+		`{"id":0,"total":"Inf","rate":"0.0","big":"0","units":"0","exact":"0.000","plain":"0",` +
+			`"discount":"5.00","count":0,"lines":null,"due":"0001-01-01T00:00:00Z","notes":null}`,
+	}
+
+	for i, input := range inputs {
+
+		output, err := Marshal(input)
+
+		if err != nil || string(output) != expected[i] {
+
+			t.Errorf("Expected: %s but received: %s (%v) testing Marshal", expected[i], output, err)
+		}
+	}
+}
+
+// Test Marshal reports invalid tags
+func TestMarshalErrors(t *testing.T) {
+
+	inputs := []interface{}{
+		struct {
+			X float64 `decimals:"precision=x"`
+		}{},
+		struct {
+			X float64 `decimals:"mode=nearest"`
+		}{},
+		struct {
+			X float64 `decimals:"locale=xx-XX"`
+		}{},
+		struct {
+			X float64 `decimals:"bold"`
+		}{},
+		struct {
+			X string `decimals:"precision=2"`
+		}{},
+	}
+
+	for _, input := range inputs {
+
+		if _, err := Marshal(input); err == nil {
+
+			t.Errorf("Expected: error but received: nil testing Marshal(%+v)", input)
+		}
+	}
+}
+
+// Test Encoder uses its formatter and writes a newline
+func TestEncoder(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	f, _ := decimals.NewFormatter("de-DE")
+	e := NewEncoder(&buf)
+	e.SetFormatter(f)
+
+	input := struct {
+		Total float64 `json:"total" decimals:"precision=2,group"`
+	}{1234.5}
+
+	expected := "{\"total\":\"1.234,50\"}\n"
+
+	if err := e.Encode(input); err != nil || buf.String() != expected {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing Encoder.Encode", expected, buf.String(), err)
+	}
+}
+
+// Test tagged numbers are encoded as the strings FormatStruct gives
+func TestEncoderMatchesFormatStruct(t *testing.T) {
+
+	type sale struct {
+		Price    float64          `decimals:"currency=USD,group"`
+		Tax      float64          `decimals:"percent,precision=1"`
+		Rounded  float64          `decimals:"precision=2,mode=half-even"`
+		Units    int64            `decimals:"group,locale=de-DE"`
+		Exact    decimals.Decimal `decimals:"currency=JPY"`
+		Discount *float64         `decimals:"precision=2"`
+	}
+
+	var (
+		discount = -0.005
+		input    = sale{1234.5, 0.0825, 2.675, 1234567, decimals.MustParseDecimal("1234.5"), &discount}
+		f        = decimals.Formatter{GroupSeparator: ",", DecimalSeparator: ".", Template: "~{}"}
+		buf      bytes.Buffer
+		encoded  map[string]string
+	)
+
+	e := NewEncoder(&buf)
+	e.SetFormatter(f)
+
+	if err := e.Encode(input); err != nil {
+
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &encoded); err != nil {
+
+		t.Fatal(err)
+	}
+
+	expected, err := f.FormatStruct(input)
+
+	if err != nil || !reflect.DeepEqual(encoded, expected) {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing Encoder.Encode", expected, encoded, err)
+	}
+}
+
+// Test WriteResponse sets the status and content type
+func TestWriteResponse(t *testing.T) {
+
+	rec := httptest.NewRecorder()
+
+	input := struct {
+		Total float64 `json:"total" decimals:"precision=1"`
+	}{2.25}
+
+	err := WriteResponse(rec, 201, input)
+
+	if err != nil || rec.Code != 201 || rec.Header().Get("Content-Type") != "application/json" ||
+		rec.Body.String() != "{\"total\":\"2.3\"}\n" {
+
+		t.Errorf("Expected: 201 {\"total\":\"2.3\"} but received: %d %q (%v) testing WriteResponse",
+			rec.Code, rec.Body.String(), err)
+	}
+}
@@ -1,6 +1,13 @@
 package decimals
 
 import (
+	"errors"
+	"flag"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -90,7 +97,7 @@ func TestRoundInt(t *testing.T) {
 
 			if output != expected[index] {
 
-				t.Errorf("Expected: %s but received: %s testing RoundInt",
+				t.Errorf("Expected: %d but received: %d testing RoundInt",
 					expected[index], output)
 			}
 		}
@@ -98,6 +105,39 @@ func TestRoundInt(t *testing.T) {
 
 }
 
+// Test RoundInt, RoundFloat and FormatFloat at extreme negative precisions
+func TestRoundExtremePrecision(t *testing.T) {
+
+	for _, precision := range []int{math.MinInt, -1 << 40} {
+
+		if output := RoundInt(12345, precision); output != 0 {
+
+			t.Errorf("Expected: 0 but received: %d testing RoundInt(12345, %d)", output, precision)
+		}
+
+		if output := RoundIntMode(12345, precision, Up); output != math.MaxInt64 {
+
+			t.Errorf("Expected: %d but received: %d testing RoundIntMode(12345, %d, Up)",
+				int64(math.MaxInt64), output, precision)
+		}
+
+		if output := RoundFloat(0.5, precision); output != 0 {
+
+			t.Errorf("Expected: 0 but received: %v testing RoundFloat(0.5, %d)", output, precision)
+		}
+
+		if output := RoundFloatMode(-0.5, precision, Floor); !math.IsInf(output, -1) {
+
+			t.Errorf("Expected: -Inf but received: %v testing RoundFloatMode(-0.5, %d, Floor)", output, precision)
+		}
+
+		if output := FormatFloat(1.5, precision); output != "0" {
+
+			t.Errorf("Expected: \"0\" but received: %q testing FormatFloat(1.5, %d)", output, precision)
+		}
+	}
+}
+
 // Test RoundFloat with a range of values
 func TestRoundFloat(t *testing.T) {
 
@@ -141,7 +181,7 @@ func TestRoundFloat(t *testing.T) {
 
 			if output != expected[index] {
 
-				t.Errorf("Expected: %s but received: %s testing RoundFloat",
+				t.Errorf("Expected: %v but received: %v testing RoundFloat",
 					expected[index], output)
 			}
 		}
@@ -205,6 +245,94 @@ func TestFormatThousands(t *testing.T) {
 	}
 }
 
+// Test FormatThousandsUint with values beyond the range of int64
+func TestFormatThousandsUint(t *testing.T) {
+
+	inputs := []uint64{
+		0,
+		999,
+		1000,
+		math.MaxInt64,
+		math.MaxInt64 + 1,
+		math.MaxUint64,
+	}
+
+	expected := []string{
+		"0",
+		"999",
+		"1,000",
+		"9,223,372,036,854,775,807",
+		"9,223,372,036,854,775,808",
+		"18,446,744,073,709,551,615",
+	}
+
+	for i, n := range inputs {
+
+		output := FormatThousandsUint(n)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %s but received: %s testing FormatThousandsUint",
+				expected[i], output)
+		}
+	}
+
+	f := Formatter{GroupSeparator: ".", DecimalSeparator: ","}
+	output := f.FormatThousandsUint(math.MaxUint64)
+
+	if output != "18.446.744.073.709.551.615" {
+
+		t.Errorf("Expected: %s but received: %s testing Formatter.FormatThousandsUint",
+			"18.446.744.073.709.551.615", output)
+	}
+}
+
+// Test FormatThousandsString with a range of values
+func TestFormatThousandsString(t *testing.T) {
+
+	inputs := []string{
+		"123456789012345678901234",
+		"-123456789012345678901234.50",
+		"+1000",
+		"0001000.000",
+		"1,234,567",
+		"-0",
+		".5",
+		"999",
+	}
+
+	expected := []string{
+		"123,456,789,012,345,678,901,234",
+		"-123,456,789,012,345,678,901,234.50",
+		"1,000",
+		"1,000.000",
+		"1,234,567",
+		"0",
+		"0.5",
+		"999",
+	}
+
+	for i, s := range inputs {
+
+		output, err := FormatThousandsString(s)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing FormatThousandsString",
+				expected[i], output, err)
+		}
+	}
+
+	// Strings that are not numbers
+	for _, s := range []string{"", "-", "12a", "1e5", "1.2.3", "12,34", " 12"} {
+
+		if _, err := FormatThousandsString(s); !errors.Is(err, ErrSyntax) {
+
+			t.Errorf("Expected: %v but received: %v testing FormatThousandsString(%q)", ErrSyntax, err, s)
+		}
+	}
+}
+
 // Test FormatInt with a range of values
 func TestFormatInt(t *testing.T) {
 
@@ -297,3 +425,258 @@ func TestFormatFloat(t *testing.T) {
 		}
 	}
 }
+
+// Test ChunkDigits with a range of identifiers and patterns
+func TestChunkDigits(t *testing.T) {
+
+	inputs := []string{
+		"4111111111111111",
+		"378282246310005",
+		"4111 1111-1111 1111",
+		"12345",
+		"",
+	}
+
+	patterns := [][]int{
+		{4},
+		{4, 6, 5},
+		{4},
+		{2},
+		{4},
+	}
+
+	expected := []string{
+		"4111 1111 1111 1111",
+		"3782 822463 10005",
+		"4111 1111 1111 1111",
+		"12 34 5",
+		"",
+	}
+
+	for i, s := range inputs {
+
+		output, err := ChunkDigits(s, patterns[i], " ")
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing ChunkDigits",
+				expected[i], output, err)
+		}
+	}
+
+	if _, err := ChunkDigits("4111x111", []int{4}, " "); !errors.Is(err, ErrSyntax) {
+
+		t.Errorf("Expected: ErrSyntax but received: %v testing ChunkDigits", err)
+	}
+
+	if _, err := ChunkDigits("4111", []int{4, 0}, " "); err == nil {
+
+		t.Errorf("Expected an error testing ChunkDigits with an invalid pattern")
+	}
+}
+
+// Test FormatFloat with values the integer conversion used to mishandle
+func TestFormatFloatEdges(t *testing.T) {
+
+	inputs := []float64{-0.0456, math.Copysign(0, -1), -0.001, 1e20, math.Inf(-1), math.NaN()}
+
+	precisions := []int{4, 2, 2, 0, 2, 2}
+
+	expected := []string{
+		"-0.0456",
+		"0.00",
+		"0.00",
+		"100,000,000,000,000,000,000",
+		"-Inf",
+		"NaN",
+	}
+
+	for i, n := range inputs {
+
+		output := FormatFloat(n, precisions[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatFloat(%v, %d)",
+				expected[i], output, n, precisions[i])
+		}
+	}
+}
+
+// Test FormatFloat formats negative values that round to zero, or to a
+// number with fewer digits, from the rounded value
+func TestFormatFloatRoundedSign(t *testing.T) {
+
+	inputs := []float64{-0.004, -0.005, -0.0049999, -0.4, -0.5, -49, -50, -0.000001}
+
+	precisions := []int{2, 2, 2, 0, 0, -2, -2, 3}
+
+	expected := []string{
+		"0.00",
+		"-0.01",
+		"0.00",
+		"0",
+		"-1",
+		"0",
+		"-100",
+		"0.000",
+	}
+
+	for i, n := range inputs {
+
+		output := FormatFloat(n, precisions[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatFloat(%v, %d)",
+				expected[i], output, n, precisions[i])
+		}
+	}
+}
+
+// The number of random floats tested by TestFormatFloatSyntax. Run a
+// longer sweep with -decimals.fuzzn 10000000
+var fuzzN = flag.Int("decimals.fuzzn", 10000, "the number of random floats to format")
+
+// Test FormatFloat produces a well formed number for random floats of
+// every magnitude and sign at a range of precisions, and that the number
+// is the float rounded to the precision
+func TestFormatFloatSyntax(t *testing.T) {
+
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < *fuzzN; i++ {
+
+		var x float64
+
+		// Mix arbitrary bit patterns with small values near rounding ties
+		switch i % 3 {
+
+		case 0:
+
+			x = math.Float64frombits(rng.Uint64())
+
+		case 1:
+
+			x = (rng.Float64() - 0.5) * math.Pow10(rng.Intn(12)-8)
+
+		default:
+
+			x = float64(rng.Intn(20001)-10000) / 1000
+		}
+
+		p := rng.Intn(13) - 4
+
+		if output, expected := FormatFloat(x, p), expectedFormattedFloat(x, p); output != expected {
+
+			t.Fatalf("Expected: %q but received: %q testing FormatFloat(%v, %d)", expected, output, x, p)
+		}
+	}
+}
+
+// expectedFormattedFloat returns x as FormatFloat formats it with the
+// default formatter, computed independently of the package: the shortest
+// decimal of x from strconv is rounded half up to the precision exactly
+// with math/big, shown with a sign only if the result is not zero, the
+// integer digits grouped in threes by commas, and exactly the precision's
+// number of decimal places.
+func expectedFormattedFloat(x float64, precision int) string {
+
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+
+		return strings.Replace(strconv.FormatFloat(x, 'g', -1, 64), "+", "", 1)
+	}
+
+	var (
+		r, _   = new(big.Rat).SetString(strconv.FormatFloat(math.Abs(x), 'g', -1, 64))
+		places = precision
+		scale  = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(math.Abs(float64(precision)))), nil)
+	)
+
+	if precision < 0 {
+
+		places = 0
+		r.Quo(r, new(big.Rat).SetInt(scale))
+
+	} else {
+
+		r.Mul(r, new(big.Rat).SetInt(scale))
+	}
+
+	// Round half up by adding a half and truncating
+	r.Add(r, big.NewRat(1, 2))
+	n := new(big.Int).Quo(r.Num(), r.Denom())
+
+	if precision < 0 {
+
+		n.Mul(n, scale)
+	}
+
+	digits := n.String()
+
+	if len(digits) <= places {
+
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+
+	is, fs := digits[:len(digits)-places], digits[len(digits)-places:]
+
+	for i := len(is) - 3; i > 0; i -= 3 {
+
+		is = is[:i] + "," + is[i:]
+	}
+
+	if fs != "" {
+
+		is += "." + fs
+	}
+
+	if x < 0 && n.Sign() != 0 {
+
+		return "-" + is
+	}
+
+	return is
+}
+
+// Test FormatFloat carries from the fraction into the integer part and its
+// groups at every 9...9.995 boundary
+func TestFormatFloatCarry(t *testing.T) {
+
+	nines := "9"
+	expected := "10.00"
+
+	for i := 1; i <= 12; i++ {
+
+		x, _ := strconv.ParseFloat(nines+".995", 64)
+
+		if output := FormatFloat(x, 2); output != expected {
+
+			t.Errorf("Expected: %q but received: %q testing FormatFloat(%s.995, 2)",
+				expected, output, nines)
+		}
+
+		if output := FormatFloat(-x, 2); output != "-"+expected {
+
+			t.Errorf("Expected: %q but received: %q testing FormatFloat(-%s.995, 2)",
+				"-"+expected, output, nines)
+		}
+
+		// Below the tie the digits are kept
+		x, _ = strconv.ParseFloat(nines+".994", 64)
+
+		if output := FormatFloat(x, 2); output != groupDigits(nines, ",")+".99" {
+
+			t.Errorf("Expected: %q but received: %q testing FormatFloat(%s.994, 2)",
+				groupDigits(nines, ",")+".99", output, nines)
+		}
+
+		nines += "9"
+		expected = groupDigits("1"+strings.Repeat("0", i+1), ",") + ".00"
+	}
+
+	if output := FormatFloat(0.995, 2); output != "1.00" {
+
+		t.Errorf("Expected: %q but received: %q testing FormatFloat(0.995, 2)", "1.00", output)
+	}
+}
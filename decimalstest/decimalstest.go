@@ -0,0 +1,217 @@
+/*
+Package decimalstest generates random numbers with the strings the decimals
+package formats them as, for testing programs that display formatted
+numbers. Projects can fuzz their display pipelines, such as templates,
+exports and renderers, with values limited to the precisions, magnitudes
+and locales they use, and compare what they show with the reference
+output of the decimals package:
+
+	g, err := decimalstest.NewGenerator(1, decimalstest.Config{
+		MaxPrecision: 2,
+		MinExponent:  -2,
+		MaxExponent:  9,
+		Negative:     true,
+		Locales:      []string{"en", "de-DE", "fr"},
+	})
+
+	for _, c := range g.Cases(1000) {
+
+		if s := render(c.Value, c.Precision, c.Locale); s != c.Expected {
+
+			t.Errorf("Expected: %q but received: %q testing %v", c.Expected, s, c.Value)
+		}
+	}
+
+A generator returns the same cases for the same seed and configuration,
+so failures can be reproduced.
+*/
+package decimalstest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+
+	"github.com/olihawkins/decimals"
+)
+
+// maxDigits is the most significant digits of the values generated, so
+// that each float holds its decimal exactly enough to convert back to it.
+const maxDigits = 15
+
+// Config limits the values a Generator returns. The zero value generates
+// positive values from 1 to below 10 formatted as integers with the
+// default formatter.
+type Config struct {
+
+	// MinPrecision and MaxPrecision limit the precision of each case, as
+	// for decimals.FormatFloat.
+	MinPrecision int
+	MaxPrecision int
+
+	// MinExponent and MaxExponent limit the magnitude of values to at
+	// least 10^MinExponent and below 10^(MaxExponent+1). They must be
+	// from -300 to 300.
+	MinExponent int
+	MaxExponent int
+
+	// Negative generates negative values as often as positive ones.
+	Negative bool
+
+	// Special generates zero, negative zero, NaN and the infinities among
+	// the values.
+	Special bool
+
+	// Locales are the locales to format with, as for decimals.NewFormatter.
+	// Without locales the default formatter is used.
+	Locales []string
+}
+
+// A Case is a generated value and the string that the decimals package
+// formats it as.
+type Case struct {
+	Value     float64
+	Precision int
+	Locale    string // the locale, or empty for the default formatter
+	Expected  string // the value formatted by FormatFloat at the precision
+}
+
+// A Generator generates random cases. It is not safe for concurrent use.
+type Generator struct {
+	rng        *rand.Rand
+	config     Config
+	formatters []decimals.Formatter
+}
+
+// NewGenerator returns a generator of cases limited by the configuration,
+// seeded with the given seed. An error is returned if the limits are not
+// in order or out of range, or if a locale is unknown.
+func NewGenerator(seed int64, config Config) (*Generator, error) {
+
+	if config.MinPrecision > config.MaxPrecision {
+
+		return nil, fmt.Errorf("decimalstest: minimum precision %d above maximum %d",
+			config.MinPrecision, config.MaxPrecision)
+	}
+
+	if config.MinExponent > config.MaxExponent {
+
+		return nil, fmt.Errorf("decimalstest: minimum exponent %d above maximum %d",
+			config.MinExponent, config.MaxExponent)
+	}
+
+	if config.MinExponent < -300 || config.MaxExponent > 300 {
+
+		return nil, fmt.Errorf("decimalstest: exponents %d to %d outside -300 to 300",
+			config.MinExponent, config.MaxExponent)
+	}
+
+	formatters := []decimals.Formatter{decimals.DefaultFormatter()}
+
+	if len(config.Locales) > 0 {
+
+		formatters = make([]decimals.Formatter, len(config.Locales))
+
+		for i, locale := range config.Locales {
+
+			f, err := decimals.NewFormatter(locale)
+
+			if err != nil {
+
+				return nil, fmt.Errorf("decimalstest: %v", err)
+			}
+
+			formatters[i] = f
+		}
+	}
+
+	return &Generator{rand.New(rand.NewSource(seed)), config, formatters}, nil
+}
+
+// Next returns the next case. A quarter of the values are ties, exactly
+// halfway between two numbers at the case's precision, where they fit in
+// fifteen significant digits, so that rounding is tested where it most
+// often goes wrong.
+func (g *Generator) Next() Case {
+
+	var (
+		c = g.config
+		i = g.rng.Intn(len(g.formatters))
+		p = c.MinPrecision + g.rng.Intn(c.MaxPrecision-c.MinPrecision+1)
+		e = c.MinExponent + g.rng.Intn(c.MaxExponent-c.MinExponent+1)
+		x = g.value(e, p)
+	)
+
+	if c.Special && g.rng.Intn(20) == 0 {
+
+		specials := []float64{0, math.Copysign(0, -1), math.NaN(), math.Inf(1), math.Inf(-1)}
+		x = specials[g.rng.Intn(len(specials))]
+
+	} else if c.Negative && g.rng.Intn(2) == 0 {
+
+		x = -x
+	}
+
+	var locale string
+
+	if len(c.Locales) > 0 {
+
+		locale = c.Locales[i]
+	}
+
+	return Case{x, p, locale, g.formatters[i].FormatFloat(x, p)}
+}
+
+// Cases returns the next n cases.
+func (g *Generator) Cases(n int) []Case {
+
+	cases := make([]Case, n)
+
+	for i := range cases {
+
+		cases[i] = g.Next()
+	}
+
+	return cases
+}
+
+// value returns a random positive value from 10^e to below 10^(e+1), which
+// is a tie at the precision one time in four if the tie fits.
+func (g *Generator) value(e int, precision int) float64 {
+
+	// A tie has digits from 10^e down to the five at 10^(-precision-1)
+	n := e + precision + 2
+
+	if n < 2 || n > maxDigits || g.rng.Intn(4) != 0 {
+
+		n = 1 + g.rng.Intn(maxDigits)
+
+		return decimalFloat(g.digits(n), e-n+1)
+	}
+
+	return decimalFloat(g.digits(n-1)+"5", -precision-1)
+}
+
+// digits returns n random decimal digits with a leading digit that is not
+// zero.
+func (g *Generator) digits(n int) string {
+
+	b := make([]byte, n)
+	b[0] = byte('1' + g.rng.Intn(9))
+
+	for i := 1; i < n; i++ {
+
+		b[i] = byte('0' + g.rng.Intn(10))
+	}
+
+	return string(b)
+}
+
+// decimalFloat returns the float nearest to digits × 10^exponent.
+func decimalFloat(digits string, exponent int) float64 {
+
+	x, _ := strconv.ParseFloat(digits+"e"+strconv.Itoa(exponent), 64)
+
+	return x
+}
@@ -0,0 +1,116 @@
+package decimalstest
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/olihawkins/decimals"
+)
+
+// Test generated cases respect the configuration and hold the output of
+// the decimals package
+func TestGenerator(t *testing.T) {
+
+	config := Config{
+		MinPrecision: -1,
+		MaxPrecision: 3,
+		MinExponent:  -2,
+		MaxExponent:  6,
+		Negative:     true,
+		Special:      true,
+		Locales:      []string{"en", "de-DE", "fr"},
+	}
+
+	g, err := NewGenerator(1, config)
+
+	if err != nil {
+
+		t.Fatalf("Expected: nil but received: %v testing NewGenerator", err)
+	}
+
+	var negatives, specials, ties int
+
+	for _, c := range g.Cases(10000) {
+
+		f, _ := decimals.NewFormatter(c.Locale)
+
+		if s := f.FormatFloat(c.Value, c.Precision); s != c.Expected {
+
+			t.Fatalf("Expected: %q but received: %q testing case %+v", s, c.Expected, c)
+		}
+
+		if c.Precision < config.MinPrecision || c.Precision > config.MaxPrecision {
+
+			t.Fatalf("Expected: a precision from %d to %d but received: %d testing Next",
+				config.MinPrecision, config.MaxPrecision, c.Precision)
+		}
+
+		x := math.Abs(c.Value)
+
+		switch {
+
+		case math.IsNaN(x) || math.IsInf(x, 0) || x == 0:
+
+			specials++
+			continue
+
+		case x < 1e-2 || x >= 1e7:
+
+			t.Fatalf("Expected: a magnitude from 1e-2 to below 1e7 but received: %v testing Next", c.Value)
+		}
+
+		if c.Value < 0 {
+
+			negatives++
+		}
+
+		// Ties end in a five in the place after the precision
+		if decimals.DecimalPlacesOf(x) == c.Precision+1 && strings.HasSuffix(decimals.CanonicalString(x), "5") {
+
+			ties++
+		}
+	}
+
+	if negatives < 4000 || specials < 300 || ties < 1500 {
+
+		t.Errorf("Expected: a mix of cases but received: %d negative, %d special and %d ties testing Next",
+			negatives, specials, ties)
+	}
+}
+
+// Test generators return the same cases for the same seed
+func TestGeneratorSeed(t *testing.T) {
+
+	config := Config{MaxPrecision: 2, MaxExponent: 12, Negative: true}
+
+	a, _ := NewGenerator(7, config)
+	b, _ := NewGenerator(7, config)
+
+	for i, c := range a.Cases(100) {
+
+		if d := b.Next(); d != c {
+
+			t.Fatalf("Expected: %+v but received: %+v testing case %d", c, d, i)
+		}
+	}
+}
+
+// Test NewGenerator rejects invalid configurations
+func TestNewGeneratorErrors(t *testing.T) {
+
+	inputs := []Config{
+		{MinPrecision: 2, MaxPrecision: 1},
+		{MinExponent: 3, MaxExponent: 2},
+		{MaxExponent: 400},
+		{Locales: []string{"xx-invalid-"}},
+	}
+
+	for _, config := range inputs {
+
+		if _, err := NewGenerator(1, config); err == nil {
+
+			t.Errorf("Expected: an error but received: nil testing NewGenerator(%+v)", config)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package decimals
+
+import (
+	"strings"
+)
+
+// A DetectedFormat describes the separators found in formatted numbers by
+// DetectFormat and DetectColumnFormat. A separator is empty if nothing
+// suggested it.
+type DetectedFormat struct {
+	DecimalSeparator string
+	GroupSeparator   string
+
+	// Ambiguous reports that the numbers read as different values under
+	// the decimal point and decimal comma conventions, as "1,234" does. The
+	// separators are then those of the decimal point convention.
+	Ambiguous bool
+}
+
+// detectSeparators are the separators recognised by DetectFormat
+var detectSeparators = []string{".", ",", "'", "\u2019", " ", NoBreakSpace, NarrowNoBreakSpace, "\u2009"}
+
+// DetectFormat detects the decimal and group separators of a formatted
+// number, so that an import can tell the decimal point convention of
+// "1,234.56" from the decimal comma convention of "1.234,56". A separator
+// that appears more than once, or before a different one, is a group
+// separator. A lone "." or "," followed by exactly three digits could be
+// either and is reported as Ambiguous. The other separator of the pair is
+// assumed if only one is seen. It returns ErrSyntax if s is not a number
+// made up of digits and separators, with an optional sign.
+func DetectFormat(s string) (DetectedFormat, error) {
+
+	df, ok := detectRaw(s)
+
+	if !ok {
+
+		return DetectedFormat{}, &NumError{"DetectFormat", s, ErrSyntax}
+	}
+
+	return df.complete(), nil
+}
+
+// DetectColumnFormat detects the separators shared by a column of formatted
+// numbers, such as a column of a CSV file, as DetectFormat does for one
+// number. Values that are not numbers, such as blank cells, are skipped.
+// Unambiguous values decide the separators, so "1,234" in a column that
+// also holds "0,5" is read with a decimal comma. The result is Ambiguous if
+// no value decides them or if values disagree. It returns ErrEmpty if no
+// value is a number.
+func DetectColumnFormat(values []string) (DetectedFormat, error) {
+
+	var (
+		df        DetectedFormat
+		found     bool
+		ambiguous bool
+	)
+
+	for _, s := range values {
+
+		v, ok := detectRaw(s)
+
+		if !ok {
+
+			continue
+		}
+
+		found = true
+
+		if v.Ambiguous {
+
+			ambiguous = true
+			continue
+		}
+
+		// Keep the first separator seen of each kind, and flag conflicts
+		df.DecimalSeparator, ok = mergeSeparator(df.DecimalSeparator, v.DecimalSeparator)
+		df.Ambiguous = df.Ambiguous || !ok
+		df.GroupSeparator, ok = mergeSeparator(df.GroupSeparator, v.GroupSeparator)
+		df.Ambiguous = df.Ambiguous || !ok
+	}
+
+	if !found {
+
+		return DetectedFormat{}, ErrEmpty
+	}
+
+	if df.DecimalSeparator != "" && df.DecimalSeparator == df.GroupSeparator {
+
+		df.Ambiguous = true
+	}
+
+	// Ambiguous values not decided by a separator seen elsewhere are read
+	// with the decimal point convention
+	if ambiguous && df.DecimalSeparator == "" && df.GroupSeparator == "" {
+
+		df = DetectedFormat{".", ",", true}
+	}
+
+	return df.complete(), nil
+}
+
+// ParseFloat parses a number formatted with the detected separators, as
+// the package level ParseFloat does with the same flags.
+func (df DetectedFormat) ParseFloat(s string, flags ParseFlag) (float64, error) {
+
+	var pairs []string
+
+	if df.GroupSeparator != "" {
+
+		pairs = append(pairs, df.GroupSeparator, ",")
+	}
+
+	if df.DecimalSeparator != "" {
+
+		pairs = append(pairs, df.DecimalSeparator, ".")
+	}
+
+	r, err := ParseFloat(strings.NewReplacer(pairs...).Replace(s), flags)
+
+	if err != nil {
+
+		err = &NumError{"DetectedFormat.ParseFloat", s, err.(*NumError).Err}
+	}
+
+	return r, err
+}
+
+// mergeSeparator returns the separator seen so far, or the new one if none
+// has been, and reports whether they agree.
+func mergeSeparator(seen, s string) (string, bool) {
+
+	if seen == "" {
+
+		return s, true
+	}
+
+	return seen, s == "" || s == seen
+}
+
+// complete fills in the separator that pairs with the one detected, when
+// only one was.
+func (df DetectedFormat) complete() DetectedFormat {
+
+	switch {
+
+	case df.DecimalSeparator == "" && df.GroupSeparator == ",":
+
+		df.DecimalSeparator = "."
+
+	case df.DecimalSeparator == "" && df.GroupSeparator == ".":
+
+		df.DecimalSeparator = ","
+
+	case df.DecimalSeparator == "" && (df.GroupSeparator == "'" || df.GroupSeparator == "\u2019"):
+
+		df.DecimalSeparator = "."
+
+	case df.GroupSeparator == "" && df.DecimalSeparator == ".":
+
+		df.GroupSeparator = ","
+
+	case df.GroupSeparator == "" && df.DecimalSeparator == ",":
+
+		df.GroupSeparator = "."
+	}
+
+	return df
+}
+
+// detectRaw detects the separators of a single number without assuming
+// the separator that pairs with one detected. It reports false if s is not
+// a number.
+func detectRaw(s string) (DetectedFormat, bool) {
+
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+
+		s = s[1:]
+	}
+
+	// Split s into runs of digits and the separators between them
+	var (
+		groups []string
+		seps   []string
+		start  int
+	)
+
+	for i := 0; i < len(s); {
+
+		if isDigit(s[i]) {
+
+			i++
+			continue
+		}
+
+		sep := matchSeparator(s[i:])
+
+		if sep == "" {
+
+			return DetectedFormat{}, false
+		}
+
+		groups = append(groups, s[start:i])
+		seps = append(seps, sep)
+		i += len(sep)
+		start = i
+	}
+
+	groups = append(groups, s[start:])
+
+	// Every run must have digits, except a leading one before a decimal
+	for i, g := range groups {
+
+		if g == "" && (i > 0 || len(seps) != 1) {
+
+			return DetectedFormat{}, false
+		}
+	}
+
+	if len(seps) == 0 {
+
+		return DetectedFormat{}, true
+	}
+
+	var (
+		last  = seps[len(seps)-1]
+		first = seps[0]
+	)
+
+	// The separators before the last must all be the group separator
+	for _, sep := range seps[:len(seps)-1] {
+
+		if sep != first {
+
+			return DetectedFormat{}, false
+		}
+	}
+
+	// A final separator that differs from the others is the decimal
+	// separator
+	if last != first {
+
+		if last != "." && last != "," {
+
+			return DetectedFormat{}, false
+		}
+
+		return DetectedFormat{DecimalSeparator: last, GroupSeparator: first}, true
+	}
+
+	if len(seps) > 1 || (last != "." && last != ",") {
+
+		return DetectedFormat{GroupSeparator: last}, true
+	}
+
+	// A lone "." or "," is a group separator only if it is followed by
+	// three digits and preceded by one to three, without a leading zero
+	lead, tail := groups[0], groups[1]
+
+	if len(tail) != 3 || len(lead) == 0 || len(lead) > 3 || lead[0] == '0' {
+
+		return DetectedFormat{DecimalSeparator: last}, true
+	}
+
+	return DetectedFormat{".", ",", true}, true
+}
+
+// matchSeparator returns the separator at the start of s, or the empty
+// string if there is none.
+func matchSeparator(s string) string {
+
+	for _, sep := range detectSeparators {
+
+		if strings.HasPrefix(s, sep) {
+
+			return sep
+		}
+	}
+
+	return ""
+}
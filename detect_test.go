@@ -0,0 +1,125 @@
+package decimals
+
+import (
+	"testing"
+)
+
+// Test DetectFormat finds the separators of single numbers
+func TestDetectFormat(t *testing.T) {
+
+	inputs := []string{
+		"1,234.56",
+		"1.234,56",
+		"-1.234.567",
+		"1,234,567",
+		"1 234,5",
+		"1'234'567.5",
+		"12,5",
+		"0.125",
+		"1234.5678",
+		".5",
+		"1,234",
+		"1.234",
+		"1234",
+	}
+
+	expected := []DetectedFormat{
+		{".", ",", false},
+		{",", ".", false},
+		{",", ".", false},
+		{".", ",", false},
+		{",", " ", false},
+		{".", "'", false},
+		{",", ".", false},
+		{".", ",", false},
+		{".", ",", false},
+		{".", ",", false},
+		{".", ",", true},
+		{".", ",", true},
+		{"", "", false},
+	}
+
+	for i, input := range inputs {
+
+		output, err := DetectFormat(input)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %+v but received: %+v (%v) testing DetectFormat(%q)", expected[i], output, err, input)
+		}
+	}
+}
+
+// Test DetectFormat rejects strings that are not numbers
+func TestDetectFormatErrors(t *testing.T) {
+
+	inputs := []string{"", "abc", "1,,234", "1.234,56.7", "1,234 5", "12.", "$12"}
+
+	for _, input := range inputs {
+
+		if _, err := DetectFormat(input); err == nil {
+
+			t.Errorf("Expected: an error but received: nil testing DetectFormat(%q)", input)
+		}
+	}
+}
+
+// Test DetectColumnFormat combines the evidence of a column
+func TestDetectColumnFormat(t *testing.T) {
+
+	inputs := [][]string{
+		{"1,234", "0,5", ""},
+		{"1.234", "2.500", "12"},
+		{"1.234", "1,5", "n/a"},
+		{"1,5", "1.5"},
+		{"1 234", "12,75"},
+	}
+
+	expected := []DetectedFormat{
+		{",", ".", false},
+		{".", ",", true},
+		{",", ".", false},
+		{",", ".", true},
+		{",", " ", false},
+	}
+
+	for i, input := range inputs {
+
+		output, err := DetectColumnFormat(input)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %+v but received: %+v (%v) testing DetectColumnFormat(%q)", expected[i], output, err, input)
+		}
+	}
+
+	if _, err := DetectColumnFormat([]string{"", "total"}); err != ErrEmpty {
+
+		t.Errorf("Expected: %v but received: %v testing DetectColumnFormat", ErrEmpty, err)
+	}
+}
+
+// Test DetectedFormat.ParseFloat parses with the detected separators
+func TestDetectedFormatParseFloat(t *testing.T) {
+
+	var (
+		formats  = []DetectedFormat{{",", ".", false}, {",", ".", false}, {".", "'", false}, {".", ",", false}}
+		inputs   = []string{"1.234,56", "-0,5", "1'234'567.5", "1,234.5"}
+		expected = []float64{1234.56, -0.5, 1234567.5, 1234.5}
+	)
+
+	for i, input := range inputs {
+
+		output, err := formats[i].ParseFloat(input, ParseStrict)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v (%v) testing DetectedFormat.ParseFloat(%q)", expected[i], output, err, input)
+		}
+	}
+
+	if _, err := formats[0].ParseFloat("1,234.5", ParseStrict); err == nil {
+
+		t.Errorf("Expected: an error but received: nil testing DetectedFormat.ParseFloat")
+	}
+}
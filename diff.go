@@ -0,0 +1,186 @@
+package decimals
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// A TextRange is a range of bytes within a string, from Start up to but not
+// including End.
+type TextRange struct {
+	Start int
+	End   int
+}
+
+// DiffFormat formats a new value and finds the digits that changed from an
+// old value using the default formatter. See Formatter.DiffFormat.
+func DiffFormat(old, new float64, precision int) (string, []TextRange) {
+
+	return DefaultFormatter().DiffFormat(old, new, precision)
+}
+
+// DiffFormat formats the new value as FormatFloat does and returns it with
+// the ranges of bytes holding digits that differ from the old value, so
+// that dashboards can highlight exactly which digits changed between
+// refreshes. Digits are compared by place value once both values are
+// rounded, so 1,234.50 and 1,294.50 differ only in the tens digit. If the
+// sign changes the markers of the formatter's SignStyle are included in the
+// ranges, and adjacent changed digits are merged into one range across any
+// separators. If either value is NaN or infinite the whole output is a
+// single range when the outputs differ.
+func (f Formatter) DiffFormat(old, new float64, precision int) (string, []TextRange) {
+
+	s := f.FormatFloat(new, precision)
+
+	if isSpecial(old) || isSpecial(new) {
+
+		if s == f.FormatFloat(old, precision) {
+
+			return s, nil
+		}
+
+		return s, []TextRange{{0, len(s)}}
+	}
+
+	var (
+		od, _   = floatDecimal(old)
+		nd, _   = floatDecimal(new)
+		lf      = f.floatLimit()
+		oldDigs = make(map[int]int)
+		ranges  []TextRange
+	)
+
+	oldNeg := lf.WriteDecimalDigits(od, precision, func(digit int, position int, _ bool) {
+
+		oldDigs[position] = digit
+	})
+
+	// Find where the number starts within the template
+	offset := 0
+
+	if f.Template != "" {
+
+		offset = strings.Index(f.Template, TemplatePlaceholder)
+
+		if offset < 0 {
+
+			offset = len(f.Template)
+		}
+	}
+
+	// Collect the digits of the new value
+	var newDigs [][2]int
+
+	newNeg := lf.WriteDecimalDigits(nd, precision, func(digit int, position int, _ bool) {
+
+		newDigs = append(newDigs, [2]int{digit, position})
+	})
+
+	// Mark the prefix of a changed sign
+	st := f.SignStyle
+
+	if st == (SignStyle{}) {
+
+		st = MinusSign
+	}
+
+	prefix, suffix := st.PositivePrefix, st.PositiveSuffix
+
+	if newNeg {
+
+		prefix, suffix = st.NegativePrefix, st.NegativeSuffix
+	}
+
+	i := offset + len(prefix)
+
+	if newNeg != oldNeg && prefix != "" {
+
+		ranges = append(ranges, TextRange{offset, i})
+	}
+
+	// Walk the digits of the new value through the formatted string,
+	// merging runs of changed digits. The digits may be those of any
+	// script set by the formatter's Digits.
+	for _, dp := range newDigs {
+
+		var size int
+
+		// Stop at the end of the output should the digits not match it
+		if i, size = nextDigit(s, i); size == 0 {
+
+			return s, ranges
+		}
+
+		if o, ok := oldDigs[dp[1]]; !ok || o != dp[0] {
+
+			if n := len(ranges); n > 0 && isSeparated(s[ranges[n-1].End:i]) {
+
+				ranges[n-1].End = i + size
+
+			} else {
+
+				ranges = append(ranges, TextRange{i, i + size})
+			}
+		}
+
+		i += size
+	}
+
+	// Mark the suffix of a changed sign
+	if newNeg != oldNeg && suffix != "" {
+
+		ranges = append(ranges, TextRange{i, i + len(suffix)})
+	}
+
+	return s, ranges
+}
+
+// isSpecial reports whether x is NaN or infinite.
+func isSpecial(x float64) bool {
+
+	return math.IsNaN(x) || math.IsInf(x, 0)
+}
+
+// nextDigit returns the byte offset and width of the first digit of any
+// script at or after byte offset i of s, or len(s) and zero if there is
+// none.
+func nextDigit(s string, i int) (int, int) {
+
+	for i < len(s) {
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		if isDigitRune(r) {
+
+			return i, size
+		}
+
+		i += size
+	}
+
+	return len(s), 0
+}
+
+// isSeparated reports whether s holds no digits, so that changed digits
+// either side of it belong to the same range.
+func isSeparated(s string) bool {
+
+	for _, r := range s {
+
+		if isDigitRune(r) {
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDigitRune reports whether r is a decimal digit of any script.
+func isDigitRune(r rune) bool {
+
+	_, ok := digitValue(r)
+
+	return ok
+}
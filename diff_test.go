@@ -0,0 +1,124 @@
+package decimals
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Test DiffFormat finds the changed digits by place value
+func TestDiffFormat(t *testing.T) {
+
+	var (
+		olds = []float64{
+			1234.5, 1234.5, 1999, 9.99, 0.5, 1234.5, -5, 5, 1.004, math.NaN(), 1, math.Inf(1)}
+		news = []float64{
+			1294.5, 1234.5, 2000, 10.01, 10.5, 1235.6, 5, -5, 1.001, 1, 1, math.Inf(1)}
+	)
+
+	expected := []struct {
+		s      string
+		ranges []TextRange
+	}{
+		{"1,294.50", []TextRange{{3, 4}}},
+		{"1,234.50", nil},
+		{"2,000.00", []TextRange{{0, 5}}},
+		{"10.01", []TextRange{{0, 5}}},
+		{"10.50", []TextRange{{0, 1}}},
+		{"1,235.60", []TextRange{{4, 7}}},
+		{"5.00", nil},
+		{"-5.00", []TextRange{{0, 1}}},
+		{"1.00", nil},
+		{"1.00", []TextRange{{0, 4}}},
+		{"1.00", nil},
+		{"Inf", nil},
+	}
+
+	for i := range olds {
+
+		s, ranges := DiffFormat(olds[i], news[i], 2)
+
+		if s != expected[i].s || !reflect.DeepEqual(ranges, expected[i].ranges) {
+
+			t.Errorf("Expected: %q %v but received: %q %v testing DiffFormat(%v, %v, 2)",
+				expected[i].s, expected[i].ranges, s, ranges, olds[i], news[i])
+		}
+	}
+}
+
+// Test DiffFormat offsets the ranges by the template and locale separators
+func TestDiffFormatTemplate(t *testing.T) {
+
+	f, _ := NewFormatter("fr-FR")
+	f.Template = "€ {}"
+
+	s, ranges := f.DiffFormat(-1234.5, -1334.5, 1)
+	expected := []TextRange{{9, 10}}
+
+	if s != "€ -1\u202F334,5" || !reflect.DeepEqual(ranges, expected) {
+
+		t.Errorf("Expected: %q %v but received: %q %v testing Formatter.DiffFormat",
+			"€ -1\u202F334,5", expected, s, ranges)
+	}
+}
+
+// Test DiffFormat includes the sign style's markers when the sign changes
+func TestDiffFormatSignStyle(t *testing.T) {
+
+	f := Formatter{GroupSeparator: ",", SignStyle: Parentheses}
+
+	s, ranges := f.DiffFormat(5, -5, 0)
+	expected := []TextRange{{0, 1}, {2, 3}}
+
+	if s != "(5)" || !reflect.DeepEqual(ranges, expected) {
+
+		t.Errorf("Expected: %q %v but received: %q %v testing Formatter.DiffFormat", "(5)", expected, s, ranges)
+	}
+}
+
+// Test DiffFormat finds digits substituted by the formatter's DigitSet
+func TestDiffFormatDigits(t *testing.T) {
+
+	f := Formatter{GroupSeparator: ",", DecimalSeparator: ".", Digits: PersianDigits}
+
+	s, ranges := f.DiffFormat(1234.5, 1294.5, 2)
+	expected := []TextRange{{5, 7}}
+
+	if s != "۱,۲۹۴.۵۰" || !reflect.DeepEqual(ranges, expected) {
+
+		t.Errorf("Expected: %q %v but received: %q %v testing Formatter.DiffFormat", "۱,۲۹۴.۵۰", expected, s, ranges)
+	}
+}
+
+// Test DiffFormat limits float digits as FormatFloat does
+func TestDiffFormatPrecisionPolicy(t *testing.T) {
+
+	f := Formatter{GroupSeparator: ",", DecimalSeparator: ".", PrecisionPolicy: PrecisionCap}
+
+	s, ranges := f.DiffFormat(1.5, 123456789.123, 20)
+	expected := []TextRange{{0, 15}}
+
+	if s != "123,456,789.12300000" || !reflect.DeepEqual(ranges, expected) {
+
+		t.Errorf("Expected: %q %v but received: %q %v testing Formatter.DiffFormat", "123,456,789.12300000", expected, s, ranges)
+	}
+}
+
+// Test nextDigit finds digits of any script and stops at the end
+func TestNextDigit(t *testing.T) {
+
+	var (
+		inputs   = []string{"1,234", "$ ۱۲", "abc", ""}
+		starts   = []int{1, 0, 0, 0}
+		expected = [][2]int{{2, 1}, {2, 2}, {3, 0}, {0, 0}}
+	)
+
+	for i, s := range inputs {
+
+		if offset, size := nextDigit(s, starts[i]); offset != expected[i][0] || size != expected[i][1] {
+
+			t.Errorf("Expected: %v but received: [%d %d] testing nextDigit(%q, %d)",
+				expected[i], offset, size, s, starts[i])
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package decimals
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FloatToDecimal decomposes a float64 into the shortest decimal that
+// converts back to the same float, as x = sign × digits × 10^exponent.
+// Sign is -1, 0 or 1, and digits is a string of decimal digits with no
+// leading or trailing zeros. Zero, including negative zero, is returned as
+// sign 0 and digits "0". NaN is returned as sign 0 and digits "NaN", and
+// the infinities as sign -1 or 1 and digits "Inf", each with exponent 0.
+func FloatToDecimal(x float64) (sign int, digits string, exponent int) {
+
+	switch {
+
+	case math.IsNaN(x):
+
+		return 0, "NaN", 0
+
+	case math.IsInf(x, 0):
+
+		return int(math.Copysign(1, x)), "Inf", 0
+
+	case x == 0:
+
+		return 0, "0", 0
+	}
+
+	sign = 1
+
+	if x < 0 {
+
+		sign = -1
+	}
+
+	// Split the shortest representation in exponent notation, such as
+	// "1.2345e+03", into its digits and exponent
+	xstr := strconv.FormatFloat(math.Abs(x), 'e', -1, 64)
+	e := strings.IndexByte(xstr, 'e')
+	exponent, _ = strconv.Atoi(xstr[e+1:])
+	digits = strings.Replace(xstr[:e], ".", "", 1)
+
+	// Move the exponent to the last digit
+	exponent -= len(digits) - 1
+
+	return sign, digits, exponent
+}
+
+// CanonicalString returns the shortest decimal that converts back to x as
+// a locale independent string, for use as a map, cache or deduplication
+// key: equal floats give equal strings and different floats different
+// ones, except that negative zero is "0", as zero is. The form is that of
+// ECMAScript's Number.prototype.toString, and will not change in future
+// versions of the package. Numbers from 1e-6 to below 1e21 in magnitude
+// are written in plain notation, as in "1234.5" and "0.000001", and others
+// in exponent notation, as in "1e+21" and "1.5e-7". NaN and the
+// infinities are "NaN", "Inf" and "-Inf".
+func CanonicalString(x float64) string {
+
+	sign, digits, exponent := FloatToDecimal(x)
+
+	switch {
+
+	case math.IsNaN(x) || math.IsInf(x, 0):
+
+		return formatSpecial(x)
+
+	case sign == 0:
+
+		return "0"
+	}
+
+	var (
+		s string
+		k = len(digits)
+		n = k + exponent // the number of digits before the point
+	)
+
+	switch {
+
+	case k <= n && n <= 21:
+
+		s = digits + strings.Repeat("0", n-k)
+
+	case 0 < n && n <= 21:
+
+		s = digits[:n] + "." + digits[n:]
+
+	case -6 < n && n <= 0:
+
+		s = "0." + strings.Repeat("0", -n) + digits
+
+	default:
+
+		s = digits[:1]
+
+		if k > 1 {
+
+			s += "." + digits[1:]
+		}
+
+		if n-1 >= 0 {
+
+			s += "e+" + strconv.Itoa(n-1)
+
+		} else {
+
+			s += "e" + strconv.Itoa(n-1)
+		}
+	}
+
+	if sign < 0 {
+
+		return "-" + s
+	}
+
+	return s
+}
+
+// A DigitFunc receives the digits of a number from WriteDigits one at a
+// time. The position is the power of ten of the digit, so the units digit
+// is at position 0 and the tenths digit at position -1, and the decimal
+// separator falls between them. GroupBoundary reports whether a group
+// separator follows the digit in the formatter's output.
+type DigitFunc func(digit int, position int, groupBoundary bool)
+
+// WriteDigits streams the digits of a float64 using the default formatter.
+// See Formatter.WriteDigits.
+func WriteDigits(x float64, precision int, fn DigitFunc) (negative bool, err error) {
+
+	return DefaultFormatter().WriteDigits(x, precision, fn)
+}
+
+// WriteDigits rounds a float64 as FormatFloat does and calls fn for each
+// digit of its absolute value, from the most significant, so that custom
+// renderers such as charts, PDF generators and seven-segment displays can
+// draw numbers without parsing formatted strings. It reports whether the
+// rounded number is negative, so that a minus sign can be drawn before the
+// digits. An error wrapping ErrRange is returned if x is NaN or infinite.
+func (f Formatter) WriteDigits(x float64, precision int, fn DigitFunc) (negative bool, err error) {
+
+	d, err := floatDecimal(x)
+
+	if err != nil {
+
+		return false, err
+	}
+
+	return f.floatLimit().WriteDecimalDigits(d, precision, fn), nil
+}
+
+// WriteDecimalDigits rounds a Decimal as FormatDecimal does and calls fn
+// for each digit of its absolute value, as WriteDigits does. It reports
+// whether the rounded number is negative.
+func (f Formatter) WriteDecimalDigits(d Decimal, precision int, fn DigitFunc) bool {
+
+	r, places := f.roundSignificant(d, precision, HalfUp)
+
+	if places < 0 {
+
+		places = 0
+	}
+
+	is, fs := r.parts(places)
+	is = f.padInteger(is, fs != "")
+
+	// Integer digits are followed by a separator at every third position,
+	// if the number is long enough to be grouped
+	grouped := f.GroupSeparator != "" && len(is) >= f.GroupingThreshold
+
+	for i := 0; i < len(is); i++ {
+
+		position := len(is) - 1 - i
+		fn(int(is[i]-'0'), position, grouped && position > 0 && position%3 == 0)
+	}
+
+	for i := 0; i < len(fs); i++ {
+
+		fn(int(fs[i]-'0'), -1-i, false)
+	}
+
+	return r.Sign() < 0
+}
@@ -0,0 +1,180 @@
+package decimals
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// Test FloatToDecimal with a range of values
+func TestFloatToDecimal(t *testing.T) {
+
+	type decimal struct {
+		sign     int
+		digits   string
+		exponent int
+	}
+
+	inputs := []float64{
+		1234.5,
+		-0.001,
+		100,
+		0.1,
+		2.675,
+		5e-324,
+		math.MaxFloat64,
+		0,
+		math.Copysign(0, -1),
+		math.NaN(),
+		math.Inf(-1),
+	}
+
+	expected := []decimal{
+		{1, "12345", -1},
+		{-1, "1", -3},
+		{1, "1", 2},
+		{1, "1", -1},
+		{1, "2675", -3},
+		{1, "5", -324},
+		{1, "17976931348623157", 292},
+		{0, "0", 0},
+		{0, "0", 0},
+		{0, "NaN", 0},
+		{-1, "Inf", 0},
+	}
+
+	for i, n := range inputs {
+
+		sign, digits, exponent := FloatToDecimal(n)
+		output := decimal{sign, digits, exponent}
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %+v but received: %+v testing FloatToDecimal(%v)",
+				expected[i], output, n)
+		}
+	}
+}
+
+// Test CanonicalString with values either side of the limits of plain
+// notation
+func TestCanonicalString(t *testing.T) {
+
+	inputs := []float64{
+		0, math.Copysign(0, -1), 1, -1234.5, 100, 0.1, 1e-6, 1.5e-7, 123e18, 1e21, -1.25e21,
+		5e-324, math.MaxFloat64, 0.000123, math.Inf(1), math.Inf(-1), math.NaN(),
+	}
+
+	expected := []string{
+		"0", "0", "1", "-1234.5", "100", "0.1", "0.000001", "1.5e-7", "123000000000000000000", "1e+21", "-1.25e+21",
+		"5e-324", "1.7976931348623157e+308", "0.000123", "Inf", "-Inf", "NaN",
+	}
+
+	for i, n := range inputs {
+
+		if output := CanonicalString(n); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing CanonicalString(%v)", expected[i], output, n)
+		}
+	}
+
+	// Canonical strings convert back to the same float
+	for _, x := range monotonicFloats() {
+
+		if r, err := strconv.ParseFloat(CanonicalString(x), 64); err != nil || r != x {
+
+			t.Errorf("Expected: %v but received: %v (%v) testing CanonicalString(%v)", x, r, err, x)
+		}
+	}
+}
+
+// Test WriteDigits streams digits that rebuild the formatted number
+func TestWriteDigits(t *testing.T) {
+
+	inputs := []float64{1234567.891, -0.005, -0.004, 999.999, 0, 1e21}
+
+	expected := []string{
+		"1,234,567.89",
+		"-0.01",
+		"0.00",
+		"1,000.00",
+		"0.00",
+		"1,000,000,000,000,000,000,000.00",
+	}
+
+	for i, input := range inputs {
+
+		var (
+			b         []byte
+			positions []int
+		)
+
+		negative, err := WriteDigits(input, 2, func(digit int, position int, groupBoundary bool) {
+
+			if position == -1 {
+
+				b = append(b, '.')
+			}
+
+			b = append(b, byte('0'+digit))
+			positions = append(positions, position)
+
+			if groupBoundary {
+
+				b = append(b, ',')
+			}
+		})
+
+		output := string(b)
+
+		if negative {
+
+			output = "-" + output
+		}
+
+		if err != nil || output != expected[i] || positions[len(positions)-1] != -2 {
+
+			t.Errorf("Expected: %q but received: %q (%v) with positions %v testing WriteDigits(%v, 2)",
+				expected[i], output, err, positions, input)
+		}
+	}
+
+	if _, err := WriteDigits(math.NaN(), 2, func(int, int, bool) {}); err == nil {
+
+		t.Errorf("Expected: error but received: nil testing WriteDigits(NaN, 2)")
+	}
+}
+
+// Test WriteDigits limits float digits as FormatFloat does under each
+// precision policy
+func TestWriteDigitsPrecisionPolicy(t *testing.T) {
+
+	for _, policy := range []PrecisionPolicy{PrecisionCap, PrecisionError} {
+
+		var (
+			f = Formatter{GroupSeparator: ",", PrecisionPolicy: policy}
+			b []byte
+		)
+
+		f.WriteDigits(123456789.123, 20, func(digit int, position int, groupBoundary bool) {
+
+			if position == -1 {
+
+				b = append(b, '.')
+			}
+
+			b = append(b, byte('0'+digit))
+
+			if groupBoundary {
+
+				b = append(b, ',')
+			}
+		})
+
+		if output, expected := string(b), f.FormatFloat(123456789.123, 20); output != expected {
+
+			t.Errorf("Expected: %q but received: %q testing WriteDigits(123456789.123, 20) with policy %v",
+				expected, output, policy)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// ErrDivisionByZero indicates an attempt to divide by zero.
+var ErrDivisionByZero = errors.New("decimals: division by zero")
+
+// DivRoundInt divides a by b and rounds the quotient to an integer using
+// the given rounding mode, instead of truncating it toward zero as Go's
+// integer division does. It returns ErrDivisionByZero if b is zero, and
+// ErrRange if the quotient overflows an int64.
+func DivRoundInt(a, b int64, mode RoundingMode) (int64, error) {
+
+	q, _, err := DivModInt(a, b, mode)
+
+	return q, err
+}
+
+// DivModInt divides a by b, rounding the quotient to an integer using the
+// given rounding mode, and returns the quotient and the remainder such
+// that a = q*b + r. With Down the results match Go's / and % operators,
+// and with Floor the remainder has the sign of the divisor. It returns
+// ErrDivisionByZero if b is zero, and ErrRange if the quotient overflows
+// an int64.
+func DivModInt(a, b int64, mode RoundingMode) (q, r int64, err error) {
+
+	if b == 0 {
+
+		return 0, 0, ErrDivisionByZero
+	}
+
+	if a == math.MinInt64 && b == -1 {
+
+		return 0, 0, ErrRange
+	}
+
+	q, r = a/b, a%b
+
+	if r == 0 {
+
+		return q, r, nil
+	}
+
+	// Compare twice the remainder with the divisor by magnitude, to find
+	// whether the discarded fraction is below, at or above one half
+	var (
+		negative   bool   = (a < 0) != (b < 0)
+		ar         uint64 = absUint64(r)
+		ab         uint64 = absUint64(b)
+		roundDigit byte   = 5
+		sticky     bool   = 2*ar != ab
+	)
+
+	if 2*ar < ab {
+
+		roundDigit = 4
+	}
+
+	if !roundsUp(mode, negative, roundDigit, sticky, q%2 != 0) {
+
+		return q, r, nil
+	}
+
+	// Move the quotient away from zero and adjust the remainder to match
+	if negative {
+
+		return q - 1, r + b, nil
+	}
+
+	return q + 1, r - b, nil
+}
+
+// Mul returns the exact product of d and y, with as many decimal places as
+// d and y together, so 1.50 × 0.2 is 0.300.
+func (d Decimal) Mul(y Decimal) Decimal {
+
+	c := new(big.Int).Mul(d.coefficient(), y.coefficient())
+
+	return bigDecimal(c, d.exponent+y.exponent)
+}
+
+// Div divides d by y and rounds the quotient to the given scale, the number
+// of decimal places, using the given rounding mode. A negative scale
+// rounds to a power of ten, as a negative precision does for Round. It
+// returns ErrDivisionByZero if y is zero.
+func (d Decimal) Div(y Decimal, scale int, mode RoundingMode) (Decimal, error) {
+
+	q, _, err := d.DivRem(y, scale, mode)
+
+	return q, err
+}
+
+// DivRem divides d by y, rounding the quotient to the given scale using the
+// given rounding mode as Div does, and returns the quotient and the exact
+// remainder such that d = q*y + r. For example, dividing 100.00 into three
+// instalments at scale 2 with Down gives 33.33 with 0.01 left over. With
+// Down the remainder has the sign of d, and with Floor the sign of y. It
+// returns ErrDivisionByZero if y is zero.
+func (d Decimal) DivRem(y Decimal, scale int, mode RoundingMode) (q, r Decimal, err error) {
+
+	if y.Sign() == 0 {
+
+		return Decimal{}, Decimal{}, ErrDivisionByZero
+	}
+
+	var (
+		num      = d.coefficient()
+		den      = y.coefficient()
+		negative = d.Sign()*y.Sign() < 0
+		shift    = d.exponent - y.exponent + scale
+	)
+
+	// Scale the coefficients so the quotient's last place is the units
+	if shift >= 0 {
+
+		num.Mul(num, pow10Big(shift))
+
+	} else {
+
+		den.Mul(den, pow10Big(-shift))
+	}
+
+	qc := roundQuo(num.Abs(num), den.Abs(den), negative, mode)
+
+	if negative {
+
+		qc.Neg(qc)
+	}
+
+	// The remainder d - q*y has the exponent of the smaller of d's last
+	// place and the last place of q*y
+	var (
+		dc  = d.coefficient()
+		qy  = new(big.Int).Mul(qc, y.coefficient())
+		qye = y.exponent - scale
+		exp = d.exponent
+	)
+
+	if qye < exp {
+
+		exp = qye
+	}
+
+	dc.Mul(dc, pow10Big(d.exponent-exp))
+	qy.Mul(qy, pow10Big(qye-exp))
+	dc.Sub(dc, qy)
+
+	return bigDecimal(qc, -scale), bigDecimal(dc, exp), nil
+}
+
+// coefficient returns the signed digits of d as a big.Int, so that d is
+// the coefficient × 10^exponent.
+func (d Decimal) coefficient() *big.Int {
+
+	c, _ := new(big.Int).SetString("0"+d.digits, 10)
+
+	if d.negative {
+
+		c.Neg(c)
+	}
+
+	return c
+}
+
+// bigDecimal returns the Decimal c × 10^exponent.
+func bigDecimal(c *big.Int, exponent int) Decimal {
+
+	return newDecimal(c.Sign() < 0, new(big.Int).Abs(c).String(), exponent)
+}
+
+// absUint64 returns the absolute value of x as a uint64, which represents
+// the absolute value of the minimum int64 correctly.
+func absUint64(x int64) uint64 {
+
+	if x < 0 {
+
+		return uint64(-(x + 1)) + 1
+	}
+
+	return uint64(x)
+}
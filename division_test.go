@@ -0,0 +1,158 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Test DivModInt with each rounding mode
+func TestDivModInt(t *testing.T) {
+
+	inputs := [][2]int64{
+		{1000, 3},
+		{7, 2},
+		{5, 2},
+		{-7, 2},
+		{-5, 2},
+		{7, -2},
+		{-1000, -3},
+		{6, 3},
+		{math.MinInt64, 3},
+		{math.MaxInt64, math.MinInt64},
+	}
+
+	modes := []RoundingMode{HalfUp, HalfEven, HalfDown, Up, Down, Ceiling, Floor}
+
+	expected := [][]int64{
+		{333, 333, 333, 334, 333, 334, 333},
+		{4, 4, 3, 4, 3, 4, 3},
+		{3, 2, 2, 3, 2, 3, 2},
+		{-4, -4, -3, -4, -3, -3, -4},
+		{-3, -2, -2, -3, -2, -2, -3},
+		{-4, -4, -3, -4, -3, -3, -4},
+		{333, 333, 333, 334, 333, 334, 333},
+		{2, 2, 2, 2, 2, 2, 2},
+		{-3074457345618258603, -3074457345618258603, -3074457345618258603,
+			-3074457345618258603, -3074457345618258602, -3074457345618258602,
+			-3074457345618258603},
+		{-1, -1, -1, -1, 0, 0, -1},
+	}
+
+	for i, n := range inputs {
+
+		for j, m := range modes {
+
+			q, r, err := DivModInt(n[0], n[1], m)
+
+			if err != nil || q != expected[i][j] {
+
+				t.Errorf("Expected: %d but received: %d (%v) testing DivModInt(%d, %d, %v)",
+					expected[i][j], q, err, n[0], n[1], m)
+			}
+
+			// The remainder must complete the division exactly
+			if q*n[1]+r != n[0] {
+
+				t.Errorf("Remainder %d does not satisfy a = q*b + r testing DivModInt(%d, %d, %v)",
+					r, n[0], n[1], m)
+			}
+		}
+	}
+}
+
+// Test DivRoundInt reports division by zero and overflow
+func TestDivRoundIntErrors(t *testing.T) {
+
+	if _, err := DivRoundInt(1, 0, HalfUp); !errors.Is(err, ErrDivisionByZero) {
+
+		t.Errorf("Expected: ErrDivisionByZero but received: %v testing DivRoundInt", err)
+	}
+
+	if _, err := DivRoundInt(math.MinInt64, -1, HalfUp); !errors.Is(err, ErrRange) {
+
+		t.Errorf("Expected: ErrRange but received: %v testing DivRoundInt", err)
+	}
+
+	if q, err := DivRoundInt(1999, 100, HalfUp); err != nil || q != 20 {
+
+		t.Errorf("Expected: 20 but received: %d (%v) testing DivRoundInt", q, err)
+	}
+}
+
+// Test Decimal.DivRem with a range of scales and modes
+func TestDecimalDivRem(t *testing.T) {
+
+	inputs := [][2]string{
+		{"100.00", "3"},
+		{"100.00", "3"},
+		{"-100", "3"},
+		{"-100", "3"},
+		{"1", "8"},
+		{"2.5", "0.5"},
+		{"12345678901234567890", "7"},
+		{"1234", "3"},
+	}
+
+	scales := []int{2, 2, 2, 2, 2, 0, 0, -1}
+
+	modes := []RoundingMode{Down, HalfUp, Down, Floor, HalfEven, HalfUp, Down, HalfUp}
+
+	expected := [][2]string{
+		{"33.33", "0.01"},
+		{"33.33", "0.01"},
+		{"-33.33", "-0.01"},
+		{"-33.34", "0.02"},
+		{"0.12", "0.04"},
+		{"5", "0.0"},
+		{"1763668414462081127", "1"},
+		{"410", "4"},
+	}
+
+	for i, n := range inputs {
+
+		d, y := MustParseDecimal(n[0]), MustParseDecimal(n[1])
+		q, r, err := d.DivRem(y, scales[i], modes[i])
+
+		if err != nil || q.String() != expected[i][0] || r.String() != expected[i][1] {
+
+			t.Errorf("Expected: %s r %s but received: %s r %s (%v) testing %s.DivRem(%s, %d, %v)",
+				expected[i][0], expected[i][1], q, r, err, n[0], n[1], scales[i], modes[i])
+		}
+	}
+
+	if _, err := MustParseDecimal("1").Div(Decimal{}, 2, HalfUp); err != ErrDivisionByZero {
+
+		t.Errorf("Expected: ErrDivisionByZero but received: %v testing Decimal.Div", err)
+	}
+
+	if q, _ := MustParseDecimal("2").Div(MustParseDecimal("3"), 4, HalfUp); q.String() != "0.6667" {
+
+		t.Errorf("Expected: 0.6667 but received: %s testing Decimal.Div", q)
+	}
+}
+
+// Test Decimal.Mul multiplies exactly and keeps decimal places
+func TestDecimalMul(t *testing.T) {
+
+	var (
+		inputs = [][2]string{
+			{"1.50", "0.2"},
+			{"-12.5", "4"},
+			{"-3", "-0.001"},
+			{"0.00", "17.5"},
+			{"123456789012345678901234567890", "1e-30"},
+		}
+		expected = []string{"0.300", "-50.0", "0.003", "0.000", "0.123456789012345678901234567890"}
+	)
+
+	for i, input := range inputs {
+
+		output := MustParseDecimal(input[0]).Mul(MustParseDecimal(input[1])).String()
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing %s × %s", expected[i], output, input[0], input[1])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package decimals
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// MaxExactFloatInt returns 2^53, the largest integer up to which every
+// integer can be represented exactly by a float64. Above it floats can
+// only hold every second integer, then every fourth and so on, so integers
+// such as identifiers and amounts in minor units are silently changed by
+// conversion to float64. Use int64 values or ParseDecimal for them instead.
+func MaxExactFloatInt() int64 {
+
+	return 1 << 53
+}
+
+// IsExactInt reports whether x is an integer no greater in magnitude than
+// MaxExactFloatInt, so that it converts to an int64 without loss and the
+// integers either side of it are also representable.
+func IsExactInt(x float64) bool {
+
+	return x == math.Trunc(x) && math.Abs(x) <= float64(MaxExactFloatInt())
+}
+
+// SafeJSONNumber returns x rounded half up to the given precision, as by
+// RoundFloat, as a JSON number in plain decimal notation, and checks that a
+// JavaScript client can parse it without loss. Numbers above 2^53 - 1 in
+// magnitude, beyond Number.MAX_SAFE_INTEGER, may not be the integers they
+// appear to be, so for them the number is returned with an error wrapping
+// ErrRange, leaving the caller to refuse it or send it as a JSON string
+// instead. NaN and the infinities, which JSON cannot encode, return an
+// empty number and an error wrapping ErrRange.
+func SafeJSONNumber(x float64, precision int) (json.Number, error) {
+
+	d, err := DecimalFromFloat(x)
+
+	if err != nil {
+
+		return "", &NumError{"SafeJSONNumber", strconv.FormatFloat(x, 'g', -1, 64), ErrRange}
+	}
+
+	r := d.Round(precision, HalfUp)
+	s := r.String()
+
+	if r.Abs().Cmp(DecimalFromInt(MaxExactFloatInt()-1)) > 0 {
+
+		return json.Number(s), &NumError{"SafeJSONNumber", s, ErrRange}
+	}
+
+	return json.Number(s), nil
+}
+
+// IsInteger reports whether x is a finite float64 with no fractional part.
+// Unlike IsExactInt it is true for integers of any magnitude, including
+// those above MaxExactFloatInt that do not fit in an int64.
+func IsInteger(x float64) bool {
+
+	return !math.IsInf(x, 0) && x == math.Trunc(x)
+}
+
+// HasFraction reports whether x is a finite float64 with a fractional part.
+func HasFraction(x float64) bool {
+
+	return !math.IsInf(x, 0) && !math.IsNaN(x) && x != math.Trunc(x)
+}
+
+// DecimalPlacesOf returns the number of decimal places in the shortest
+// decimal representation of x, so DecimalPlacesOf(2.50) is 1 and
+// DecimalPlacesOf(1e-7) is 7. Integers, NaN and the infinities have no
+// decimal places. Formatting x with FormatFloat at this precision shows
+// every digit needed to identify it and no trailing zeros.
+func DecimalPlacesOf(x float64) int {
+
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+
+		return 0
+	}
+
+	_, _, exponent := FloatToDecimal(x)
+
+	if exponent > 0 {
+
+		return 0
+	}
+
+	return -exponent
+}
@@ -0,0 +1,143 @@
+package decimals
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+// Test IsExactInt either side of the float64 integer limit
+func TestIsExactInt(t *testing.T) {
+
+	max := float64(MaxExactFloatInt())
+
+	inputs := []float64{0, -42, 1.5, max, -max, max + 2, math.Inf(1), math.NaN()}
+
+	expected := []bool{true, true, false, true, true, false, false, false}
+
+	for i, n := range inputs {
+
+		if output := IsExactInt(n); output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v testing IsExactInt(%v)",
+				expected[i], output, n)
+		}
+	}
+
+	if MaxExactFloatInt() != 9007199254740992 {
+
+		t.Errorf("Expected: 9007199254740992 but received: %d testing MaxExactFloatInt",
+			MaxExactFloatInt())
+	}
+}
+
+// Test formatting and rounding integers near the limit use exact digits
+func TestExactIntFormatting(t *testing.T) {
+
+	max := float64(MaxExactFloatInt())
+
+	inputs := []string{
+		FormatFloat(max, 0),
+		FormatFloat(-max, 2),
+		FormatFloat(max-1, 0),
+		FormatFloat(max, -3),
+	}
+
+	expected := []string{
+		"9,007,199,254,740,992",
+		"-9,007,199,254,740,992.00",
+		"9,007,199,254,740,991",
+		"9,007,199,254,741,000",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing exact integer formatting",
+				expected[i], output)
+		}
+	}
+
+	if r := RoundFloat(max-1, 2); r != max-1 {
+
+		t.Errorf("Expected: %v but received: %v testing RoundFloat", max-1, r)
+	}
+}
+
+// Test SafeJSONNumber accepts numbers a JavaScript parse keeps and refuses
+// those it would change
+func TestSafeJSONNumber(t *testing.T) {
+
+	max := float64(MaxExactFloatInt())
+	a, b := 0.1, 0.2
+
+	inputs := []float64{1234.5678, -0.004, 0.5, 5555, max - 1, -(max - 1), a + b, max, -1e20, 5e15, math.Inf(1), math.NaN()}
+
+	precisions := []int{2, 2, 2, -2, 0, 0, 17, 0, 0, -16, 0, 0}
+
+	expected := []json.Number{
+		"1234.57",
+		"0.00",
+		"0.5",
+		"5600",
+		"9007199254740991",
+		"-9007199254740991",
+		"0.30000000000000004",
+		"9007199254740992",
+		"-100000000000000000000",
+		"10000000000000000",
+		"",
+		"",
+	}
+
+	fails := []bool{false, false, false, false, false, false, false, true, true, true, true, true}
+
+	for i, n := range inputs {
+
+		output, err := SafeJSONNumber(n, precisions[i])
+
+		if output != expected[i] || (err != nil) != fails[i] {
+
+			t.Errorf("Expected: %q (failure %v) but received: %q (%v) testing SafeJSONNumber(%v, %d)",
+				expected[i], fails[i], output, err, n, precisions[i])
+		}
+
+		if err != nil && !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: ErrRange but received: %v testing SafeJSONNumber(%v, %d)",
+				err, n, precisions[i])
+		}
+	}
+}
+
+// Test IsInteger, HasFraction and DecimalPlacesOf with a range of values
+func TestValueClass(t *testing.T) {
+
+	inputs := []float64{0, -5, 2.5, 0.1, 1e-7, 1e300, 123.456, math.Inf(1), math.NaN()}
+
+	integers := []bool{true, true, false, false, false, true, false, false, false}
+
+	fractions := []bool{false, false, true, true, true, false, true, false, false}
+
+	places := []int{0, 0, 1, 1, 7, 0, 3, 0, 0}
+
+	for i, n := range inputs {
+
+		if output := IsInteger(n); output != integers[i] {
+
+			t.Errorf("Expected: %v but received: %v testing IsInteger(%v)", integers[i], output, n)
+		}
+
+		if output := HasFraction(n); output != fractions[i] {
+
+			t.Errorf("Expected: %v but received: %v testing HasFraction(%v)", fractions[i], output, n)
+		}
+
+		if output := DecimalPlacesOf(n); output != places[i] {
+
+			t.Errorf("Expected: %d but received: %d testing DecimalPlacesOf(%v)", places[i], output, n)
+		}
+	}
+}
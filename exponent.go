@@ -0,0 +1,86 @@
+package decimals
+
+import (
+	"math"
+)
+
+// FormatWithCommonExponent formats a set of float64s scaled by a shared
+// power of ten using the default formatter. See
+// Formatter.FormatWithCommonExponent.
+func FormatWithCommonExponent(xs []float64, precision int) ([]string, int) {
+
+	return DefaultFormatter().FormatWithCommonExponent(xs, precision)
+}
+
+// FormatWithCommonExponent factors a shared power of ten out of a set of
+// float64s, for scientific tables and readouts labelled "values ×10³". It
+// returns each value divided by 10^exponent and formatted as by
+// FormatFloat at the given precision, and the exponent. The exponent is a
+// multiple of three chosen so that the largest value has one to three
+// integer digits once rounded. It is zero if every value is zero. NaN and
+// the infinities are formatted as by FormatFloat and do not affect the
+// exponent. Values are scaled exactly by their decimal digits.
+func (f Formatter) FormatWithCommonExponent(xs []float64, precision int) ([]string, int) {
+
+	var (
+		ds       = make([]Decimal, len(xs))
+		exponent int
+		found    bool
+	)
+
+	// Find the place of the largest leading digit
+	for i, x := range xs {
+
+		d, err := DecimalFromFloat(x)
+
+		if err != nil || d.Sign() == 0 {
+
+			continue
+		}
+
+		ds[i] = d
+
+		if leading := len(d.digits) + d.exponent - 1; !found || leading > exponent {
+
+			exponent, found = leading, true
+		}
+	}
+
+	// Round the place down to a multiple of three
+	exponent -= ((exponent % 3) + 3) % 3
+
+	// Move up a multiple if rounding the largest value carries to a fourth
+	// integer digit
+	for _, d := range ds {
+
+		if d.Sign() == 0 {
+
+			continue
+		}
+
+		r := Decimal{d.negative, d.digits, d.exponent - exponent}.Round(precision, HalfUp)
+
+		if len(r.digits)+r.exponent > 3 {
+
+			exponent += 3
+			break
+		}
+	}
+
+	out := make([]string, len(xs))
+
+	for i, x := range xs {
+
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+
+			out[i] = f.FormatFloat(x, precision)
+			continue
+		}
+
+		d := ds[i]
+		d.exponent -= exponent
+		out[i] = f.applyTemplate(f.formatDecimal(d.Round(precision, HalfUp), precision))
+	}
+
+	return out, exponent
+}
@@ -0,0 +1,53 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatWithCommonExponent chooses a shared engineering exponent
+func TestFormatWithCommonExponent(t *testing.T) {
+
+	inputs := [][]float64{
+		{1200, 34500, 999},
+		{0.0012, 0.00034},
+		{999.96, 5},
+		{-4.5e9, 1e6},
+		{0, 0},
+		{12.5, math.NaN()},
+		nil,
+	}
+
+	expected := [][]string{
+		{"1.2", "34.5", "1.0"},
+		{"1.2", "0.3"},
+		{"1.0", "0.0"},
+		{"-4.5", "0.0"},
+		{"0.0", "0.0"},
+		{"12.5", "NaN"},
+		{},
+	}
+
+	exponents := []int{3, -3, 3, 9, 0, 0, 0}
+
+	for i, xs := range inputs {
+
+		output, exponent := FormatWithCommonExponent(xs, 1)
+
+		if exponent != exponents[i] || len(output) != len(expected[i]) {
+
+			t.Errorf("Expected: %q ×10^%d but received: %q ×10^%d testing FormatWithCommonExponent",
+				expected[i], exponents[i], output, exponent)
+			continue
+		}
+
+		for j := range output {
+
+			if output[j] != expected[i][j] {
+
+				t.Errorf("Expected: %q but received: %q testing FormatWithCommonExponent(%v)",
+					expected[i][j], output[j], xs)
+			}
+		}
+	}
+}
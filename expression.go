@@ -0,0 +1,115 @@
+package decimals
+
+import (
+	"strings"
+)
+
+// Operators written between the terms of an expression
+const (
+	expressionPlus   = " + "
+	expressionMinus  = " − "
+	expressionEquals = " = "
+)
+
+// FormatExpression formats a sum of terms and its result using the default
+// formatter. See Formatter.FormatExpression.
+func FormatExpression(terms []float64, ops []rune, precision int) string {
+
+	return DefaultFormatter().FormatExpression(terms, ops, precision)
+}
+
+// FormatExpression formats the terms joined by the operators, followed by
+// the result, for invoice summaries and audit logs, as in "1,200.00 +
+// 350.50 − 75.25 = 1,475.25". ops holds the operator before each term
+// after the first, '+' or '-' (or '−'), and is applied from left to right.
+// Every term is rounded half up to the precision as by FormatFloat, and
+// the result is the exact sum of the rounded terms, so the expression as
+// written always adds up. If a term is NaN or infinite the result is
+// computed with floats. It panics if ops does not have one operator fewer
+// than terms or holds another operator.
+func (f Formatter) FormatExpression(terms []float64, ops []rune, precision int) string {
+
+	if len(terms) == 0 && len(ops) == 0 {
+
+		return ""
+	}
+
+	if len(ops) != len(terms)-1 {
+
+		panic("decimals: FormatExpression with mismatched terms and operators")
+	}
+
+	var (
+		b       strings.Builder
+		rounded = make([]Decimal, 0, len(terms))
+		total   float64
+		finite  = true
+	)
+
+	for i, x := range terms {
+
+		subtract := false
+
+		if i > 0 {
+
+			switch ops[i-1] {
+
+			case '+':
+
+				b.WriteString(expressionPlus)
+
+			case '-', '−':
+
+				b.WriteString(expressionMinus)
+				subtract = true
+
+			default:
+
+				panic("decimals: FormatExpression with unknown operator " + string(ops[i-1]))
+			}
+		}
+
+		b.WriteString(f.FormatFloat(x, precision))
+
+		// Keep the term as it is shown, to add it exactly
+		if subtract {
+
+			total -= x
+
+		} else {
+
+			total += x
+		}
+
+		d, err := DecimalFromFloat(x)
+
+		if err != nil {
+
+			finite = false
+			continue
+		}
+
+		r, _ := f.floatLimit().roundSignificant(d, precision, HalfUp)
+
+		if subtract {
+
+			r = r.Neg()
+		}
+
+		rounded = append(rounded, r)
+	}
+
+	b.WriteString(expressionEquals)
+
+	if !finite {
+
+		b.WriteString(f.FormatFloat(total, precision))
+
+	} else {
+
+		r, places := f.floatLimit().roundSignificant(Sum(rounded), precision, HalfUp)
+		b.WriteString(f.applyTemplate(f.formatDecimal(r, places)))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatExpression writes expressions that add up
+func TestFormatExpression(t *testing.T) {
+
+	inputs := []string{
+		FormatExpression([]float64{1200, 350.5, 75.25}, []rune{'+', '−'}, 2),
+		FormatExpression([]float64{0.333, 0.333, 0.333}, []rune{'+', '+'}, 2),
+		FormatExpression([]float64{1.005, 2.675}, []rune{'-'}, 2),
+		FormatExpression([]float64{19.99}, nil, 2),
+		FormatExpression(nil, nil, 2),
+		FormatExpression([]float64{10, math.Inf(1)}, []rune{'+'}, 0),
+		FormatExpression([]float64{-5, 5}, []rune{'+'}, 1),
+		Formatter{GroupSeparator: ".", DecimalSeparator: ",", Template: "{} €"}.FormatExpression([]float64{1000, 0.5}, []rune{'+'}, 2),
+	}
+
+	expected := []string{
+		"1,200.00 + 350.50 − 75.25 = 1,475.25",
+		"0.33 + 0.33 + 0.33 = 0.99",
+		"1.01 − 2.68 = -1.67",
+		"19.99 = 19.99",
+		"",
+		"10 + Inf = Inf",
+		"-5.0 + 5.0 = 0.0",
+		"1.000,00 € + 0,50 € = 1.000,50 €",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatExpression", expected[i], output)
+		}
+	}
+}
+
+// Test FormatExpression panics with mismatched operators
+func TestFormatExpressionPanics(t *testing.T) {
+
+	inputs := [][]rune{{'+', '+'}, {'×'}}
+
+	for _, ops := range inputs {
+
+		func() {
+
+			defer func() {
+
+				if recover() == nil {
+
+					t.Errorf("Expected: a panic but received: none testing FormatExpression with %q", ops)
+				}
+			}()
+
+			FormatExpression([]float64{1, 2}, ops, 2)
+		}()
+	}
+}
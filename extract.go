@@ -0,0 +1,209 @@
+package decimals
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// A ParsedNumber is a number found in text by ExtractNumbers.
+type ParsedNumber struct {
+	Text     string  // the matched text, including any sign, symbol and percent sign
+	Start    int     // the byte offset of the match in the text
+	End      int     // the byte offset following the match
+	Value    float64 // the number as written, so 12.5% has the value 12.5
+	Decimal  Decimal // the number as written, with every digit kept
+	Percent  bool    // whether the number is followed by a percent sign
+	Currency string  // the currency symbol before or after the number, if any
+}
+
+// ExtractNumbers finds the numbers in free text, for log scraping and text
+// preprocessing. Numbers are written as ParseFloat accepts with the
+// ParseExponent flag, optionally preceded by a sign and a currency symbol
+// in either order, and followed by a currency symbol or a percent sign, as
+// in "-$1,234.50", "€5", "5€" and "12.5%". A number must not start inside
+// a word or another number, so the digits in "abc123" and "1.2.3" after
+// the first number are skipped. A list written without spaces, such as
+// "1,2,3", gives one number per item, and punctuation ending a sentence is
+// not included in a match.
+func ExtractNumbers(s string) []ParsedNumber {
+
+	var numbers []ParsedNumber
+
+	for i := 0; i < len(s); {
+
+		n, ok := extractNumber(s, i)
+
+		if ok {
+
+			numbers = append(numbers, n)
+			i = n.End
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+
+	return numbers
+}
+
+// extractNumber reports whether a number starts at byte offset i of s and
+// returns it.
+func extractNumber(s string, i int) (ParsedNumber, bool) {
+
+	var (
+		n        ParsedNumber = ParsedNumber{Start: i}
+		signed   bool
+		negative bool
+	)
+
+	// Numbers may not start inside a word or another number
+	if r, _ := utf8.DecodeLastRuneInString(s[:i]); i > 0 && (isWordRune(r) || r == '.') {
+
+		return n, false
+	}
+
+	// Read a sign and a currency symbol in either order
+	for k := 0; k < 2; k++ {
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+
+		case !signed && (r == '-' || r == '−' || r == '+'):
+
+			signed, negative = true, r != '+'
+			i += size
+
+		case n.Currency == "" && unicode.Is(unicode.Sc, r):
+
+			n.Currency = string(r)
+			i += size
+		}
+	}
+
+	end, ok := scanNumber(s, i)
+
+	if !ok {
+
+		return n, false
+	}
+
+	d, err := ParseDecimal(s[i:end], ParseExponent)
+
+	if err != nil {
+
+		return n, false
+	}
+
+	// Read a following currency symbol or percent sign
+	if r, size := utf8.DecodeRuneInString(s[end:]); r == '%' {
+
+		n.Percent = true
+		end += size
+
+	} else if n.Currency == "" && unicode.Is(unicode.Sc, r) {
+
+		n.Currency = string(r)
+		end += size
+	}
+
+	if negative {
+
+		d = d.Neg()
+	}
+
+	n.Text, n.End, n.Decimal, n.Value = s[n.Start:end], end, d, d.Float64()
+
+	return n, true
+}
+
+// scanNumber returns the end of the longest number accepted by ParseFloat
+// with the ParseExponent flag starting at byte offset i of s, and reports
+// whether there is one. The number must end in a digit. It reads s once,
+// from left to right, noting the end of each valid number it passes.
+func scanNumber(s string, i int) (int, bool) {
+
+	var (
+		j    = skipDigits(s, i)
+		n    = j - i
+		last = -1
+	)
+
+	if n > 0 {
+
+		last = j
+	}
+
+	// Read groups of three digits after a leading group of one to three.
+	// A digit following the groups ends the number, which cannot go on.
+	if n >= 1 && n <= 3 && j < len(s) && s[j] == ',' {
+
+		for j+4 <= len(s) && s[j] == ',' && isDigits(s[j+1:j+4]) {
+
+			j += 4
+			last = j
+		}
+
+		if j < len(s) && isDigit(s[j]) {
+
+			return last, true
+		}
+	}
+
+	// Read the fractional part, where a point without digits is valid but
+	// does not end a number
+	if j < len(s) && s[j] == '.' {
+
+		k := skipDigits(s, j+1)
+
+		if k > j+1 {
+
+			last = k
+		}
+
+		n += k - j - 1
+		j = k
+	}
+
+	if n == 0 {
+
+		return i, false
+	}
+
+	// Read the exponent
+	if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+
+		k := j + 1
+
+		if k < len(s) && (s[k] == '-' || s[k] == '+') {
+
+			k++
+		}
+
+		if e := skipDigits(s, k); e > k {
+
+			last = e
+		}
+	}
+
+	return last, last >= 0
+}
+
+// skipDigits returns the offset following the run of ASCII digits starting
+// at byte offset i of s.
+func skipDigits(s string, i int) int {
+
+	for i < len(s) && isDigit(s[i]) {
+
+		i++
+	}
+
+	return i
+}
+
+// isWordRune reports whether r is a letter, digit or underscore.
+func isWordRune(r rune) bool {
+
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
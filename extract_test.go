@@ -0,0 +1,76 @@
+package decimals
+
+import (
+	"testing"
+)
+
+// Test ExtractNumbers finds numbers in a range of texts
+func TestExtractNumbers(t *testing.T) {
+
+	inputs := []string{
+		"Paid $1,234.50 on 3 items.",
+		"CPU at 95.5%, load −0.25",
+		"-$5 and €-7 and 12€",
+		"version 1.2.3 of abc123",
+		"1,2,3",
+		"1.5e3 or 2e or 3-4",
+		"nothing here",
+	}
+
+	expected := [][]string{
+		{"$1,234.50", "3"},
+		{"95.5%", "−0.25"},
+		{"-$5", "€-7", "12€"},
+		{"1.2"},
+		{"1", "2", "3"},
+		{"1.5e3", "2", "3", "4"},
+		nil,
+	}
+
+	for i, s := range inputs {
+
+		output := ExtractNumbers(s)
+
+		if len(output) != len(expected[i]) {
+
+			t.Errorf("Expected: %q but received: %+v testing ExtractNumbers(%q)",
+				expected[i], output, s)
+			continue
+		}
+
+		for j, n := range output {
+
+			if n.Text != expected[i][j] || s[n.Start:n.End] != n.Text {
+
+				t.Errorf("Expected: %q but received: %q testing ExtractNumbers(%q)",
+					expected[i][j], n.Text, s)
+			}
+		}
+	}
+}
+
+// Test ExtractNumbers reports values, percents and currencies
+func TestExtractNumbersValues(t *testing.T) {
+
+	output := ExtractNumbers("-$1,234.50 rose 12.5% to 99999999999999999999.5 €")
+
+	if len(output) != 3 {
+
+		t.Fatalf("Expected: 3 numbers but received: %+v testing ExtractNumbers", output)
+	}
+
+	if n := output[0]; n.Value != -1234.5 || n.Currency != "$" || n.Percent {
+
+		t.Errorf("Expected: -1234.5 in $ but received: %+v testing ExtractNumbers", n)
+	}
+
+	if n := output[1]; n.Value != 12.5 || !n.Percent || n.Currency != "" {
+
+		t.Errorf("Expected: 12.5%% but received: %+v testing ExtractNumbers", n)
+	}
+
+	if n := output[2]; n.Decimal.String() != "99999999999999999999.5" || n.Currency != "" {
+
+		t.Errorf("Expected: 99999999999999999999.5 but received: %+v testing ExtractNumbers", n)
+	}
+}
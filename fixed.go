@@ -0,0 +1,327 @@
+package decimals
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+// MaxFixedPlaces is the largest number of decimal places of a Fixed64.
+const MaxFixedPlaces = 18
+
+// Powers of ten up to 10^MaxFixedPlaces
+var pow10Uint64 = func() (p [MaxFixedPlaces + 1]uint64) {
+
+	p[0] = 1
+
+	for i := 1; i < len(p); i++ {
+
+		p[i] = p[i-1] * 10
+	}
+
+	return p
+}()
+
+// A Fixed64 is a decimal held as an int64 count of units of 10^-places,
+// such as microunits with six places, for high frequency counters in
+// metering and rate limiting where Decimal is too slow. Its arithmetic
+// uses math/bits and does not allocate. Operations that overflow an int64
+// return ErrRange. The zero value is zero with no places.
+type Fixed64 struct {
+	units  int64
+	places int
+}
+
+// NewFixed64 returns the Fixed64 of the given units with the given number
+// of decimal places, so NewFixed64(1500000, 6) is 1.5. It panics if places
+// is not from 0 to MaxFixedPlaces.
+func NewFixed64(units int64, places int) Fixed64 {
+
+	checkFixedPlaces(places)
+
+	return Fixed64{units, places}
+}
+
+// Fixed64FromFloat returns x rounded to the given number of decimal places
+// using the given rounding mode, as RoundFloatMode does. It returns
+// ErrRange if x is NaN or infinite or the result overflows, and panics if
+// places is not from 0 to MaxFixedPlaces.
+func Fixed64FromFloat(x float64, places int, mode RoundingMode) (Fixed64, error) {
+
+	checkFixedPlaces(places)
+
+	d, err := DecimalFromFloat(x)
+
+	if err != nil {
+
+		return Fixed64{}, ErrRange
+	}
+
+	// Shift the rounded decimal to a whole number of units
+	d = d.Round(places, mode)
+
+	if d.digits != "" {
+
+		d.exponent += places
+	}
+
+	units, err := d.Int64()
+
+	if err != nil {
+
+		return Fixed64{}, ErrRange
+	}
+
+	return Fixed64{units, places}, nil
+}
+
+// checkFixedPlaces panics if places is out of range for a Fixed64.
+func checkFixedPlaces(places int) {
+
+	if places < 0 || places > MaxFixedPlaces {
+
+		panic("decimals: Fixed64 with places outside 0 to " + strconv.Itoa(MaxFixedPlaces))
+	}
+}
+
+// Units returns x as a count of units of 10^-places.
+func (x Fixed64) Units() int64 {
+
+	return x.units
+}
+
+// Places returns the number of decimal places of x.
+func (x Fixed64) Places() int {
+
+	return x.places
+}
+
+// Add returns x + y with the larger of their numbers of places, or ErrRange
+// if it overflows.
+func (x Fixed64) Add(y Fixed64) (Fixed64, error) {
+
+	x, y, err := alignFixed(x, y)
+
+	if err != nil {
+
+		return Fixed64{}, err
+	}
+
+	s := x.units + y.units
+
+	// The sum overflowed if the operands share a sign that it does not
+	if (x.units < 0) == (y.units < 0) && (s < 0) != (x.units < 0) {
+
+		return Fixed64{}, ErrRange
+	}
+
+	return Fixed64{s, x.places}, nil
+}
+
+// Sub returns x - y with the larger of their numbers of places, or
+// ErrRange if it overflows.
+func (x Fixed64) Sub(y Fixed64) (Fixed64, error) {
+
+	x, y, err := alignFixed(x, y)
+
+	if err != nil {
+
+		return Fixed64{}, err
+	}
+
+	s := x.units - y.units
+
+	// The difference overflowed if the operands differ in sign and it does
+	// not have the sign of x
+	if (x.units < 0) != (y.units < 0) && (s < 0) != (x.units < 0) {
+
+		return Fixed64{}, ErrRange
+	}
+
+	return Fixed64{s, x.places}, nil
+}
+
+// Mul returns x × y with the places of x, rounded using the given rounding
+// mode, or ErrRange if it overflows. The product is computed exactly in
+// 128 bits before it is rounded.
+func (x Fixed64) Mul(y Fixed64, mode RoundingMode) (Fixed64, error) {
+
+	negative := (x.units < 0) != (y.units < 0)
+	hi, lo := bits.Mul64(absUint64(x.units), absUint64(y.units))
+	q, ok := divRound128(hi, lo, pow10Uint64[y.places], negative, mode)
+
+	return fixedResult(q, ok, negative, x.places)
+}
+
+// Div returns x ÷ y with the places of x, rounded using the given rounding
+// mode. It returns ErrDivisionByZero if y is zero, and ErrRange if the
+// quotient overflows.
+func (x Fixed64) Div(y Fixed64, mode RoundingMode) (Fixed64, error) {
+
+	if y.units == 0 {
+
+		return Fixed64{}, ErrDivisionByZero
+	}
+
+	negative := (x.units < 0) != (y.units < 0)
+	hi, lo := bits.Mul64(absUint64(x.units), pow10Uint64[y.places])
+	q, ok := divRound128(hi, lo, absUint64(y.units), negative, mode)
+
+	return fixedResult(q, ok, negative, x.places)
+}
+
+// Rescale returns x with the given number of places, rounded using the
+// given rounding mode if it has fewer. It returns ErrRange if the result
+// overflows, and panics if places is not from 0 to MaxFixedPlaces.
+func (x Fixed64) Rescale(places int, mode RoundingMode) (Fixed64, error) {
+
+	checkFixedPlaces(places)
+
+	if places >= x.places {
+
+		units, err := MulPow10(x.units, places-x.places)
+
+		if err != nil {
+
+			return Fixed64{}, ErrRange
+		}
+
+		return Fixed64{units, places}, nil
+	}
+
+	negative := x.units < 0
+	q, ok := divRound128(0, absUint64(x.units), pow10Uint64[x.places-places], negative, mode)
+
+	return fixedResult(q, ok, negative, places)
+}
+
+// Cmp compares x and y and returns -1, 0 or 1 as x is less than, equal to
+// or greater than y.
+func (x Fixed64) Cmp(y Fixed64) int {
+
+	if x.places != y.places {
+
+		return x.Decimal().Cmp(y.Decimal())
+	}
+
+	switch {
+
+	case x.units < y.units:
+
+		return -1
+
+	case x.units > y.units:
+
+		return 1
+	}
+
+	return 0
+}
+
+// Decimal returns the exact value of x as a Decimal.
+func (x Fixed64) Decimal() Decimal {
+
+	return newDecimal(x.units < 0, strconv.FormatUint(absUint64(x.units), 10), -x.places)
+}
+
+// Float64 returns the float64 nearest to x.
+func (x Fixed64) Float64() float64 {
+
+	return x.Decimal().Float64()
+}
+
+// String returns x in plain decimal notation with all of its places, as in
+// "1.500000".
+func (x Fixed64) String() string {
+
+	return x.Decimal().String()
+}
+
+// FormatFixed64 formats a Fixed64 using the default formatter. See
+// Formatter.FormatFixed64.
+func FormatFixed64(x Fixed64, precision int) string {
+
+	return DefaultFormatter().FormatFixed64(x, precision)
+}
+
+// FormatFixed64 converts a Fixed64 to a formatted string, rounded half up
+// to the given precision as FormatDecimal does.
+func (f Formatter) FormatFixed64(x Fixed64, precision int) string {
+
+	r, places := f.roundSignificant(x.Decimal(), precision, HalfUp)
+
+	return f.applyTemplate(f.formatDecimal(r, places))
+}
+
+// alignFixed returns x and y with the larger of their numbers of places.
+func alignFixed(x, y Fixed64) (Fixed64, Fixed64, error) {
+
+	var err error
+
+	if x.places < y.places {
+
+		x.units, err = MulPow10(x.units, y.places-x.places)
+		x.places = y.places
+
+	} else if y.places < x.places {
+
+		y.units, err = MulPow10(y.units, x.places-y.places)
+		y.places = x.places
+	}
+
+	return x, y, err
+}
+
+// divRound128 divides the 128-bit hi × 2^64 + lo by d and rounds the
+// quotient using mode, given the sign of the true quotient. It reports
+// false if the quotient does not fit in a uint64.
+func divRound128(hi, lo, d uint64, negative bool, mode RoundingMode) (uint64, bool) {
+
+	if hi >= d {
+
+		return 0, false
+	}
+
+	q, r := bits.Div64(hi, lo, d)
+
+	if r == 0 {
+
+		return q, true
+	}
+
+	// Find the first discarded decimal digit and whether any later digits
+	// are set. The remainder is less than d, so ten times it divided by d
+	// fits in a uint64.
+	rhi, rlo := bits.Mul64(r, 10)
+	digit, sticky := bits.Div64(rhi, rlo, d)
+
+	if roundsUp(mode, negative, byte(digit), sticky != 0, q%2 == 1) {
+
+		if q == math.MaxUint64 {
+
+			return 0, false
+		}
+
+		q++
+	}
+
+	return q, true
+}
+
+// fixedResult returns the Fixed64 of the magnitude q with the given sign,
+// or ErrRange if it overflowed or does not fit in an int64.
+func fixedResult(q uint64, ok bool, negative bool, places int) (Fixed64, error) {
+
+	switch {
+
+	case !ok || q > 1<<63 || q == 1<<63 && !negative:
+
+		return Fixed64{}, ErrRange
+
+	case negative:
+
+		return Fixed64{int64(-q), places}, nil
+	}
+
+	return Fixed64{int64(q), places}, nil
+}
@@ -0,0 +1,169 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test Fixed64 arithmetic with a range of operands
+func TestFixed64Arithmetic(t *testing.T) {
+
+	var (
+		a = NewFixed64(1500000, 6) // 1.5
+		b = NewFixed64(-250, 2)    // -2.5
+		c = NewFixed64(3, 0)       // 3
+		m = NewFixed64(math.MaxInt64, 0)
+		n = NewFixed64(math.MinInt64, 0)
+	)
+
+	sum, _ := a.Add(b)
+	diff, _ := a.Sub(b)
+	prod, _ := a.Mul(b, HalfUp)
+	third, _ := NewFixed64(1000000, 6).Div(c, HalfUp)
+	twothirds, _ := NewFixed64(-2000000, 6).Div(c, HalfEven)
+	half, _ := NewFixed64(25, 2).Mul(NewFixed64(5, 1), HalfEven)
+	rate, _ := NewFixed64(123456789, 6).Div(NewFixed64(60, 0), Floor)
+	neg, _ := n.Div(NewFixed64(1, 0), HalfUp)
+
+	inputs := []string{
+		sum.String(),
+		diff.String(),
+		prod.String(),
+		third.String(),
+		twothirds.String(),
+		half.String(),
+		rate.String(),
+		neg.String(),
+	}
+
+	expected := []string{
+		"-1.000000",
+		"4.000000",
+		"-3.750000",
+		"0.333333",
+		"-0.666667",
+		"0.12",
+		"2.057613",
+		"-9223372036854775808",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Fixed64 arithmetic", expected[i], output)
+		}
+	}
+
+	// Operations that overflow or divide by zero
+	_, err1 := m.Add(NewFixed64(1, 0))
+	_, err2 := n.Sub(NewFixed64(1, 0))
+	_, err3 := m.Mul(NewFixed64(2, 0), HalfUp)
+	_, err4 := n.Div(NewFixed64(-1, 0), HalfUp)
+	_, err5 := a.Div(Fixed64{}, HalfUp)
+	r6, err6 := NewFixed64(math.MaxInt64, 0).Rescale(1, HalfUp)
+	_, err7 := NewFixed64(1, 0).Add(NewFixed64(math.MaxInt64, 18))
+
+	errs := []error{err1, err2, err3, err4, err5, err6, err7}
+	expectedErrs := []error{ErrRange, ErrRange, ErrRange, ErrRange, ErrDivisionByZero, ErrRange, ErrRange}
+
+	for i, err := range errs {
+
+		if err != expectedErrs[i] {
+
+			t.Errorf("Expected: %v but received: %v testing Fixed64 error %d", expectedErrs[i], err, i+1)
+		}
+	}
+
+	if r6 != (Fixed64{}) {
+
+		t.Errorf("Expected: the zero Fixed64 but received: %v testing Fixed64.Rescale overflow", r6)
+	}
+}
+
+// Test Fixed64 conversions, rescaling and comparison
+func TestFixed64Conversions(t *testing.T) {
+
+	f, err := Fixed64FromFloat(2.675, 2, HalfUp)
+
+	if err != nil || f.Units() != 268 || f.Places() != 2 {
+
+		t.Errorf("Expected: 268 at 2 places but received: %d at %d (%v) testing Fixed64FromFloat", f.Units(), f.Places(), err)
+	}
+
+	if _, err := Fixed64FromFloat(math.NaN(), 2, HalfUp); err != ErrRange {
+
+		t.Errorf("Expected: %v but received: %v testing Fixed64FromFloat", ErrRange, err)
+	}
+
+	if _, err := Fixed64FromFloat(1e17, 6, HalfUp); err != ErrRange {
+
+		t.Errorf("Expected: %v but received: %v testing Fixed64FromFloat", ErrRange, err)
+	}
+
+	r, _ := NewFixed64(1234567, 6).Rescale(2, HalfEven)
+	u, _ := NewFixed64(-125, 2).Rescale(1, HalfEven)
+	w, _ := NewFixed64(5, 0).Rescale(3, HalfUp)
+
+	inputs := []string{r.String(), u.String(), w.String(), Fixed64{}.String(), NewFixed64(-5, 3).String()}
+	expected := []string{"1.23", "-1.2", "5.000", "0", "-0.005"}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Fixed64", expected[i], output)
+		}
+	}
+
+	if NewFixed64(15, 1).Cmp(NewFixed64(1500, 3)) != 0 || NewFixed64(1, 0).Cmp(NewFixed64(2, 0)) != -1 {
+
+		t.Errorf("Expected: equal and less testing Fixed64.Cmp")
+	}
+
+	if output := NewFixed64(1500000, 6).Float64(); output != 1.5 {
+
+		t.Errorf("Expected: 1.5 but received: %v testing Fixed64.Float64", output)
+	}
+
+	if output := FormatFixed64(NewFixed64(1234567891, 6), 2); output != "1,234.57" {
+
+		t.Errorf("Expected: %q but received: %q testing FormatFixed64", "1,234.57", output)
+	}
+}
+
+// Test NewFixed64 panics with places out of range
+func TestNewFixed64Panics(t *testing.T) {
+
+	defer func() {
+
+		if recover() == nil {
+
+			t.Errorf("Expected: a panic but received: none testing NewFixed64")
+		}
+	}()
+
+	NewFixed64(1, MaxFixedPlaces+1)
+}
+
+// Test Fixed64 arithmetic does not allocate
+func TestFixed64Allocs(t *testing.T) {
+
+	var (
+		a = NewFixed64(1500000, 6)
+		b = NewFixed64(3, 0)
+	)
+
+	allocs := testing.AllocsPerRun(100, func() {
+
+		s, _ := a.Add(b)
+		s, _ = s.Mul(b, HalfEven)
+		s, _ = s.Div(b, HalfEven)
+		_, _ = s.Sub(a)
+	})
+
+	if allocs != 0 {
+
+		t.Errorf("Expected: 0 allocations but received: %v testing Fixed64 arithmetic", allocs)
+	}
+}
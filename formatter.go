@@ -0,0 +1,582 @@
+package decimals
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A Formatter formats numbers using the separators of a particular locale.
+// The zero value formats numbers without grouping and with a point as the
+// decimal separator.
+type Formatter struct {
+
+	// GroupSeparator separates groups of thousands. It may be any string,
+	// including multi-byte characters such as NarrowNoBreakSpace. If it is
+	// empty numbers are not grouped.
+	GroupSeparator string
+
+	// MinIntegerDigits pads the integer part with leading zeros to at
+	// least this many digits, as in "007.50" with three, for invoice
+	// numbers and fixed width identifiers. If it is zero one digit is
+	// shown, and if it is negative numbers less than one are formatted
+	// without an integer digit, as in ".25".
+	MinIntegerDigits int
+
+	// GroupingThreshold is the number of integer digits a number needs
+	// before it is grouped, as required by style guides that write "1234"
+	// but "12,345", for which it is five. If it is four or less every
+	// number of four or more integer digits is grouped.
+	GroupingThreshold int
+
+	// DecimalSeparator separates the integer and fractional parts. If it is
+	// empty a point is used.
+	DecimalSeparator string
+
+	// Digits are the runes used for the digits zero to nine, such as
+	// PersianDigits or ThaiDigits. They replace the ASCII digits of the
+	// formatted number before the template is applied. If it is the zero
+	// value ASCII digits are used.
+	Digits DigitSet
+
+	// Magnitudes names the powers of ten used by FormatCompact and
+	// FormatLong. If it is nil ShortScale is used.
+	Magnitudes MagnitudeScale
+
+	// MaxSignificantDigits limits the number of significant digits shown by
+	// FormatThousands, FormatInt, FormatFloat, FormatDecimal and
+	// FormatWithSpec, so 123456 is formatted as "123,000" with a limit of
+	// three. Numbers are rounded once, to whichever of the precision and the
+	// limit keeps fewer digits, and decimal places beyond the limit are not
+	// shown. If it is zero the number of digits is not limited.
+	MaxSignificantDigits int
+
+	// PrecisionPolicy decides how floats are formatted to precisions that
+	// would show more significant digits than a float64 holds. If it is the
+	// zero value, PrecisionZeroFill, the digits are padded with zeros.
+	PrecisionPolicy PrecisionPolicy
+
+	// PercentPattern places the percent sign around numbers formatted as
+	// percentages, with the placeholder "{}" marking where the number goes,
+	// as in "%{}" in Turkish, or "{} %" with a no-break space in German. A
+	// minus sign is placed before the whole pattern. If it is empty "{}%" is
+	// used.
+	PercentPattern string
+
+	// Placeholder stands in for missing values, such as "—" or "N/A". If it
+	// is not empty it is returned in place of the whole output for NaN by
+	// the functions that format float64s, without the template, and for nil
+	// pointers by FormatFloatPtr and FormatStruct. If it is empty NaN is
+	// formatted as "NaN" and nil pointers as empty strings.
+	Placeholder string
+
+	// SignStyle marks the sign of formatted numbers, such as Parentheses for
+	// "(1,234.50)" or CreditDebit for "1,234.50 DR". It replaces the minus
+	// sign of the number before the template is applied, so it surrounds
+	// any currency symbol, percent sign or unit. If it is the zero value a
+	// leading minus sign is used.
+	SignStyle SignStyle
+
+	// Template surrounds each formatted number with labels such as units or
+	// currencies, as in "{} USD" or "≈{}". The first occurrence of the
+	// placeholder "{}" is replaced with the number. A template without the
+	// placeholder is used as a prefix.
+	Template string
+}
+
+// TemplatePlaceholder marks where the number goes in a Formatter's Template.
+const TemplatePlaceholder = "{}"
+
+// Space separators that do not allow a line break within a number
+const (
+	// NoBreakSpace is the no-break space, U+00A0, used to group digits in
+	// Canadian French and many other locales.
+	NoBreakSpace = "\u00A0"
+
+	// NarrowNoBreakSpace is the narrow no-break space, U+202F, used to group
+	// digits in French.
+	NarrowNoBreakSpace = "\u202F"
+)
+
+//go:generate go run ./internal/gencldr -cldr $CLDR_NUMBERS -locales de,de-AT,de-CH,en,es,fr,it,ja,nl,pl,pt,pt-PT,ru,sv,tr -o cldr.go
+
+// Preset formatters for common locales, keyed by lower case language tag
+var locales = map[string]Formatter{
+	"en-us": {GroupSeparator: ",", DecimalSeparator: "."},
+	"en-gb": {GroupSeparator: ",", DecimalSeparator: "."},
+	"en-au": {GroupSeparator: ",", DecimalSeparator: "."},
+	"en-ca": {GroupSeparator: ",", DecimalSeparator: "."},
+	"ja-jp": {GroupSeparator: ",", DecimalSeparator: "."},
+	"zh-cn": {GroupSeparator: ",", DecimalSeparator: "."},
+	"de-de": {GroupSeparator: ".", DecimalSeparator: ",", Magnitudes: LongScale, PercentPattern: "{}" + NoBreakSpace + "%"},
+	"es-es": {GroupSeparator: ".", DecimalSeparator: ",", Magnitudes: LongScale, PercentPattern: "{}" + NoBreakSpace + "%"},
+	"it-it": {GroupSeparator: ".", DecimalSeparator: ",", Magnitudes: LongScale},
+	"nl-nl": {GroupSeparator: ".", DecimalSeparator: ",", Magnitudes: LongScale},
+	"pt-br": {GroupSeparator: ".", DecimalSeparator: ","},
+	"id-id": {GroupSeparator: ".", DecimalSeparator: ",", Magnitudes: LongScale},
+	"fr-fr": {GroupSeparator: NarrowNoBreakSpace, DecimalSeparator: ",", Magnitudes: LongScale, PercentPattern: "{}" + NarrowNoBreakSpace + "%"},
+	"fr-ca": {GroupSeparator: NoBreakSpace, DecimalSeparator: ",", PercentPattern: "{}" + NoBreakSpace + "%"},
+	"tr-tr": {GroupSeparator: ".", DecimalSeparator: ",", PercentPattern: "%{}"},
+	"de-ch": {GroupSeparator: "'", DecimalSeparator: ".", Magnitudes: LongScale},
+}
+
+// SIFormatter groups thousands with a narrow no-break space and uses a
+// point for decimals, as in 1 234 567.89, following the SI recommendation
+// of thin spaces between groups of three digits, which avoids mistaking
+// either a comma or a point for the decimal separator.
+var SIFormatter = Formatter{GroupSeparator: NarrowNoBreakSpace, DecimalSeparator: "."}
+
+// UnderscoreFormatter groups thousands with underscores, as in 1_000_000.5,
+// matching the numeric literal syntax of Go, Python and Rust so numbers
+// can be written into generated source code and configuration files.
+// ParseFloat accepts its output with the ParseUnderscore flag.
+var UnderscoreFormatter = Formatter{GroupSeparator: "_", DecimalSeparator: "."}
+
+// The default formatter used by the package level Format functions
+var (
+	defaultMutex     sync.RWMutex
+	defaultFormatter = Formatter{GroupSeparator: ",", DecimalSeparator: "."}
+)
+
+// Formatters registered with RegisterLocale, keyed by lower case language
+// tag
+var (
+	registeredMutex   sync.RWMutex
+	registeredLocales = map[string]Formatter{}
+)
+
+// RegisterLocale registers f as the formatter for a locale, given as a
+// language tag as for NewFormatter, so that a house style such as Swiss
+// apostrophe grouping can be used everywhere a locale is looked up,
+// including WithLocale. A registered locale overrides the preset and the
+// CLDR data for the tag, and a registered language such as "de" is also
+// used for its regions that have no data of their own. Registering a tag
+// again replaces its formatter. It is safe to call concurrently with
+// formatting.
+func RegisterLocale(locale string, f Formatter) {
+
+	registeredMutex.Lock()
+	defer registeredMutex.Unlock()
+
+	registeredLocales[localeKey(locale)] = f
+}
+
+// localeKey returns the lower case language tag used to look up a locale.
+func localeKey(locale string) string {
+
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// NewFormatter returns the preset formatter for a locale, given as a
+// language tag such as "en-US" or "de_DE". Tags are matched without regard
+// to case. Locales registered with RegisterLocale are used first. Locales
+// without a preset use the data of the Unicode CLDR, falling back from a
+// tag such as "fr-BE" to its language "fr" if the CLDR has no data for the
+// region.
+func NewFormatter(locale string) (Formatter, error) {
+
+	key := localeKey(locale)
+
+	registeredMutex.RLock()
+	defer registeredMutex.RUnlock()
+
+	if f, ok := registeredLocales[key]; ok {
+
+		return f, nil
+	}
+
+	if f, ok := locales[key]; ok {
+
+		return f, nil
+	}
+
+	// Drop subtags until a registered locale or the CLDR has data for the
+	// tag
+	for tag := key; tag != ""; {
+
+		if f, ok := registeredLocales[tag]; ok {
+
+			return f, nil
+		}
+
+		if f, ok := cldrLocales[tag]; ok {
+
+			return f, nil
+		}
+
+		i := strings.LastIndexByte(tag, '-')
+
+		if i < 0 {
+
+			break
+		}
+
+		tag = tag[:i]
+	}
+
+	return Formatter{}, fmt.Errorf("decimals: unknown locale %q", locale)
+}
+
+// DefaultFormatter returns the formatter used by the package level Format
+// functions. Unless changed with SetDefaultFormatter it uses a comma
+// separator for thousands and a point for decimals.
+func DefaultFormatter() Formatter {
+
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+
+	return defaultFormatter
+}
+
+// SetDefaultFormatter sets the formatter used by the package level Format
+// functions, so an application can choose its locale once at startup. It
+// is safe to call concurrently with formatting.
+func SetDefaultFormatter(f Formatter) {
+
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+
+	defaultFormatter = f
+}
+
+// FormatThousands converts an int64 into a string formatted using the
+// formatter's separator for thousands.
+func (f Formatter) FormatThousands(x int64) string {
+
+	return f.applyTemplate(f.formatThousands(x))
+}
+
+// FormatThousandsUint converts a uint64 into a string formatted using the
+// formatter's separator for thousands, as FormatThousands does, for counts
+// and identifiers above the largest int64.
+func (f Formatter) FormatThousandsUint(x uint64) string {
+
+	r, places := f.roundSignificant(newDecimal(false, strconv.FormatUint(x, 10), 0), 0, HalfUp)
+
+	return f.applyTemplate(f.formatDecimal(r, places))
+}
+
+// FormatThousandsString formats a number given as a string of digits of
+// any length, such as an amount from an external system too large for an
+// int64, using the formatter's separators. The string may have a sign and
+// a fractional part after a point, which is kept as it is, so
+// "-123456789012345678901234.50" becomes
+// "-123,456,789,012,345,678,901,234.50". Leading zeros are removed. It
+// returns an error wrapping ErrSyntax if s is not such a number.
+func (f Formatter) FormatThousandsString(s string) (string, error) {
+
+	d, err := ParseDecimal(s, ParsePlus)
+
+	if err != nil {
+
+		return "", &NumError{"FormatThousandsString", s, err.(*NumError).Err}
+	}
+
+	var places int
+
+	if d.exponent < 0 {
+
+		places = -d.exponent
+	}
+
+	r, places := f.roundSignificant(d, places, HalfUp)
+
+	return f.applyTemplate(f.formatDecimal(r, places)), nil
+}
+
+// FormatInt converts an int64 to a formatted string. The int is rounded
+// to the given precision and formatted using the formatter's separator for
+// thousands.
+func (f Formatter) FormatInt(x int64, precision int) string {
+
+	if f.MaxSignificantDigits > 0 {
+
+		precision = f.significantPrecision(DecimalFromInt(x), precision)
+	}
+
+	return f.applyTemplate(f.formatThousands(RoundInt(x, precision)))
+}
+
+// FormatFloat converts a float64 to a formatted string. The float is rounded
+// to the given precision and formatted using the formatter's separators for
+// thousands and decimals.
+func (f Formatter) FormatFloat(x float64, precision int) string {
+
+	if p, ok := f.placeholder(x); ok {
+
+		return p
+	}
+
+	return f.applyTemplate(f.formatFloat(x, precision))
+}
+
+// FormatFloatPtr formats the float64 that x points to as FormatFloat does,
+// using the default formatter. See Formatter.FormatFloatPtr.
+func FormatFloatPtr(x *float64, precision int) string {
+
+	return DefaultFormatter().FormatFloatPtr(x, precision)
+}
+
+// FormatFloatPtr formats the float64 that x points to as FormatFloat does,
+// for optional values such as nullable database columns. A nil pointer is
+// formatted as the formatter's Placeholder.
+func (f Formatter) FormatFloatPtr(x *float64, precision int) string {
+
+	if x == nil {
+
+		return f.Placeholder
+	}
+
+	return f.FormatFloat(*x, precision)
+}
+
+// FormatDecimal converts a Decimal to a formatted string. The decimal is
+// rounded half up to the given precision and formatted using the
+// formatter's separators for thousands and decimals. Unlike FormatFloat it
+// formats numbers of any length exactly.
+func (f Formatter) FormatDecimal(d Decimal, precision int) string {
+
+	return f.FormatDecimalMode(d, precision, HalfUp)
+}
+
+// FormatDecimalMode converts a Decimal to a formatted string as
+// FormatDecimal does, rounding it with the given rounding mode.
+func (f Formatter) FormatDecimalMode(d Decimal, precision int, mode RoundingMode) string {
+
+	r, places := f.roundSignificant(d, precision, mode)
+
+	return f.applyTemplate(f.formatDecimal(r, places))
+}
+
+// formatThousands formats an int64 with the formatter's separator for
+// thousands, without applying the template.
+func (f Formatter) formatThousands(x int64) string {
+
+	r, places := f.roundSignificant(DecimalFromInt(x), 0, HalfUp)
+
+	return f.formatDecimal(r, places)
+}
+
+// formatFloat rounds and formats a float64 with the formatter's separators,
+// without applying the template.
+func (f Formatter) formatFloat(x float64, precision int) string {
+
+	d, err := floatDecimal(x)
+
+	if err != nil {
+
+		return formatSpecial(x)
+	}
+
+	r, places := f.floatLimit().roundSignificant(d, precision, HalfUp)
+
+	return f.formatDecimal(r, places)
+}
+
+// floatDecimal returns the Decimal that floats are formatted from, with
+// integers that floats hold exactly taking the integer path. An error
+// wrapping ErrRange is returned if x is NaN or infinite.
+func floatDecimal(x float64) (Decimal, error) {
+
+	if IsExactInt(x) {
+
+		return DecimalFromInt(int64(x)), nil
+	}
+
+	return DecimalFromFloat(x)
+}
+
+// roundSignificant rounds d to the precision, or to fewer places if
+// needed to keep within the formatter's maximum number of significant
+// digits, and returns the rounded decimal and the number of decimal places
+// to show.
+func (f Formatter) roundSignificant(d Decimal, precision int, mode RoundingMode) (Decimal, int) {
+
+	precision = f.significantPrecision(d, precision)
+	r := d.Round(precision, mode)
+
+	// Drop the last place if rounding carried into a new leading digit, as
+	// when 9.996 becomes 10.00 with a limit of three digits. The dropped
+	// digit is zero so this does not round again.
+	if p := f.significantPrecision(r, precision); p < precision {
+
+		return r.Round(p, mode), p
+	}
+
+	return r, precision
+}
+
+// significantPrecision returns the precision, reduced if rounding d to it
+// would keep more than the formatter's maximum number of significant
+// digits.
+func (f Formatter) significantPrecision(d Decimal, precision int) int {
+
+	if f.MaxSignificantDigits <= 0 || d.digits == "" {
+
+		return precision
+	}
+
+	// Find the precision of the last significant digit
+	if p := f.MaxSignificantDigits - len(d.digits) - d.exponent; p < precision {
+
+		return p
+	}
+
+	return precision
+}
+
+// formatDecimal formats a rounded decimal with the formatter's separators
+// and the number of decimal places given by precision, without applying
+// the template. The sign and digits are all taken from the rounded value,
+// so a negative number that rounds to zero, such as -0.004 to two places,
+// is formatted without a minus sign.
+func (f Formatter) formatDecimal(d Decimal, precision int) string {
+
+	s := f.formatDigits(d, precision, f.GroupSeparator)
+
+	if d.Sign() < 0 {
+
+		return "-" + s
+	}
+
+	return s
+}
+
+// formatDigits formats the absolute value of a rounded decimal with the
+// group separator sep and the number of decimal places given by precision.
+func (f Formatter) formatDigits(d Decimal, precision int, sep string) string {
+
+	if precision < 0 {
+
+		precision = 0
+	}
+
+	is, fs := d.parts(precision)
+	is = f.groupInteger(f.padInteger(is, fs != ""), sep)
+
+	if fs == "" {
+
+		return is
+	}
+
+	return is + f.decimalSeparator() + fs
+}
+
+// placeholder returns the formatter's placeholder and true if x is NaN and
+// the formatter has a placeholder for missing values.
+func (f Formatter) placeholder(x float64) (string, bool) {
+
+	return f.Placeholder, f.Placeholder != "" && math.IsNaN(x)
+}
+
+// padInteger pads a string of integer digits with zeros to the formatter's
+// minimum number of integer digits. A lone zero is removed if the minimum
+// is negative and the number has a fractional part.
+func (f Formatter) padInteger(digits string, fraction bool) string {
+
+	if f.MinIntegerDigits < 0 && fraction && digits == "0" {
+
+		return ""
+	}
+
+	if n := f.MinIntegerDigits - len(digits); n > 0 {
+
+		return strings.Repeat("0", n) + digits
+	}
+
+	return digits
+}
+
+// groupInteger groups a string of integer digits with sep, unless it has
+// fewer digits than the formatter's grouping threshold.
+func (f Formatter) groupInteger(digits string, sep string) string {
+
+	if len(digits) < f.GroupingThreshold {
+
+		return digits
+	}
+
+	return groupDigits(digits, sep)
+}
+
+// formatSpecial formats NaN and the infinities.
+func formatSpecial(x float64) string {
+
+	switch {
+
+	case math.IsNaN(x):
+
+		return "NaN"
+
+	case x < 0:
+
+		return "-Inf"
+	}
+
+	return "Inf"
+}
+
+// applyTemplate marks the sign of a formatted number with the formatter's
+// sign style and places it in the formatter's template.
+func (f Formatter) applyTemplate(s string) string {
+
+	s = f.applySign(s)
+
+	if f.Digits != (DigitSet{}) {
+
+		s = SubstituteDigits(s, f.Digits)
+	}
+
+	if f.Template == "" {
+
+		return s
+	}
+
+	if !strings.Contains(f.Template, TemplatePlaceholder) {
+
+		return f.Template + s
+	}
+
+	return strings.Replace(f.Template, TemplatePlaceholder, s, 1)
+}
+
+// formatPercent places a formatted number in the formatter's percent
+// pattern, keeping any minus sign first.
+func (f Formatter) formatPercent(s string) string {
+
+	var (
+		pattern string = f.PercentPattern
+		sign    string
+	)
+
+	if pattern == "" {
+
+		pattern = "{}%"
+	}
+
+	if strings.HasPrefix(s, "-") {
+
+		s, sign = s[1:], "-"
+	}
+
+	if !strings.Contains(pattern, TemplatePlaceholder) {
+
+		return sign + pattern + s
+	}
+
+	return sign + strings.Replace(pattern, TemplatePlaceholder, s, 1)
+}
+
+// decimalSeparator returns the formatter's decimal separator, or a point if
+// none is set.
+func (f Formatter) decimalSeparator() string {
+
+	if f.DecimalSeparator == "" {
+
+		return "."
+	}
+
+	return f.DecimalSeparator
+}
@@ -0,0 +1,509 @@
+package decimals
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+// Test Formatter methods with a range of locales
+func TestFormatter(t *testing.T) {
+
+	locales := []string{"en-US", "de_DE", "PT-br", ""}
+
+	expected := [][3]string{
+		{"-5,555,555", "5,555,600", "-5,555,555.12"},
+		{"-5.555.555", "5.555.600", "-5.555.555,12"},
+		{"-5.555.555", "5.555.600", "-5.555.555,12"},
+		{"-5555555", "5555600", "-5555555.12"},
+	}
+
+	for i, locale := range locales {
+
+		f, err := NewFormatter(locale)
+
+		if locale == "" {
+
+			f, err = Formatter{}, nil
+		}
+
+		if err != nil {
+
+			t.Fatalf("Unexpected error: %v testing NewFormatter(%q)", err, locale)
+		}
+
+		output := [3]string{
+			f.FormatThousands(-5555555),
+			f.FormatInt(5555555, -2),
+			f.FormatFloat(-5555555.123, 2),
+		}
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Formatter for %q",
+				expected[i], output, locale)
+		}
+	}
+
+	if _, err := NewFormatter("xx-XX"); err == nil {
+
+		t.Errorf("Expected an error testing NewFormatter with an unknown locale")
+	}
+}
+
+// Test SetDefaultFormatter changes the package level functions
+func TestSetDefaultFormatter(t *testing.T) {
+
+	saved := DefaultFormatter()
+	defer SetDefaultFormatter(saved)
+
+	f, _ := NewFormatter("de-DE")
+	SetDefaultFormatter(f)
+
+	inputs := []string{
+		FormatThousands(1234567),
+		FormatInt(1234567, -3),
+		FormatFloat(1234.5, 2),
+		FormatWithSpec(1234.5, FormatSpec{Precision: 1, Grouping: true}),
+	}
+
+	expected := []string{"1.234.567", "1.235.000", "1.234,50", "1.234,5"}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing the default formatter",
+				expected[i], output)
+		}
+	}
+}
+
+// Test the default formatter can be used while it is being replaced
+func TestDefaultFormatterConcurrency(t *testing.T) {
+
+	saved := DefaultFormatter()
+	defer SetDefaultFormatter(saved)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+
+		wg.Add(2)
+
+		go func() {
+
+			defer wg.Done()
+			SetDefaultFormatter(saved)
+		}()
+
+		go func() {
+
+			defer wg.Done()
+
+			if output := FormatInt(1234, 0); output != "1,234" {
+
+				t.Errorf("Expected: %q but received: %q testing FormatInt concurrently",
+					"1,234", output)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// Test a Formatter's template is applied by each formatting method
+func TestFormatterTemplate(t *testing.T) {
+
+	f := Formatter{GroupSeparator: ",", Template: "{} USD"}
+	g := Formatter{GroupSeparator: ",", Template: "≈{}"}
+	h := Formatter{GroupSeparator: ",", Template: "$"}
+
+	inputs := []string{
+		f.FormatThousands(1234567),
+		f.FormatInt(1234567, -3),
+		f.FormatFloat(-1234.5, 2),
+		f.FormatCompact(1234567, 1),
+		f.FormatWithSpec(12.5, FormatSpec{Precision: 1, Width: 10}),
+		g.FormatFloat(0.333, 2),
+		g.ExactPercent(1, 3, 0),
+		h.FormatFloat(5, 2),
+		Formatter{Template: "{} / {}"}.FormatInt(5, 0),
+	}
+
+	expected := []string{
+		"1,234,567 USD",
+		"1,235,000 USD",
+		"-1,234.50 USD",
+		"1.2M USD",
+		"  12.5 USD",
+		"≈0.33",
+		"≈33%",
+		"$5.00",
+		"5 / {}",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Formatter templates",
+				expected[i], output)
+		}
+	}
+}
+
+// Test the de-CH preset and SIFormatter
+func TestSwissAndSIFormatters(t *testing.T) {
+
+	swiss, err := NewFormatter("de-CH")
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	inputs := []string{
+		swiss.FormatFloat(1234567.891, 2),
+		swiss.FormatFloat(-0.5, 2),
+		SIFormatter.FormatFloat(1234567.891, 2),
+		SIFormatter.FormatInt(1234, 0),
+	}
+
+	expected := []string{
+		"1'234'567.89",
+		"-0.50",
+		"1\u202F234\u202F567.89",
+		"1\u202F234",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing de-CH and SIFormatter", expected[i], output)
+		}
+	}
+}
+
+// Test UnderscoreFormatter output is accepted by ParseFloat
+func TestUnderscoreFormatter(t *testing.T) {
+
+	inputs := []float64{1000000, -1234567.5, 999, 0.25}
+
+	expected := []string{"1_000_000.00", "-1_234_567.50", "999.00", "0.25"}
+
+	for i, n := range inputs {
+
+		output := UnderscoreFormatter.FormatFloat(n, 2)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing UnderscoreFormatter.FormatFloat(%v, 2)",
+				expected[i], output, n)
+		}
+
+		if r, err := ParseFloat(output, ParseUnderscore); err != nil || r != n {
+
+			t.Errorf("Expected: %v but received: %v (%v) testing ParseFloat(%q, ParseUnderscore)",
+				n, r, err, output)
+		}
+	}
+}
+
+// Test formatters with multi-byte separators
+func TestFormatterMultiByte(t *testing.T) {
+
+	f, _ := NewFormatter("fr-FR")
+	g := Formatter{GroupSeparator: "\u2009", DecimalSeparator: "\u066b"}
+
+	inputs := []string{
+		f.FormatFloat(1234567.891, 2),
+		f.FormatInt(-1234567, 0),
+		f.FormatWithSpec(1234.5, FormatSpec{Precision: 1, Grouping: true, Width: 9}),
+		f.FormatCompact(1234567, 1),
+		g.FormatFloat(1234567.891, 2),
+		FormatRange(1000, 2000, 0, WithFormatter(f), WithWidth(12)),
+	}
+
+	expected := []string{
+		"1\u202f234\u202f567,89",
+		"-1\u202f234\u202f567",
+		"  1\u202f234,5",
+		"1,2M",
+		"1\u2009234\u2009567\u066b89",
+		" 1\u202f000–2\u202f000",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing multi-byte separators",
+				expected[i], output)
+		}
+	}
+}
+
+// Test MaxSignificantDigits limits digits with grouping and decimals
+func TestFormatterMaxSignificantDigits(t *testing.T) {
+
+	f := Formatter{GroupSeparator: ",", DecimalSeparator: ".", MaxSignificantDigits: 3}
+	g := Formatter{GroupSeparator: ".", DecimalSeparator: ",", MaxSignificantDigits: 2}
+
+	inputs := []string{
+		f.FormatThousands(123456),
+		f.FormatInt(-123456, 0),
+		f.FormatInt(123, -2),
+		f.FormatFloat(1234.5678, 2),
+		f.FormatFloat(1.23456, 4),
+		f.FormatFloat(1.2, 4),
+		f.FormatFloat(9.996, 3),
+		f.FormatFloat(999999, 0),
+		f.FormatFloat(0.00123456, 6),
+		f.FormatFloat(0, 2),
+		f.FormatDecimal(MustParseDecimal("12345678901234567890"), 0),
+		f.FormatWithSpec(2.345, FormatSpec{Precision: 3, Mode: HalfEven}),
+		g.FormatInt(1249, -1),
+		g.FormatFloat(-987654.321, 2),
+	}
+
+	expected := []string{
+		"123,000",
+		"-123,000",
+		"100",
+		"1,230",
+		"1.23",
+		"1.20",
+		"10.0",
+		"1,000,000",
+		"0.00123",
+		"0.00",
+		"12,300,000,000,000,000,000",
+		"2.34",
+		"1.200",
+		"-990.000",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing MaxSignificantDigits", expected[i], output)
+		}
+	}
+}
+
+// Test the placeholder replaces the whole output for missing values
+func TestFormatterPlaceholder(t *testing.T) {
+
+	var (
+		f       = Formatter{GroupSeparator: ",", Template: "{} USD", Placeholder: "—"}
+		missing = math.NaN()
+		value   = 1234.5
+	)
+
+	inputs := []string{
+		f.FormatFloat(missing, 2),
+		f.FormatFloat(math.Inf(-1), 2),
+		f.FormatFloatPtr(nil, 2),
+		f.FormatFloatPtr(&value, 2),
+		f.FormatCompact(missing, 1),
+		f.FormatLong(missing, 1),
+		f.FormatPercent(missing, 1),
+		f.FormatScientific(missing, 2),
+		f.FormatQuantity(Quantity{missing, "item", "items"}, 0),
+		f.FormatQuantitySI(Quantity{missing, "m", "m"}, 0),
+		f.FormatWithSpec(missing, FormatSpec{Width: 4, Sign: SignAlways}),
+		FormatFloatPtr(nil, 2),
+		Formatter{}.FormatFloat(missing, 2),
+	}
+
+	expected := []string{
+		"—",
+		"-Inf USD",
+		"—",
+		"1,234.50 USD",
+		"—",
+		"—",
+		"—",
+		"—",
+		"—",
+		"—",
+		"   —",
+		"",
+		"NaN",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Placeholder", expected[i], output)
+		}
+	}
+}
+
+// Test the grouping threshold leaves short integer parts ungrouped
+func TestFormatterGroupingThreshold(t *testing.T) {
+
+	var (
+		f      = Formatter{GroupSeparator: ",", GroupingThreshold: 5}
+		groups []int
+	)
+
+	f.WriteDigits(1234, 0, func(digit int, position int, groupBoundary bool) {
+
+		if groupBoundary {
+
+			groups = append(groups, position)
+		}
+	})
+
+	inputs := []string{
+		f.FormatInt(1234, 0),
+		f.FormatInt(-12345, 0),
+		f.FormatFloat(9999.99, 1),
+		f.FormatFloat(1234.5678, 2),
+		f.FormatDecimal(MustParseDecimal("123456.7"), 1),
+		f.FormatWithSpec(1234, FormatSpec{Grouping: true}),
+		f.FormatSeries(make([]float64, 1234), 0, 0),
+		Formatter{GroupSeparator: ",", GroupingThreshold: 2}.FormatInt(1234, 0),
+	}
+
+	expected := []string{
+		"1234",
+		"-12,345",
+		"10,000.0",
+		"1234.57",
+		"123,456.7",
+		"1234",
+		"… (n=1234)",
+		"1,234",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing GroupingThreshold", expected[i], output)
+		}
+	}
+
+	if len(groups) != 0 {
+
+		t.Errorf("Expected: [] but received: %v testing WriteDigits with GroupingThreshold", groups)
+	}
+}
+
+// Test MinIntegerDigits pads or removes integer digits
+func TestFormatterMinIntegerDigits(t *testing.T) {
+
+	var (
+		pad  = Formatter{GroupSeparator: ",", DecimalSeparator: ".", MinIntegerDigits: 3}
+		wide = Formatter{DecimalSeparator: ".", MinIntegerDigits: 6}
+		none = Formatter{GroupSeparator: ",", DecimalSeparator: ".", MinIntegerDigits: -1}
+	)
+
+	inputs := []string{
+		pad.FormatFloat(7.5, 2),
+		pad.FormatFloat(-7.5, 2),
+		pad.FormatFloat(0.25, 2),
+		pad.FormatFloat(1234.5, 1),
+		pad.FormatInt(42, 0),
+		pad.FormatDecimal(MustParseDecimal("5"), 0),
+		wide.FormatInt(2024, 0),
+		none.FormatFloat(0.25, 2),
+		none.FormatFloat(-0.25, 2),
+		none.FormatFloat(0, 0),
+		none.FormatFloat(1.25, 2),
+		DefaultFormatter().FormatFloat(0.25, 2),
+	}
+
+	expected := []string{
+		"007.50",
+		"-007.50",
+		"000.25",
+		"1,234.5",
+		"042",
+		"005",
+		"002024",
+		".25",
+		"-.25",
+		"0",
+		"1.25",
+		"0.25",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing MinIntegerDigits", expected[i], output)
+		}
+	}
+}
+
+// Test NewFormatter falls back to CLDR data for locales without a preset
+func TestNewFormatterCLDR(t *testing.T) {
+
+	var (
+		inputs   = []string{"de-AT", "de_CH", "fr-BE", "pl", "pt-PT", "pt-BR"}
+		expected = []string{"1\u00A0234,5", "1'234.5", "1\u202F234,5", "1234,5", "12\u00A0345,5", "1.234,5"}
+		values   = []float64{1234.5, 1234.5, 1234.5, 1234.5, 12345.5, 1234.5}
+	)
+
+	for i, input := range inputs {
+
+		f, err := NewFormatter(input)
+
+		if output := f.FormatFloat(values[i], 1); err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing NewFormatter(%q)", expected[i], output, err, input)
+		}
+	}
+
+	if _, err := NewFormatter("xx-YY"); err == nil {
+
+		t.Errorf("Expected: error but received: nil testing NewFormatter(%q)", "xx-YY")
+	}
+}
+
+// Test RegisterLocale overrides presets and CLDR data and is used by
+// WithLocale
+func TestRegisterLocale(t *testing.T) {
+
+	defer func() {
+
+		registeredMutex.Lock()
+		defer registeredMutex.Unlock()
+
+		delete(registeredLocales, "de-ch")
+		delete(registeredLocales, "en-us")
+		delete(registeredLocales, "xx")
+	}()
+
+	RegisterLocale("de_CH", Formatter{GroupSeparator: "\u2019", DecimalSeparator: "."})
+	RegisterLocale("en-US", Formatter{GroupSeparator: " ", DecimalSeparator: "."})
+	RegisterLocale("XX", Formatter{GroupSeparator: ".", DecimalSeparator: ","})
+
+	var (
+		inputs   = []string{"de-CH", "en-us", "xx", "xx-YY", "de-AT"}
+		expected = []string{"1\u2019234\u2019567.5", "1 234 567.5", "1.234.567,5", "1.234.567,5", "1 234 567,5"}
+	)
+
+	for i, input := range inputs {
+
+		f, err := NewFormatter(input)
+
+		if output := f.FormatFloat(1234567.5, 1); err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing RegisterLocale(%q)", expected[i], output, err, input)
+		}
+	}
+
+	if output := FormatFloatOpt(1234567.5, WithLocale("de-ch"), WithPrecision(1)); output != expected[0] {
+
+		t.Errorf("Expected: %q but received: %q testing RegisterLocale with WithLocale", expected[0], output)
+	}
+}
@@ -0,0 +1,67 @@
+package decimals
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// A FormattedGauge holds a float64 for publishing with expvar, and renders
+// it with a Formatter so that debug endpoints show grouped, rounded
+// numbers such as "1,234,567.89" rather than raw floats. It implements
+// expvar.Var, and is safe for concurrent use.
+type FormattedGauge struct {
+	mu        sync.Mutex
+	formatter Formatter
+	precision int
+	value     float64
+}
+
+// NewFormattedGauge returns a FormattedGauge of zero that is rendered by f
+// to the given precision, as by FormatFloat. Publish it with
+// expvar.Publish.
+func NewFormattedGauge(f Formatter, precision int) *FormattedGauge {
+
+	return &FormattedGauge{formatter: f, precision: precision}
+}
+
+// Set sets the gauge to x.
+func (g *FormattedGauge) Set(x float64) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.value = x
+}
+
+// Add adds delta to the gauge.
+func (g *FormattedGauge) Add(delta float64) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.value += delta
+}
+
+// Value returns the value of the gauge.
+func (g *FormattedGauge) Value() float64 {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.value
+}
+
+// Format returns the value of the gauge formatted by its formatter.
+func (g *FormattedGauge) Format() string {
+
+	return g.formatter.FormatFloat(g.Value(), g.precision)
+}
+
+// String returns the formatted value of the gauge as a quoted JSON string,
+// as expvar.Var requires.
+func (g *FormattedGauge) String() string {
+
+	b, _ := json.Marshal(g.Format())
+
+	return string(b)
+}
@@ -0,0 +1,69 @@
+package decimals
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+	"testing"
+)
+
+// Test FormattedGauge renders its value with its formatter as JSON
+func TestFormattedGauge(t *testing.T) {
+
+	french, err := NewFormatter("fr")
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	var (
+		g             = NewFormattedGauge(DefaultFormatter(), 2)
+		fr            = NewFormattedGauge(french, 0)
+		v  expvar.Var = g
+	)
+
+	g.Set(1234567.891)
+	fr.Set(-1234567.5)
+
+	inputs := []string{v.String(), g.Format(), fr.Format()}
+	expected := []string{`"1,234,567.89"`, "1,234,567.89", "-1 234 568"}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormattedGauge", expected[i], output)
+		}
+	}
+
+	var s string
+
+	if err := json.Unmarshal([]byte(fr.String()), &s); err != nil || s != fr.Format() {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing FormattedGauge.String", fr.Format(), s, err)
+	}
+
+	// Concurrent additions are not lost
+	var wg sync.WaitGroup
+
+	g.Set(0)
+
+	for i := 0; i < 100; i++ {
+
+		wg.Add(1)
+
+		go func() {
+
+			defer wg.Done()
+			g.Add(0.5)
+		}()
+	}
+
+	wg.Wait()
+
+	if output := g.Value(); output != 50 {
+
+		t.Errorf("Expected: 50 but received: %v testing FormattedGauge.Add", output)
+	}
+}
@@ -0,0 +1,269 @@
+package decimals
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// goldenCorpus holds the output of this version of the package for every
+// golden case, one "call = output" line per case
+//
+//go:embed testdata/format.golden
+var goldenCorpus []byte
+
+// A GoldenCase is a call to a function of the package with fixed inputs and
+// the output it produced, written as "FormatFloat(2.675, 2)" and "2.68".
+type GoldenCase struct {
+	Call   string
+	Output string
+}
+
+// A GoldenMismatch is a golden case whose output has changed.
+type GoldenMismatch struct {
+	Call     string
+	Expected string // the output recorded in the corpus
+	Received string // the output of this version of the package
+
+	// Missing reports that the call is no longer in the corpus of this
+	// version, so there is no output to compare.
+	Missing bool
+}
+
+// Inputs chosen to sit on rounding boundaries, near the limits of float64
+// and at values that have no exact binary representation
+var goldenInputs = []float64{
+	0,
+	math.Copysign(0, -1),
+	0.30000000000000004,
+	1.005,
+	2.675,
+	-2.675,
+	999.995,
+	-0.0456,
+	123456.789,
+	999999.5,
+	1e21,
+	-1e-7,
+	9007199254740993,
+	5e-324,
+	math.MaxFloat64,
+	math.Inf(1),
+	math.NaN(),
+	1e-300,
+	-0.5,
+}
+
+// Integers at the limits of int64 and on rounding boundaries
+var goldenInts = []int64{0, 5, -15, 999999, math.MinInt64, math.MaxInt64}
+
+// Strings to parse, from the forms the formatter writes to invalid input
+var goldenStrings = []string{"1,234.5", "-0", "1e-300", "+1.5E3", " 5 ", "1,23", "NaN", "", "١٢٣٫٤", "1e400", "1.2K", "-3.4 M", "9e18E"}
+
+// The rounding modes in order, named as in calls
+var goldenModes = []string{"HalfUp", "HalfEven", "HalfDown", "Up", "Down", "Ceiling", "Floor"}
+
+// Decimals beyond the range and precision of float64 and int64, and on
+// rounding boundaries
+var goldenDecimals = []string{"0", "-0.005", "2.675", "-2.665", "1e-30", "123456789012345678901234567890.125", "-9223372036854775809"}
+
+// GoldenCases returns the golden corpus of this version of the package:
+// calls to its main formatting, parsing and rounding functions and Decimal
+// methods with exotic inputs, such as -0, 1e-300, NaN and math.MinInt64,
+// and the output each produced, formatted with the separators of the
+// initial default formatter. The corpus is embedded in the package.
+func GoldenCases() []GoldenCase {
+
+	cases, _ := readGolden(bytes.NewReader(goldenCorpus))
+
+	return cases
+}
+
+// WriteGolden writes the golden corpus of this version of the package to
+// w, one "call = output" line per case, so that it can be saved alongside
+// a project's tests and checked with VerifyAgainstGolden after upgrading.
+func WriteGolden(w io.Writer) error {
+
+	_, err := w.Write(goldenCorpus)
+
+	return err
+}
+
+// VerifyAgainstGolden reads a golden corpus written by WriteGolden, perhaps
+// by an earlier version of the package, and repeats each call with this
+// version. It returns the cases whose output has changed, in the order of
+// the corpus, so that behaviour changes between versions can be caught in
+// continuous integration. Cases not in the corpus of this version are
+// returned as Missing. An error is returned if the corpus cannot be read.
+func VerifyAgainstGolden(r io.Reader) ([]GoldenMismatch, error) {
+
+	saved, err := readGolden(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	current := make(map[string]string)
+
+	for _, c := range goldenOutput() {
+
+		current[c.Call] = c.Output
+	}
+
+	var mismatches []GoldenMismatch
+
+	for _, c := range saved {
+
+		output, ok := current[c.Call]
+
+		if !ok || output != c.Output {
+
+			mismatches = append(mismatches, GoldenMismatch{c.Call, c.Output, output, !ok})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// readGolden parses a golden corpus.
+func readGolden(r io.Reader) ([]GoldenCase, error) {
+
+	var (
+		cases   []GoldenCase
+		scanner = bufio.NewScanner(r)
+	)
+
+	for scanner.Scan() {
+
+		line := scanner.Text()
+
+		if line == "" {
+
+			continue
+		}
+
+		i := strings.Index(line, " = ")
+
+		if i < 0 {
+
+			return nil, fmt.Errorf("decimals: invalid golden case %q", line)
+		}
+
+		cases = append(cases, GoldenCase{line[:i], line[i+3:]})
+	}
+
+	return cases, scanner.Err()
+}
+
+// goldenOutput calls every golden function with each golden input. The
+// functions are called as methods of a formatter with the separators of
+// the initial default formatter, so that the output does not depend on
+// the default set by SetDefaultFormatter.
+func goldenOutput() []GoldenCase {
+
+	var (
+		cases []GoldenCase
+		f     = Formatter{GroupSeparator: ",", DecimalSeparator: "."}
+	)
+
+	add := func(output string, format string, args ...interface{}) {
+
+		cases = append(cases, GoldenCase{fmt.Sprintf(format, args...), output})
+	}
+
+	for _, x := range goldenInputs {
+
+		for _, p := range []int{-2, 0, 2, 6} {
+
+			add(f.FormatFloat(x, p), "FormatFloat(%v, %d)", x, p)
+		}
+
+		add(fmt.Sprint(RoundFloat(x, 2)), "RoundFloat(%v, 2)", x)
+		add(f.FormatCompact(x, 1), "FormatCompact(%v, 1)", x)
+		add(f.FormatLong(x, 2), "FormatLong(%v, 2)", x)
+		add(f.FormatFloat(x, adaptivePrecision(x, DefaultBreakpoints, DefaultSignificantDigits)), "FormatAdaptive(%v)", x)
+		add(f.FormatWithSpec(x, FormatSpec{Precision: 3, Mode: HalfEven, Grouping: true, Sign: SignAlways}),
+			"FormatWithSpec(%v, {3 HalfEven})", x)
+		add(f.FormatPercent(x, 1), "FormatPercent(%v, 1)", x)
+		add(f.FormatScientific(x, 3), "FormatScientific(%v, 3)", x)
+		add(fmt.Sprint(Quantize(x, 0.05, HalfEven)), "Quantize(%v, 0.05, HalfEven)", x)
+
+		for m, name := range goldenModes {
+
+			mode := RoundingMode(m)
+
+			add(fmt.Sprint(RoundFloatExact(x, 2, mode)), "RoundFloatExact(%v, 2, %s)", x, name)
+			add(fmt.Sprint(RoundFloatMode(x, 0, mode)), "RoundFloatMode(%v, 0, %s)", x, name)
+		}
+	}
+
+	ss, exponent := f.FormatWithCommonExponent(goldenInputs[:10], 2)
+	add(fmt.Sprint(ss, " ", exponent), "FormatWithCommonExponent(goldenInputs[:10], 2)")
+
+	for _, n := range [][2]int64{{1, 3}, {2, 3}, {-1, 7}, {math.MaxInt64, 3}} {
+
+		add(f.ExactPercent(n[0], n[1], 4), "ExactPercent(%d, %d, 4)", n[0], n[1])
+	}
+
+	for _, n := range goldenInts {
+
+		add(f.FormatInt(n, -1), "FormatInt(%d, -1)", n)
+		add(f.FormatThousands(n), "FormatThousands(%d)", n)
+		add(fmt.Sprint(RoundInt(n, -2)), "RoundInt(%d, -2)", n)
+		add(fmt.Sprint(RoundToNearest(n, 5, HalfEven)), "RoundToNearest(%d, 5, HalfEven)", n)
+
+		for m, name := range goldenModes {
+
+			mode := RoundingMode(m)
+
+			add(fmt.Sprint(RoundIntMode(n, -1, mode)), "RoundIntMode(%d, -1, %s)", n, name)
+		}
+	}
+
+	for _, s := range goldenStrings {
+
+		r, err := ParseFloat(s, ParseLenient)
+		add(fmt.Sprint(r, " ", err), "ParseFloat(%q, ParseLenient)", s)
+
+		r, err = ParseCompact(s, ParseLenient)
+		add(fmt.Sprint(r, " ", err), "ParseCompact(%q, ParseLenient)", s)
+
+		d, err := ParseDecimal(s, ParseLenient)
+		add(fmt.Sprint(d, " ", err), "ParseDecimal(%q, ParseLenient)", s)
+	}
+
+	three := DecimalFromInt(3)
+
+	for _, s := range goldenDecimals {
+
+		d, _ := ParseDecimal(s, ParseExponent)
+
+		add(f.FormatDecimal(d, 2), "FormatDecimal(%s, 2)", s)
+		add(d.Neg().String(), "%s.Neg()", s)
+		add(d.Mul(three).String(), "%s.Mul(3)", s)
+		add(fmt.Sprint(d.Float64()), "%s.Float64()", s)
+
+		n, err := d.Int64()
+		add(fmt.Sprint(n, " ", err), "%s.Int64()", s)
+
+		q, err := d.Div(three, 10, HalfEven)
+		add(fmt.Sprint(q, " ", err), "%s.Div(3, 10, HalfEven)", s)
+
+		r, err := d.Sqrt(10, HalfEven)
+		add(fmt.Sprint(r, " ", err), "%s.Sqrt(10, HalfEven)", s)
+
+		for m, name := range goldenModes {
+
+			mode := RoundingMode(m)
+
+			add(d.Round(2, mode).String(), "%s.Round(2, %s)", s, name)
+		}
+	}
+
+	return cases
+}
@@ -0,0 +1,127 @@
+package decimals
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Regenerate the golden files with go test -update
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// Test every Format function produces output identical to the golden file,
+// which is the same on every architecture
+func TestGolden(t *testing.T) {
+
+	path := filepath.Join("testdata", "format.golden")
+
+	var b strings.Builder
+
+	for _, c := range goldenOutput() {
+
+		fmt.Fprintf(&b, "%s = %s\n", c.Call, c.Output)
+	}
+
+	output := b.String()
+
+	if *update {
+
+		if err := ioutil.WriteFile(path, []byte(output), 0644); err != nil {
+
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := ioutil.ReadFile(path)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	got := strings.Split(output, "\n")
+	want := strings.Split(string(expected), "\n")
+
+	if len(got) != len(want) {
+
+		t.Fatalf("Expected: %d lines but received: %d testing golden output", len(want), len(got))
+	}
+
+	for i := range want {
+
+		if got[i] != want[i] {
+
+			t.Errorf("Expected: %q but received: %q testing golden output", want[i], got[i])
+		}
+	}
+}
+
+// Test VerifyAgainstGolden reports changed and missing cases
+func TestVerifyAgainstGolden(t *testing.T) {
+
+	var b strings.Builder
+
+	if err := WriteGolden(&b); err != nil {
+
+		t.Fatal(err)
+	}
+
+	if cases := GoldenCases(); len(cases) != len(goldenOutput()) || cases[0].Call != "FormatFloat(0, -2)" {
+
+		t.Errorf("Expected: %d cases but received: %d testing GoldenCases", len(goldenOutput()), len(cases))
+	}
+
+	mismatches, err := VerifyAgainstGolden(strings.NewReader(b.String()))
+
+	if err != nil || len(mismatches) != 0 {
+
+		t.Errorf("Expected: no mismatches but received: %v (%v) testing VerifyAgainstGolden", mismatches, err)
+	}
+
+	// A corpus from a version with other outputs and calls
+	saved := "FormatFloat(2.675, 2) = 2.67\nFormatFloat(0, 0) = 0\nFormatRoman(4) = IV\n"
+	expected := []GoldenMismatch{
+		{"FormatFloat(2.675, 2)", "2.67", "2.68", false},
+		{"FormatRoman(4)", "IV", "", true},
+	}
+
+	mismatches, err = VerifyAgainstGolden(strings.NewReader(saved))
+
+	if err != nil || len(mismatches) != len(expected) {
+
+		t.Fatalf("Expected: %v but received: %v (%v) testing VerifyAgainstGolden", expected, mismatches, err)
+	}
+
+	for i, m := range mismatches {
+
+		if m != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v testing VerifyAgainstGolden", expected[i], m)
+		}
+	}
+
+	if _, err := VerifyAgainstGolden(strings.NewReader("FormatFloat(1, 2)\n")); err == nil {
+
+		t.Errorf("Expected: an error but received: nil testing VerifyAgainstGolden")
+	}
+}
+
+// Test VerifyAgainstGolden does not depend on the default formatter
+func TestVerifyAgainstGoldenLocale(t *testing.T) {
+
+	saved := DefaultFormatter()
+	defer SetDefaultFormatter(saved)
+
+	f, _ := NewFormatter("de-DE")
+	SetDefaultFormatter(f)
+
+	mismatches, err := VerifyAgainstGolden(strings.NewReader(string(goldenCorpus)))
+
+	if err != nil || len(mismatches) != 0 {
+
+		t.Errorf("Expected: no mismatches but received: %d (%v) testing VerifyAgainstGolden with de-DE", len(mismatches), err)
+	}
+}
@@ -0,0 +1,207 @@
+package decimals
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// An Int128 is a signed 128-bit integer, for 128-bit identifiers and
+// balances such as amounts in wei, held in two's complement as Hi × 2^64 +
+// Lo. It is formatted and rounded with math/bits, without math/big.
+type Int128 struct {
+	Hi int64
+	Lo uint64
+}
+
+// The limits of Int128
+var (
+	MaxInt128 = Int128{math.MaxInt64, math.MaxUint64}
+	MinInt128 = Int128{math.MinInt64, 0}
+)
+
+// Int128FromInt64 returns the Int128 with the value of x.
+func Int128FromInt64(x int64) Int128 {
+
+	return Int128{x >> 63, uint64(x)}
+}
+
+// Sign returns -1 if x is negative, 0 if x is zero and 1 if x is positive.
+func (x Int128) Sign() int {
+
+	switch {
+
+	case x.Hi < 0:
+
+		return -1
+
+	case x.Hi == 0 && x.Lo == 0:
+
+		return 0
+	}
+
+	return 1
+}
+
+// String returns x in decimal digits, with a minus sign if it is negative.
+func (x Int128) String() string {
+
+	if x.Sign() < 0 {
+
+		return "-" + x.absDigits()
+	}
+
+	return x.absDigits()
+}
+
+// absDigits returns the decimal digits of the absolute value of x.
+func (x Int128) absDigits() string {
+
+	hi, lo := uint64(x.Hi), x.Lo
+
+	if x.Hi < 0 {
+
+		// Negate in two's complement, which gives 2^127 for MinInt128
+		var borrow uint64
+
+		lo, borrow = bits.Sub64(0, lo, 0)
+		hi, _ = bits.Sub64(0, hi, borrow)
+	}
+
+	if hi == 0 {
+
+		return strconv.FormatUint(lo, 10)
+	}
+
+	// Divide by 10^19, the largest power of ten in a uint64, collecting
+	// the remainders as chunks of nineteen digits from the right
+	const chunk = 1e19
+
+	var chunks []uint64
+
+	for hi != 0 {
+
+		var r uint64
+
+		q := hi / chunk
+		lo, r = bits.Div64(hi%chunk, lo, chunk)
+		hi = q
+
+		chunks = append(chunks, r)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(strconv.FormatUint(lo, 10))
+
+	for i := len(chunks) - 1; i >= 0; i-- {
+
+		s := strconv.FormatUint(chunks[i], 10)
+		b.WriteString(strings.Repeat("0", 19-len(s)))
+		b.WriteString(s)
+	}
+
+	return b.String()
+}
+
+// DecimalFromInt128 returns the Decimal with the value of x.
+func DecimalFromInt128(x Int128) Decimal {
+
+	return newDecimal(x.Sign() < 0, x.absDigits(), 0)
+}
+
+// Int128 returns the integer part of d, truncating any fraction toward
+// zero. If it does not fit in an Int128 MinInt128 or MaxInt128 is returned
+// with an error wrapping ErrRange.
+func (d Decimal) Int128() (Int128, error) {
+
+	var (
+		is, _  = d.parts(0)
+		hi, lo uint64
+	)
+
+	// Accumulate the digits, multiplying by ten with math/bits
+	for i := 0; i < len(is); i++ {
+
+		h1, l1 := bits.Mul64(lo, 10)
+		h2, l2 := bits.Mul64(hi, 10)
+
+		var carry uint64
+
+		lo, carry = bits.Add64(l1, uint64(is[i]-'0'), 0)
+		hi, carry = bits.Add64(h1, l2, carry)
+
+		if h2 != 0 || carry != 0 || hi > 1<<63 || (hi == 1<<63 && (lo != 0 || !d.negative)) {
+
+			if d.negative {
+
+				return MinInt128, &NumError{"Decimal.Int128", d.String(), ErrRange}
+			}
+
+			return MaxInt128, &NumError{"Decimal.Int128", d.String(), ErrRange}
+		}
+	}
+
+	if d.negative {
+
+		var borrow uint64
+
+		lo, borrow = bits.Sub64(0, lo, 0)
+		hi, _ = bits.Sub64(0, hi, borrow)
+	}
+
+	return Int128{int64(hi), lo}, nil
+}
+
+// RoundInt128 rounds an Int128 half up to the given precision, as RoundInt
+// does. Results beyond the range of Int128 saturate at MinInt128 or
+// MaxInt128.
+func RoundInt128(x Int128, precision int) Int128 {
+
+	if precision > -1 {
+
+		return x
+	}
+
+	r, _ := DecimalFromInt128(x).Round(precision, HalfUp).Int128()
+
+	return r
+}
+
+// FormatThousands128 formats an Int128 using the default formatter. See
+// Formatter.FormatThousands128.
+func FormatThousands128(x Int128) string {
+
+	return DefaultFormatter().FormatThousands128(x)
+}
+
+// FormatThousands128 converts an Int128 into a string formatted using the
+// formatter's separator for thousands, as FormatThousands does.
+func (f Formatter) FormatThousands128(x Int128) string {
+
+	return f.FormatInt128(x, 0)
+}
+
+// FormatInt128 formats an Int128 using the default formatter. See
+// Formatter.FormatInt128.
+func FormatInt128(x Int128, precision int) string {
+
+	return DefaultFormatter().FormatInt128(x, precision)
+}
+
+// FormatInt128 converts an Int128 to a formatted string, rounded half up
+// to the given precision and formatted using the formatter's separator for
+// thousands, as FormatInt does. The rounded value is formatted exactly,
+// even if it is beyond the range of Int128.
+func (f Formatter) FormatInt128(x Int128, precision int) string {
+
+	if precision > 0 {
+
+		precision = 0
+	}
+
+	r, places := f.roundSignificant(DecimalFromInt128(x), precision, HalfUp)
+
+	return f.applyTemplate(f.formatDecimal(r, places))
+}
@@ -0,0 +1,114 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Test Int128 converts to and from decimal digits
+func TestInt128String(t *testing.T) {
+
+	var (
+		inputs = []Int128{
+			{}, Int128FromInt64(-1), Int128FromInt64(math.MinInt64), {0, math.MaxUint64}, {1, 0},
+			{1, 1}, MaxInt128, MinInt128, {-1, 1}}
+		expected = []string{
+			"0", "-1", "-9223372036854775808", "18446744073709551615", "18446744073709551616",
+			"18446744073709551617", "170141183460469231731687303715884105727",
+			"-170141183460469231731687303715884105728", "-18446744073709551615"}
+	)
+
+	for i, input := range inputs {
+
+		output := input.String()
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Int128.String(%#v)", expected[i], output, input)
+		}
+
+		if back, err := MustParseDecimal(output).Int128(); err != nil || back != input {
+
+			t.Errorf("Expected: %#v but received: %#v (%v) testing Decimal.Int128(%q)", input, back, err, output)
+		}
+	}
+}
+
+// Test Decimal.Int128 truncates and saturates
+func TestDecimalInt128(t *testing.T) {
+
+	var (
+		inputs = []string{
+			"-2.9", "170141183460469231731687303715884105728", "-170141183460469231731687303715884105729", "1e40"}
+		expected = []Int128{Int128FromInt64(-2), MaxInt128, MinInt128, MaxInt128}
+		errs     = []error{nil, ErrRange, ErrRange, ErrRange}
+	)
+
+	for i, input := range inputs {
+
+		output, err := MustParseDecimal(input).Int128()
+
+		if output != expected[i] || !errors.Is(err, errs[i]) || (err == nil) != (errs[i] == nil) {
+
+			t.Errorf("Expected: %v (%v) but received: %v (%v) testing Decimal.Int128(%q)",
+				expected[i], errs[i], output, err, input)
+		}
+	}
+}
+
+// Test RoundInt128 rounds half up and saturates
+func TestRoundInt128(t *testing.T) {
+
+	var (
+		wei, _ = MustParseDecimal("1234567890123456789012345").Int128()
+		inputs = []Int128{wei, wei, Int128FromInt64(-25), MaxInt128, MinInt128}
+		powers = []int{2, -18, -1, -1, -1}
+	)
+
+	expected := []string{
+		"1234567890123456789012345",
+		"1234568000000000000000000",
+		"-30",
+		"170141183460469231731687303715884105727",
+		"-170141183460469231731687303715884105728",
+	}
+
+	for i, input := range inputs {
+
+		if output := RoundInt128(input, powers[i]).String(); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing RoundInt128(%v, %d)", expected[i], output, input, powers[i])
+		}
+	}
+}
+
+// Test FormatInt128 and FormatThousands128 group and round exactly
+func TestFormatInt128(t *testing.T) {
+
+	f, _ := NewFormatter("de-DE")
+
+	inputs := []string{
+		FormatThousands128(MaxInt128),
+		FormatThousands128(Int128FromInt64(-1234)),
+		FormatInt128(MaxInt128, -36),
+		FormatInt128(Int128FromInt64(999), 2),
+		f.FormatInt128(MinInt128, -30),
+	}
+
+	expected := []string{
+		"170,141,183,460,469,231,731,687,303,715,884,105,727",
+		"-1,234",
+		"170,000,000,000,000,000,000,000,000,000,000,000,000",
+		"999",
+		"-170.141.183.000.000.000.000.000.000.000.000.000.000",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatInt128", expected[i], output)
+		}
+	}
+}
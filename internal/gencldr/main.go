@@ -0,0 +1,345 @@
+/*
+Command gencldr generates the CLDR locale table of the decimals package
+from the JSON distribution of the Unicode CLDR, so that locale data can be
+updated without adding a runtime dependency.
+
+Usage:
+
+	gencldr -cldr dir [-locales list] [-o file]
+
+The flags are:
+
+	-cldr dir
+		the root of a checkout of the cldr-numbers-full package of
+		cldr-json, which holds main/<locale>/numbers.json
+	-locales list
+		a comma-separated list of the locales to include, such as
+		de,de-CH,fr, matched without regard to case (default all)
+	-o file
+		the Go source file to write (default cldr.go)
+
+For each locale the separators, the percent pattern and the minimum
+grouping digits of the Latin digits numbering system are read, and written
+as a map of Formatters keyed by lower case language tag. Each locale
+listed by -locales must be present in the data. It is run from the
+package directory by go generate, which lists the locales of the checked
+in table so that it can be reproduced from any CLDR release, with the
+CLDR_NUMBERS environment variable set to the root of the checkout:
+
+	CLDR_NUMBERS=~/cldr-json/cldr-json/cldr-numbers-full go generate
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// locale holds the number formatting data of one CLDR locale
+type locale struct {
+	tag               string
+	group             string
+	decimal           string
+	percentPattern    string
+	groupingThreshold int
+}
+
+// numbersFile is the layout of a CLDR numbers.json file
+type numbersFile struct {
+	Main map[string]struct {
+		Numbers struct {
+			MinimumGroupingDigits string `json:"minimumGroupingDigits"`
+			Symbols               struct {
+				Decimal     string `json:"decimal"`
+				Group       string `json:"group"`
+				PercentSign string `json:"percentSign"`
+			} `json:"symbols-numberSystem-latn"`
+			PercentFormats struct {
+				Standard string `json:"standard"`
+			} `json:"percentFormats-numberSystem-latn"`
+		} `json:"numbers"`
+	} `json:"main"`
+}
+
+func main() {
+
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run executes the command with the given arguments, and returns the exit
+// status.
+func run(args []string, stderr io.Writer) int {
+
+	var (
+		flags = flag.NewFlagSet("gencldr", flag.ContinueOnError)
+		dir   = flags.String("cldr", "", "the root of the cldr-numbers-full package")
+		list  = flags.String("locales", "", "a comma-separated list of the locales to include (default all)")
+		out   = flags.String("o", "cldr.go", "the Go source file to write")
+	)
+
+	flags.SetOutput(stderr)
+
+	if err := flags.Parse(args); err != nil {
+
+		return 2
+	}
+
+	if *dir == "" {
+
+		fmt.Fprintln(stderr, "gencldr: -cldr is required")
+		return 2
+	}
+
+	locales, err := readLocales(*dir, allowedLocales(*list))
+
+	if err != nil {
+
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	src, err := generate(locales)
+
+	if err != nil {
+
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// allowedLocales returns the set of lower case tags in a comma-separated
+// list, or nil if the list is empty.
+func allowedLocales(list string) map[string]bool {
+
+	if list == "" {
+
+		return nil
+	}
+
+	allow := make(map[string]bool)
+
+	for _, tag := range strings.Split(list, ",") {
+
+		allow[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+
+	return allow
+}
+
+// readLocales reads the numbers.json file of every locale under dir, or of
+// those in allow if it is not nil, sorted by tag. It returns an error if
+// a locale in allow is not found.
+func readLocales(dir string, allow map[string]bool) ([]locale, error) {
+
+	paths, err := filepath.Glob(filepath.Join(dir, "main", "*", "numbers.json"))
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+
+		return nil, fmt.Errorf("gencldr: no main/*/numbers.json files in %s", dir)
+	}
+
+	var locales []locale
+
+	for _, path := range paths {
+
+		data, err := os.ReadFile(path)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		var nf numbersFile
+
+		if err := json.Unmarshal(data, &nf); err != nil {
+
+			return nil, fmt.Errorf("gencldr: %s: %v", path, err)
+		}
+
+		for tag, m := range nf.Main {
+
+			n := m.Numbers
+
+			// Skip locales not allowed or without Latin digit symbols
+			if allow != nil && !allow[strings.ToLower(tag)] || n.Symbols.Decimal == "" {
+
+				continue
+			}
+
+			l := locale{
+				tag:     strings.ToLower(tag),
+				group:   n.Symbols.Group,
+				decimal: n.Symbols.Decimal,
+			}
+
+			if l.percentPattern, err = percentPattern(n.PercentFormats.Standard, n.Symbols.PercentSign); err != nil {
+
+				return nil, fmt.Errorf("gencldr: %s: %v", path, err)
+			}
+
+			// A minimum of two grouping digits leaves four digit numbers
+			// ungrouped
+			if mgd, _ := strconv.Atoi(n.MinimumGroupingDigits); mgd > 1 {
+
+				l.groupingThreshold = 3 + mgd
+			}
+
+			locales = append(locales, l)
+		}
+	}
+
+	sort.Slice(locales, func(i, j int) bool {
+
+		return locales[i].tag < locales[j].tag
+	})
+
+	// Check every allowed locale was found, in a stable order
+	found := make(map[string]bool, len(locales))
+
+	for _, l := range locales {
+
+		found[l.tag] = true
+	}
+
+	missing := make([]string, 0, len(allow))
+
+	for tag := range allow {
+
+		if !found[tag] {
+
+			missing = append(missing, tag)
+		}
+	}
+
+	if len(missing) > 0 {
+
+		sort.Strings(missing)
+
+		return nil, fmt.Errorf("gencldr: locales not found in %s: %s", dir, strings.Join(missing, ", "))
+	}
+
+	return locales, nil
+}
+
+// percentPattern converts a CLDR percent format such as "#,##0 %" into a
+// Formatter percent pattern such as "{} %", with the locale's percent sign.
+// The default "{}%" is returned as the empty string.
+func percentPattern(cldr string, sign string) (string, error) {
+
+	// Use the pattern for positive numbers
+	if i := strings.IndexByte(cldr, ';'); i >= 0 {
+
+		cldr = cldr[:i]
+	}
+
+	start := strings.IndexAny(cldr, "#0")
+	end := strings.LastIndexAny(cldr, "#0")
+
+	if start < 0 {
+
+		return "", fmt.Errorf("invalid percent format %q", cldr)
+	}
+
+	pattern := cldr[:start] + "{}" + cldr[end+1:]
+	pattern = strings.Replace(pattern, "%", sign, 1)
+
+	if pattern == "{}%" {
+
+		return "", nil
+	}
+
+	return pattern, nil
+}
+
+// generate returns the formatted Go source of the locale table.
+func generate(locales []locale) ([]byte, error) {
+
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by gencldr from CLDR JSON data. DO NOT EDIT.\n\n")
+	buf.WriteString("package decimals\n\n")
+	buf.WriteString("// Formatters for the locales of the Unicode CLDR, keyed by lower case\n")
+	buf.WriteString("// language tag, used by NewFormatter for locales without a preset\n")
+	buf.WriteString("var cldrLocales = map[string]Formatter{\n")
+
+	for _, l := range locales {
+
+		fmt.Fprintf(&buf, "%s: {GroupSeparator: %s, DecimalSeparator: %s", quote(l.tag), quote(l.group), quote(l.decimal))
+
+		if l.percentPattern != "" {
+
+			fmt.Fprintf(&buf, ", PercentPattern: %s", quote(l.percentPattern))
+		}
+
+		if l.groupingThreshold != 0 {
+
+			fmt.Fprintf(&buf, ", GroupingThreshold: %d", l.groupingThreshold)
+		}
+
+		buf.WriteString("},\n")
+	}
+
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// quote returns s as a Go string literal with every non-ASCII character
+// escaped, so that invisible separators such as no-break spaces are
+// visible in the source.
+func quote(s string) string {
+
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for _, r := range s {
+
+		switch {
+
+		case r == '"' || r == '\\':
+
+			b.WriteByte('\\')
+			b.WriteRune(r)
+
+		case r > 0xFFFF:
+
+			fmt.Fprintf(&b, "\\U%08X", r)
+
+		case r < 0x20 || r >= utf8.RuneSelf:
+
+			fmt.Fprintf(&b, "\\u%04X", r)
+
+		default:
+
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test percentPattern converts CLDR percent formats
+func TestPercentPattern(t *testing.T) {
+
+	var (
+		inputs   = []string{"#,##0%", "#,##0\u00A0%", "%#,##0", "#,##0 %;-#,##0 %", "#,##,##0%"}
+		expected = []string{"", "{}\u00A0%", "%{}", "{} %", ""}
+	)
+
+	for i, input := range inputs {
+
+		output, err := percentPattern(input, "%")
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing percentPattern(%q)", expected[i], output, err, input)
+		}
+	}
+
+	if _, err := percentPattern("%", "%"); err == nil {
+
+		t.Errorf("Expected: error but received: nil testing percentPattern(%q)", "%")
+	}
+}
+
+// Test run writes the locale table from CLDR data
+func TestRun(t *testing.T) {
+
+	var (
+		stderr bytes.Buffer
+		out    = filepath.Join(t.TempDir(), "cldr.go")
+	)
+
+	if status := run([]string{"-cldr", "testdata", "-o", out}, &stderr); status != 0 {
+
+		t.Fatalf("Expected: 0 but received: %d (%q) testing run", status, stderr.String())
+	}
+
+	src, _ := os.ReadFile(out)
+
+	expected := []string{
+		"// Code generated by gencldr from CLDR JSON data. DO NOT EDIT.",
+		"\"de-ch\": {GroupSeparator: \"\\u2019\", DecimalSeparator: \".\"},",
+		"\"es\":    {GroupSeparator: \".\", DecimalSeparator: \",\", PercentPattern: \"{}\\u00A0%\", GroupingThreshold: 5},",
+		"\"tr\":    {GroupSeparator: \".\", DecimalSeparator: \",\", PercentPattern: \"%{}\"},",
+	}
+
+	for _, line := range expected {
+
+		if !strings.Contains(string(src), line) {
+
+			t.Errorf("Expected: %s but received: %s testing run", line, src)
+		}
+	}
+}
+
+// Test run writes only the locales listed by -locales
+func TestRunLocales(t *testing.T) {
+
+	var (
+		stderr bytes.Buffer
+		out    = filepath.Join(t.TempDir(), "cldr.go")
+	)
+
+	if status := run([]string{"-cldr", "testdata", "-locales", "de-CH, tr", "-o", out}, &stderr); status != 0 {
+
+		t.Fatalf("Expected: 0 but received: %d (%q) testing run", status, stderr.String())
+	}
+
+	src, _ := os.ReadFile(out)
+
+	if n := strings.Count(string(src), "GroupSeparator"); n != 2 || !strings.Contains(string(src), "\"de-ch\"") {
+
+		t.Errorf("Expected: de-ch and tr but received: %s testing run -locales", src)
+	}
+}
+
+// Test the checked in table is reproduced by the go:generate line
+func TestRunTable(t *testing.T) {
+
+	var (
+		stderr bytes.Buffer
+		out    = filepath.Join(t.TempDir(), "cldr.go")
+	)
+
+	source, err := os.ReadFile(filepath.Join("..", "..", "formatter.go"))
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	// Run the generator with the flags of the go:generate line
+	var args []string
+
+	for _, line := range strings.Split(string(source), "\n") {
+
+		if strings.HasPrefix(line, "//go:generate go run ./internal/gencldr ") {
+
+			args = strings.Fields(strings.TrimPrefix(line, "//go:generate go run ./internal/gencldr "))
+		}
+	}
+
+	for i := range args {
+
+		switch args[i] {
+
+		case "$CLDR_NUMBERS":
+
+			args[i] = "testdata"
+
+		case "cldr.go":
+
+			args[i] = out
+		}
+	}
+
+	if status := run(args, &stderr); status != 0 {
+
+		t.Fatalf("Expected: 0 but received: %d (%q) testing run(%q)", status, stderr.String(), args)
+	}
+
+	var (
+		src, _   = os.ReadFile(out)
+		table, _ = os.ReadFile(filepath.Join("..", "..", "cldr.go"))
+	)
+
+	if !bytes.Equal(src, table) {
+
+		t.Errorf("Expected: %s but received: %s testing run(%q)", table, src, args)
+	}
+}
+
+// Test run reports missing flags and data
+func TestRunErrors(t *testing.T) {
+
+	var (
+		inputs   = [][]string{{}, {"-cldr", t.TempDir()}, {"-bogus"}, {"-cldr", "testdata", "-locales", "de,xx"}}
+		expected = []int{2, 1, 2, 1}
+	)
+
+	for i, args := range inputs {
+
+		var stderr bytes.Buffer
+
+		if status := run(args, &stderr); status != expected[i] {
+
+			t.Errorf("Expected: %d but received: %d (%q) testing run(%q)", expected[i], status, stderr.String(), args)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package decimals
+
+import (
+	"strings"
+)
+
+// InterpolateFormatted formats a value between two numbers using the
+// default formatter. See Formatter.InterpolateFormatted.
+func InterpolateFormatted(from, to float64, t float64, precision int) string {
+
+	return DefaultFormatter().InterpolateFormatted(from, to, t, precision)
+}
+
+// InterpolateFormatted formats the value the fraction t of the way from
+// from to to, rounded to the given precision as by FormatFloat, for
+// odometer style animations in terminals and live dashboards. Every frame
+// is padded on the left with spaces to the display width of the wider of
+// the two formatted ends, so the digits and group separators do not shift
+// as the number grows or shrinks. t is clamped to the range 0 to 1, with
+// NaN treated as 0, and the ends are formatted exactly as from and to.
+func (f Formatter) InterpolateFormatted(from, to float64, t float64, precision int) string {
+
+	var x float64
+
+	switch {
+
+	case !(t > 0):
+
+		x = from
+
+	case t >= 1:
+
+		x = to
+
+	default:
+
+		x = from + (to-from)*t
+	}
+
+	var (
+		s     = f.FormatFloat(x, precision)
+		width = DisplayWidth(f.FormatFloat(from, precision))
+	)
+
+	if w := DisplayWidth(f.FormatFloat(to, precision)); w > width {
+
+		width = w
+	}
+
+	if pad := width - DisplayWidth(s); pad > 0 {
+
+		return strings.Repeat(" ", pad) + s
+	}
+
+	return s
+}
@@ -0,0 +1,50 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test InterpolateFormatted with a range of values
+func TestInterpolateFormatted(t *testing.T) {
+
+	type frame struct {
+		from, to, t float64
+		precision   int
+	}
+
+	inputs := []frame{
+		{0, 1000000, 0, 0},
+		{0, 1000000, 0.0005, 0},
+		{0, 1000000, 0.5, 0},
+		{0, 1000000, 1, 0},
+		{0, 1000000, 2, 0},
+		{0, 1000000, math.NaN(), 0},
+		{1234.5, -20, 0.5, 2},
+		{0.1, 0.3, 1, 2},
+		{99.5, 100.5, 0.25, 1},
+	}
+
+	expected := []string{
+		"        0",
+		"      500",
+		"  500,000",
+		"1,000,000",
+		"1,000,000",
+		"        0",
+		"  607.25",
+		"0.30",
+		" 99.8",
+	}
+
+	for i, input := range inputs {
+
+		output := InterpolateFormatted(input.from, input.to, input.t, input.precision)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing InterpolateFormatted(%v, %v, %v, %d)",
+				expected[i], output, input.from, input.to, input.t, input.precision)
+		}
+	}
+}
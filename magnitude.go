@@ -0,0 +1,200 @@
+package decimals
+
+import (
+	"math"
+)
+
+// A Magnitude names a power of ten for compact and long-form formatting.
+type Magnitude struct {
+	Exponent int    // the power of ten, such as 6
+	Symbol   string // the compact suffix, such as "M"
+	Word     string // the long-form word, such as "million"
+}
+
+// A MagnitudeScale is a table of magnitudes in ascending order of exponent.
+type MagnitudeScale []Magnitude
+
+// ShortScale names powers of ten in the short scale used in American and
+// modern British English, where a billion is a thousand million.
+var ShortScale = MagnitudeScale{
+	{3, "K", "thousand"},
+	{6, "M", "million"},
+	{9, "B", "billion"},
+	{12, "T", "trillion"},
+}
+
+// BritishScale names powers of ten in traditional British usage, where a
+// billion is a million million.
+var BritishScale = MagnitudeScale{
+	{3, "K", "thousand"},
+	{6, "M", "million"},
+	{9, "B", "thousand million"},
+	{12, "T", "billion"},
+}
+
+// LongScale names powers of ten in the long scale used in continental
+// Europe, where a thousand million is a milliard.
+var LongScale = MagnitudeScale{
+	{3, "k", "thousand"},
+	{6, "M", "million"},
+	{9, "Md", "milliard"},
+	{12, "B", "billion"},
+}
+
+// SIScale names powers of ten with the metric prefixes from pico to exa,
+// for scaling quantities with units. It includes the unprefixed exponent
+// zero so that values from one to a thousand are left unscaled.
+var SIScale = MagnitudeScale{
+	{-12, "p", "pico"},
+	{-9, "n", "nano"},
+	{-6, "µ", "micro"},
+	{-3, "m", "milli"},
+	{0, "", ""},
+	{3, "k", "kilo"},
+	{6, "M", "mega"},
+	{9, "G", "giga"},
+	{12, "T", "tera"},
+	{15, "P", "peta"},
+	{18, "E", "exa"},
+}
+
+// IndianScale names powers of ten in the South Asian numbering system.
+var IndianScale = MagnitudeScale{
+	{3, "K", "thousand"},
+	{5, "L", "lakh"},
+	{7, "Cr", "crore"},
+}
+
+// FormatCompact formats a float64 in compact notation using the default
+// formatter. See Formatter.FormatCompact.
+func FormatCompact(x float64, precision int) string {
+
+	return DefaultFormatter().FormatCompact(x, precision)
+}
+
+// FormatCompactDecimal formats a Decimal in compact notation using the
+// default formatter. See Formatter.FormatCompactDecimal.
+func FormatCompactDecimal(d Decimal, precision int) string {
+
+	return DefaultFormatter().FormatCompactDecimal(d, precision)
+}
+
+// FormatLong formats a float64 in long-form notation using the default
+// formatter. See Formatter.FormatLong.
+func FormatLong(x float64, precision int) string {
+
+	return DefaultFormatter().FormatLong(x, precision)
+}
+
+// FormatCompact formats a float64 in compact notation, scaled to the
+// largest magnitude in the formatter's scale not greater than the number
+// and followed by the magnitude's symbol, such as "1.2M". Numbers smaller
+// than every magnitude are formatted as by FormatFloat.
+func (f Formatter) FormatCompact(x float64, precision int) string {
+
+	if p, ok := f.placeholder(x); ok {
+
+		return p
+	}
+
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+
+		return f.applyTemplate(formatSpecial(x))
+	}
+
+	d, _ := DecimalFromFloat(x)
+
+	return f.FormatCompactDecimal(d, precision)
+}
+
+// FormatCompactDecimal formats a Decimal in compact notation as
+// FormatCompact does, scaling it exactly so that numbers of any length
+// keep their digits.
+func (f Formatter) FormatCompactDecimal(d Decimal, precision int) string {
+
+	r, m := f.scaleDecimalMagnitude(d, precision)
+
+	if m == nil {
+
+		return f.applyTemplate(f.formatDecimal(r, precision))
+	}
+
+	return f.applyTemplate(f.formatDecimal(r, precision) + m.Symbol)
+}
+
+// FormatLong formats a float64 in long-form notation, scaled as for
+// FormatCompact and followed by the magnitude's word, such as
+// "1.2 million" or "3.5 crore".
+func (f Formatter) FormatLong(x float64, precision int) string {
+
+	if p, ok := f.placeholder(x); ok {
+
+		return p
+	}
+
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+
+		return f.applyTemplate(formatSpecial(x))
+	}
+
+	r, m := f.scaleMagnitude(x, precision)
+
+	if m == nil {
+
+		return f.applyTemplate(f.formatDecimal(r, precision))
+	}
+
+	return f.applyTemplate(f.formatDecimal(r, precision) + " " + m.Word)
+}
+
+// scaleMagnitude scales x to the largest magnitude in the formatter's scale
+// that is not greater than x once rounded, and returns the rounded result
+// and the magnitude. The magnitude is nil if none apply. x must be finite.
+func (f Formatter) scaleMagnitude(x float64, precision int) (Decimal, *Magnitude) {
+
+	d, _ := DecimalFromFloat(x)
+
+	return f.scaleDecimalMagnitude(d, precision)
+}
+
+// scaleDecimalMagnitude scales d as scaleMagnitude does for floats.
+func (f Formatter) scaleDecimalMagnitude(d Decimal, precision int) (Decimal, *Magnitude) {
+
+	var (
+		scale MagnitudeScale = f.Magnitudes
+		i     int            = -1
+		exp   int
+	)
+
+	if scale == nil {
+
+		scale = ShortScale
+	}
+
+	// Find the largest magnitude not greater than d
+	for i+1 < len(scale) && d.Sign() != 0 && len(d.digits)+d.exponent > scale[i+1].Exponent {
+
+		i++
+		exp = scale[i].Exponent
+	}
+
+	// Scale d exactly by moving its exponent
+	r := Decimal{d.negative, d.digits, d.exponent - exp}.Round(precision, HalfUp)
+
+	// Move up a magnitude if rounding carried into the next one, for
+	// example when 999,999 would otherwise become "1,000.0K"
+	if i+1 < len(scale) && r.Sign() != 0 &&
+		len(r.digits)+r.exponent > scale[i+1].Exponent-exp {
+
+		i++
+		exp = scale[i].Exponent
+		r = Decimal{d.negative, d.digits, d.exponent - exp}.Round(precision, HalfUp)
+	}
+
+	if i < 0 {
+
+		return r, nil
+	}
+
+	return r, &scale[i]
+}
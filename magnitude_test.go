@@ -0,0 +1,105 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatCompact with a range of values
+func TestFormatCompact(t *testing.T) {
+
+	inputs := []float64{950, 1234, -1234, 999999, 1500000, 2345678901, 12e12, 5e15}
+
+	expected := []string{
+		"950.0",
+		"1.2K",
+		"-1.2K",
+		"1.0M",
+		"1.5M",
+		"2.3B",
+		"12.0T",
+		"5,000.0T",
+	}
+
+	for i, n := range inputs {
+
+		output := FormatCompact(n, 1)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatCompact(%v, 1)",
+				expected[i], output, n)
+		}
+	}
+}
+
+// Test FormatLong with each magnitude scale
+func TestFormatLong(t *testing.T) {
+
+	scales := []MagnitudeScale{ShortScale, BritishScale, LongScale, IndianScale}
+
+	inputs := []float64{1500, 2500000, 3400000000, 1.2e12}
+
+	expected := []string{
+		"1.5 thousand", "2.5 million", "3.4 billion", "1.2 trillion",
+		"1.5 thousand", "2.5 million", "3.4 thousand million", "1.2 billion",
+		"1.5 thousand", "2.5 million", "3.4 milliard", "1.2 billion",
+		"1.5 thousand", "25.0 lakh", "340.0 crore", "120,000.0 crore",
+	}
+
+	for i, scale := range scales {
+
+		f := Formatter{GroupSeparator: ",", Magnitudes: scale}
+
+		for j, n := range inputs {
+
+			output := f.FormatLong(n, 1)
+			index := (i * len(inputs)) + j
+
+			if output != expected[index] {
+
+				t.Errorf("Expected: %q but received: %q testing FormatLong(%v)",
+					expected[index], output, n)
+			}
+		}
+	}
+}
+
+// Test locale presets select their magnitude scale
+func TestFormatCompactLocale(t *testing.T) {
+
+	f, _ := NewFormatter("de-DE")
+
+	if output := f.FormatLong(3400000000, 1); output != "3,4 milliard" {
+
+		t.Errorf("Expected: %q but received: %q testing FormatLong for de-DE",
+			"3,4 milliard", output)
+	}
+}
+
+// Test FormatCompactDecimal scales numbers of any length exactly
+func TestFormatCompactDecimal(t *testing.T) {
+
+	inputs := []string{"0", "999.95", "999999.5", "-12345678901234567.89", "98765432109876543210"}
+
+	expected := []string{"0.0", "1.0K", "1.0M", "-12,345.7T", "98,765,432.1T"}
+
+	for i, s := range inputs {
+
+		d, _ := ParseDecimal(s, ParseStrict)
+
+		if output := FormatCompactDecimal(d, 1); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatCompactDecimal(%s, 1)",
+				expected[i], output, s)
+		}
+
+		if x := d.Float64(); math.Abs(x) < 1e15 {
+
+			if output := FormatCompact(x, 1); output != expected[i] {
+
+				t.Errorf("Expected: %q but received: %q testing FormatCompact(%v, 1)", expected[i], output, x)
+			}
+		}
+	}
+}
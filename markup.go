@@ -0,0 +1,78 @@
+package decimals
+
+import (
+	"html"
+	"strings"
+)
+
+// MarkdownRightAlign is the cell of the delimiter row of a Markdown table
+// that aligns a column of numbers to the right, as in "| Item | ---: |".
+const MarkdownRightAlign = "---:"
+
+// markdownEscaper escapes the characters that would end a Markdown table
+// cell or escape the character after them.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`)
+
+// FormatHTML formats a float64 rounded to the given precision as HTML with
+// each part of the number in a span whose class names it, so that web
+// templates can style the parts, such as the fraction, without parsing the
+// number on the client:
+//
+//	<span class="number"><span class="int">1,234</span><span class="frac">.56</span></span>
+//
+// The parts are those of FormatParts, in the classes "prefix", "sign",
+// "int", "frac", "sign" again for a sign marker after the digits, and
+// "suffix", and only parts that are not empty are written. The fraction
+// includes the decimal separator. The text is escaped. It is configured by
+// the options as FormatFloatOpt is, except that the width and the sign
+// options are ignored.
+func FormatHTML(x float64, precision int, opts ...Option) string {
+
+	o := applyOptions(opts)
+	f := o.formatter
+
+	if !o.spec.Grouping {
+
+		f.GroupSeparator = ""
+	}
+
+	var (
+		b     strings.Builder
+		parts = f.formatParts(x, precision, o.spec.Mode)
+	)
+
+	b.WriteString(`<span class="number">`)
+
+	for _, part := range []struct{ class, text string }{
+		{"prefix", parts.Prefix},
+		{"sign", parts.Sign},
+		{"int", parts.Integer},
+		{"frac", parts.DecimalSeparator + parts.Fraction},
+		{"sign", parts.SignSuffix},
+		{"suffix", parts.Suffix},
+	} {
+
+		if part.text != "" {
+
+			b.WriteString(`<span class="` + part.class + `">` + html.EscapeString(part.text) + `</span>`)
+		}
+	}
+
+	b.WriteString(`</span>`)
+
+	return b.String()
+}
+
+// FormatMarkdownCell formats a float64 rounded to the given precision as
+// the text of a Markdown table cell, configured by the options as
+// FormatFloatOpt is, with pipes and backslashes escaped so that they do
+// not end the cell. With WithWidth the number is padded on the left, so
+// that a column of numbers is aligned to the right in the Markdown source
+// as well as when the column is aligned with MarkdownRightAlign.
+func FormatMarkdownCell(x float64, precision int, opts ...Option) string {
+
+	o := applyOptions(opts)
+	o.spec.Precision = precision
+
+	return markdownEscaper.Replace(o.formatter.FormatWithSpec(x, o.spec))
+}
@@ -0,0 +1,64 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatHTML marks up each part of formatted numbers
+func TestFormatHTML(t *testing.T) {
+
+	inputs := []string{
+		FormatHTML(1234.5612, 2),
+		FormatHTML(-1234.5, 0),
+		FormatHTML(1234.5, 1, WithoutGrouping()),
+		FormatHTML(2.5, 0, WithMode(HalfEven)),
+		FormatHTML(-1234.5, 2, WithFormatter(Formatter{GroupSeparator: ".", DecimalSeparator: ",", SignStyle: Parentheses, Template: "{} €"})),
+		FormatHTML(99, 0, WithTemplate("<{}>")),
+		FormatHTML(math.Inf(-1), 2),
+	}
+
+	expected := []string{
+		`<span class="number"><span class="int">1,234</span><span class="frac">.56</span></span>`,
+		`<span class="number"><span class="sign">-</span><span class="int">1,235</span></span>`,
+		`<span class="number"><span class="int">1234</span><span class="frac">.5</span></span>`,
+		`<span class="number"><span class="int">2</span></span>`,
+		`<span class="number"><span class="sign">(</span><span class="int">1.234</span><span class="frac">,50</span><span class="sign">)</span><span class="suffix"> €</span></span>`,
+		`<span class="number"><span class="prefix">&lt;</span><span class="int">99</span><span class="suffix">&gt;</span></span>`,
+		`<span class="number"><span class="sign">-</span><span class="int">Inf</span></span>`,
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatHTML", expected[i], output)
+		}
+	}
+}
+
+// Test FormatMarkdownCell escapes and pads table cells
+func TestFormatMarkdownCell(t *testing.T) {
+
+	inputs := []string{
+		FormatMarkdownCell(1234.5, 2),
+		FormatMarkdownCell(-1234.5, 0, WithWidth(8)),
+		FormatMarkdownCell(1234.5, 1, WithTemplate("|{}|")),
+		FormatMarkdownCell(5, 0, WithTemplate(`\{}`), WithPrecision(3)),
+	}
+
+	expected := []string{
+		"1,234.50",
+		"  -1,235",
+		`\|1,234.5\|`,
+		`\\5`,
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatMarkdownCell", expected[i], output)
+		}
+	}
+}
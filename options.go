@@ -0,0 +1,189 @@
+package decimals
+
+// An Option configures a call to one of the Opt formatting functions, such
+// as FormatFloatOpt. New formatting settings are added as options rather
+// than as new function variants.
+type Option func(*options)
+
+// options collects the settings of a formatting call
+type options struct {
+	formatter Formatter
+	spec      FormatSpec
+	rangeSep  string
+}
+
+// WithPrecision sets the precision, interpreted as for RoundFloat. The
+// default precision is zero.
+func WithPrecision(precision int) Option {
+
+	return func(o *options) {
+
+		o.spec.Precision = precision
+	}
+}
+
+// WithMode sets the rounding mode. The default mode is HalfUp.
+func WithMode(mode RoundingMode) Option {
+
+	return func(o *options) {
+
+		o.spec.Mode = mode
+	}
+}
+
+// WithSeparator sets the separator for thousands. An empty separator
+// turns grouping off.
+func WithSeparator(sep string) Option {
+
+	return func(o *options) {
+
+		o.formatter.GroupSeparator = sep
+	}
+}
+
+// WithoutGrouping turns grouping off, so that numbers are formatted without
+// a separator for thousands whatever the formatter or locale.
+func WithoutGrouping() Option {
+
+	return func(o *options) {
+
+		o.spec.Grouping = false
+	}
+}
+
+// WithGroupingThreshold sets the number of integer digits a number needs
+// before it is grouped, as for Formatter.GroupingThreshold.
+func WithGroupingThreshold(n int) Option {
+
+	return func(o *options) {
+
+		o.formatter.GroupingThreshold = n
+	}
+}
+
+// WithMinIntegerDigits sets the minimum number of integer digits, as for
+// Formatter.MinIntegerDigits.
+func WithMinIntegerDigits(n int) Option {
+
+	return func(o *options) {
+
+		o.formatter.MinIntegerDigits = n
+	}
+}
+
+// WithDigits sets the runes used for the digits zero to nine, as for
+// Formatter.Digits.
+func WithDigits(digits DigitSet) Option {
+
+	return func(o *options) {
+
+		o.formatter.Digits = digits
+	}
+}
+
+// WithPrecisionPolicy sets how precisions beyond the digits of a float64
+// are treated, as for Formatter.PrecisionPolicy.
+func WithPrecisionPolicy(p PrecisionPolicy) Option {
+
+	return func(o *options) {
+
+		o.formatter.PrecisionPolicy = p
+	}
+}
+
+// WithDecimalSeparator sets the separator between the integer and
+// fractional parts.
+func WithDecimalSeparator(sep string) Option {
+
+	return func(o *options) {
+
+		o.formatter.DecimalSeparator = sep
+	}
+}
+
+// WithLocale uses the separators of the preset formatter for a locale, as
+// returned by NewFormatter. An unknown locale leaves the formatter
+// unchanged, so validate user supplied locales with NewFormatter and pass
+// the result to WithFormatter instead.
+func WithLocale(locale string) Option {
+
+	return func(o *options) {
+
+		if f, err := NewFormatter(locale); err == nil {
+
+			o.formatter = f
+		}
+	}
+}
+
+// WithFormatter uses the given formatter in place of the default formatter.
+func WithFormatter(f Formatter) Option {
+
+	return func(o *options) {
+
+		o.formatter = f
+	}
+}
+
+// WithTemplate sets the template placed around the formatted number, as
+// for Formatter.Template.
+func WithTemplate(template string) Option {
+
+	return func(o *options) {
+
+		o.formatter.Template = template
+	}
+}
+
+// WithSign sets when a sign is shown. The default is SignNegative.
+func WithSign(sign SignMode) Option {
+
+	return func(o *options) {
+
+		o.spec.Sign = sign
+	}
+}
+
+// WithWidth sets the minimum width of the output in columns, as measured
+// by DisplayWidth, which is padded with spaces on the left.
+func WithWidth(width int) Option {
+
+	return func(o *options) {
+
+		o.spec.Width = width
+	}
+}
+
+// WithRangeSeparator sets the separator between the bounds of a range
+// formatted by FormatRange. The default is an en dash.
+func WithRangeSeparator(sep string) Option {
+
+	return func(o *options) {
+
+		o.rangeSep = sep
+	}
+}
+
+// FormatFloatOpt converts a float64 to a formatted string configured by
+// options. Without options it formats as FormatFloat(x, 0) does, using
+// the default formatter, and options are applied in order so later options
+// override earlier ones.
+func FormatFloatOpt(x float64, opts ...Option) string {
+
+	o := applyOptions(opts)
+
+	return o.formatter.FormatWithSpec(x, o.spec)
+}
+
+// applyOptions returns the settings configured by the options.
+func applyOptions(opts []Option) options {
+
+	o := options{DefaultFormatter(), FormatSpec{Grouping: true}, "–"}
+
+	for _, opt := range opts {
+
+		opt(&o)
+	}
+
+	return o
+}
@@ -0,0 +1,56 @@
+package decimals
+
+import (
+	"testing"
+)
+
+// Test FormatFloatOpt with combinations of options
+func TestFormatFloatOpt(t *testing.T) {
+
+	inputs := []string{
+		FormatFloatOpt(1234.5678),
+		FormatFloatOpt(1234.5678, WithPrecision(2)),
+		FormatFloatOpt(2.665, WithPrecision(2), WithMode(HalfEven)),
+		FormatFloatOpt(1234.5678, WithPrecision(1), WithSeparator("")),
+		FormatFloatOpt(1234.5678, WithPrecision(2), WithLocale("de-DE")),
+		FormatFloatOpt(1234.5678, WithPrecision(2), WithLocale("xx-XX")),
+		FormatFloatOpt(1234.5678, WithSeparator(" "), WithDecimalSeparator(","), WithPrecision(1)),
+		FormatFloatOpt(5, WithTemplate("{} USD"), WithSign(SignAlways), WithWidth(8)),
+		FormatFloatOpt(5, WithFormatter(UnderscoreFormatter), WithPrecision(-3)),
+		FormatFloatOpt(1e6, WithLocale("de-DE"), WithSeparator("'")),
+		FormatFloatOpt(1e6, WithoutGrouping(), WithLocale("de-DE")),
+		FormatFloatOpt(1234, WithGroupingThreshold(5)),
+		FormatFloatOpt(12345, WithGroupingThreshold(5)),
+		FormatFloatOpt(7.5, WithMinIntegerDigits(3), WithPrecision(2)),
+		FormatFloatOpt(0.25, WithMinIntegerDigits(-1), WithPrecision(2)),
+		FormatFloatOpt(0.1, WithPrecisionPolicy(PrecisionCap), WithPrecision(20)),
+	}
+
+	expected := []string{
+		"1,235",
+		"1,234.57",
+		"2.66",
+		"1234.6",
+		"1.234,57",
+		"1,234.57",
+		"1 234,6",
+		"  +5 USD",
+		"0",
+		"1'000'000",
+		"1000000",
+		"1234",
+		"12,345",
+		"007.50",
+		".25",
+		"0.10000000000000000",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatFloatOpt",
+				expected[i], output)
+		}
+	}
+}
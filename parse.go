@@ -0,0 +1,502 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrSyntax indicates that a value does not have the right syntax for a number.
+var ErrSyntax = errors.New("invalid syntax")
+
+// ErrRange indicates that a value is out of range for the target type.
+var ErrRange = errors.New("value out of range")
+
+// A NumError records a failed conversion.
+type NumError struct {
+	Func string // the failing function (ParseFloat, ...)
+	Num  string // the input
+	Err  error  // the reason the conversion failed (ErrSyntax, ErrRange, ...)
+}
+
+func (e *NumError) Error() string {
+
+	return "decimals." + e.Func + ": parsing " + strconv.Quote(e.Num) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the reason the conversion failed.
+func (e *NumError) Unwrap() error {
+
+	return e.Err
+}
+
+// ParseFlag is a set of flags enabling relaxed input forms when parsing.
+type ParseFlag uint
+
+const (
+	// ParseExponent accepts exponent notation such as "1.2e3" and "1.2E+03".
+	ParseExponent ParseFlag = 1 << iota
+
+	// ParsePlus accepts a leading plus sign.
+	ParsePlus
+
+	// ParseSpace accepts leading and trailing whitespace.
+	ParseSpace
+
+	// ParseUnderscore accepts underscores between digits, as in "1_000_000".
+	ParseUnderscore
+
+	// ParseIgnoreCase accepts magnitude suffixes in any case in
+	// ParseCompact, as in "1.5m" for 1.5 million.
+	ParseIgnoreCase
+
+	// ParseTimesTen accepts a power of ten written after a multiplication
+	// sign, as in the output of FormatScientific and FormatScientificASCII:
+	// "1.23 × 10⁶", "1.23 x 10^6" or "1.23*10^-3".
+	ParseTimesTen
+)
+
+const (
+	// ParseStrict accepts only the forms produced by the Format functions.
+	ParseStrict ParseFlag = 0
+
+	// ParseLenient accepts every relaxed input form.
+	ParseLenient = ParseExponent | ParsePlus | ParseSpace | ParseUnderscore | ParseIgnoreCase | ParseTimesTen
+)
+
+// Exponents of the magnitude suffixes accepted by ParseCompact
+var compactSuffixes = map[string]int{
+	"K": 3, "k": 3, "M": 6, "B": 9, "G": 9, "T": 12, "P": 15, "E": 18,
+}
+
+// ParseFloat converts a formatted string into a float64. It accepts the
+// output of FormatFloat and FormatInt: an optional minus sign, digits
+// optionally grouped into thousands with commas, and an optional decimal
+// point followed by digits. The flags enable further relaxed input forms,
+// so strict callers can pass ParseStrict and lenient callers ParseLenient.
+// If the number is too large for a float64 the error wraps ErrRange and
+// the returned value is the signed infinity, as with strconv.ParseFloat.
+func ParseFloat(s string, flags ParseFlag) (float64, error) {
+
+	clean, ok := cleanNumber(s, flags)
+
+	if !ok {
+
+		return 0, &NumError{"ParseFloat", s, ErrSyntax}
+	}
+
+	// The cleaned string is valid so strconv can only fail on range
+	r, err := strconv.ParseFloat(clean, 64)
+
+	if err != nil {
+
+		return r, &NumError{"ParseFloat", s, ErrRange}
+	}
+
+	return r, nil
+}
+
+// ParseCompact converts a number in compact notation, such as "1.2K" or
+// "3.4M", into a float64, reversing FormatCompact so configuration values
+// like "512K" can be accepted. The number is parsed as by ParseFloat with
+// the same flags and may be followed by one of the suffixes K, M, B and T
+// for the short scale, or k, M, G, T, P and E for SI prefixes. Suffixes
+// are case-sensitive, so "m" is not accepted, unless ParseIgnoreCase is
+// set. With ParseSpace a space may separate the number and its suffix.
+// The number is scaled exactly before it is converted to a float64.
+func ParseCompact(s string, flags ParseFlag) (float64, error) {
+
+	var (
+		num      string = s
+		exponent int
+	)
+
+	if flags&ParseSpace != 0 {
+
+		num = strings.TrimSpace(num)
+	}
+
+	// Remove the suffix and find its exponent
+	if n := len(num); n > 0 {
+
+		suffix := num[n-1:]
+
+		if flags&ParseIgnoreCase != 0 {
+
+			suffix = strings.ToUpper(suffix)
+		}
+
+		if e, ok := compactSuffixes[suffix]; ok {
+
+			num, exponent = num[:n-1], e
+		}
+	}
+
+	if flags&ParseSpace != 0 {
+
+		num = strings.TrimRight(num, " \t")
+	}
+
+	d, err := ParseDecimal(num, flags)
+
+	if err != nil {
+
+		return 0, &NumError{"ParseCompact", s, errors.Unwrap(err)}
+	}
+
+	d.exponent += exponent
+	r := d.Float64()
+
+	if math.IsInf(r, 0) {
+
+		return r, &NumError{"ParseCompact", s, ErrRange}
+	}
+
+	return r, nil
+}
+
+// cleanNumber validates s against the number syntax enabled by flags and
+// returns it stripped of separators and whitespace, ready for strconv.
+func cleanNumber(s string, flags ParseFlag) (string, bool) {
+
+	var (
+		buf        []byte
+		i          int
+		intDigits  int
+		fracDigits int
+	)
+
+	if flags&ParseSpace != 0 {
+
+		s = strings.TrimSpace(s)
+	}
+
+	s = asciiDigits(s)
+	buf = make([]byte, 0, len(s))
+
+	// Copy the sign
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+
+		if s[i] == '+' && flags&ParsePlus == 0 {
+
+			return "", false
+		}
+
+		buf = append(buf, s[i])
+		i++
+	}
+
+	// Copy the integer part
+	start := i
+	n := len(buf)
+	buf, i = scanDigits(s, i, buf, flags)
+	intDigits = len(buf) - n
+
+	// If the integer part is grouped the leading group must be one to three
+	// plain digits, followed by groups of exactly three digits
+	if i < len(s) && s[i] == ',' {
+
+		if intDigits == 0 || intDigits > 3 || strings.IndexByte(s[start:i], '_') >= 0 {
+
+			return "", false
+		}
+
+		for i < len(s) && s[i] == ',' {
+
+			if i+4 > len(s) || !isDigits(s[i+1:i+4]) {
+
+				return "", false
+			}
+
+			buf = append(buf, s[i+1:i+4]...)
+			i += 4
+		}
+
+		if i < len(s) && isDigit(s[i]) {
+
+			return "", false
+		}
+	}
+
+	// Copy the fractional part
+	if i < len(s) && s[i] == '.' {
+
+		buf = append(buf, '.')
+		n = len(buf)
+		buf, i = scanDigits(s, i+1, buf, flags)
+		fracDigits = len(buf) - n
+	}
+
+	if intDigits+fracDigits == 0 {
+
+		return "", false
+	}
+
+	// Copy the exponent
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+
+		if flags&ParseExponent == 0 {
+
+			return "", false
+		}
+
+		buf = append(buf, 'e')
+		i++
+
+		if i < len(s) && (s[i] == '-' || s[i] == '+') {
+
+			buf = append(buf, s[i])
+			i++
+		}
+
+		n = len(buf)
+		buf, i = scanDigits(s, i, buf, flags)
+
+		if len(buf) == n {
+
+			return "", false
+		}
+
+	} else if i < len(s) && flags&ParseTimesTen != 0 {
+
+		var ok bool
+
+		if buf, i, ok = scanTimesTen(s, i, buf); !ok {
+
+			return "", false
+		}
+	}
+
+	// Anything left over is not part of a number
+	if i != len(s) {
+
+		return "", false
+	}
+
+	return string(buf), true
+}
+
+// scanDigits appends the run of digits starting at s[i] to buf and returns
+// the index following the run. Underscores are skipped if they are allowed
+// by flags and sit between two digits.
+func scanDigits(s string, i int, buf []byte, flags ParseFlag) ([]byte, int) {
+
+	for start := i; i < len(s); i++ {
+
+		if isDigit(s[i]) {
+
+			buf = append(buf, s[i])
+			continue
+		}
+
+		if s[i] == '_' && flags&ParseUnderscore != 0 &&
+			i > start && i+1 < len(s) && isDigit(s[i+1]) {
+
+			continue
+		}
+
+		break
+	}
+
+	return buf, i
+}
+
+// scanTimesTen appends the exponent of a power of ten written as "× 10⁶",
+// "x 10^6" or "*10^-3" at s[i] to buf in e notation, and returns the index
+// following it. It reports false if s[i] does not start a power of ten.
+func scanTimesTen(s string, i int, buf []byte) ([]byte, int, bool) {
+
+	i = skipSpaces(s, i)
+
+	// Skip the multiplication sign and the base
+	switch {
+
+	case strings.HasPrefix(s[i:], "×"):
+
+		i += len("×")
+
+	case i < len(s) && (s[i] == 'x' || s[i] == 'X' || s[i] == '*'):
+
+		i++
+
+	default:
+
+		return buf, i, false
+	}
+
+	i = skipSpaces(s, i)
+
+	if !strings.HasPrefix(s[i:], "10") {
+
+		return buf, i, false
+	}
+
+	i += 2
+	buf = append(buf, 'e')
+	n := len(buf)
+
+	// Copy the exponent, after a caret or in superscript digits
+	if i < len(s) && s[i] == '^' {
+
+		i++
+
+		if i < len(s) && (s[i] == '-' || s[i] == '+') {
+
+			buf = append(buf, s[i])
+			i++
+			n++
+		}
+
+		buf, i = scanDigits(s, i, buf, 0)
+
+		return buf, i, len(buf) > n
+	}
+
+	if strings.HasPrefix(s[i:], SuperscriptMinus) {
+
+		buf = append(buf, '-')
+		i += len(SuperscriptMinus)
+		n++
+	}
+
+	for matched := true; matched; {
+
+		matched = false
+
+		for digit, sup := range superscriptDigits {
+
+			if strings.HasPrefix(s[i:], sup) {
+
+				buf = append(buf, byte('0'+digit))
+				i += len(sup)
+				matched = true
+				break
+			}
+		}
+	}
+
+	return buf, i, len(buf) > n
+}
+
+// skipSpaces returns the index of the first character at or after s[i]
+// that is not a space, a no-break space or a thin space.
+func skipSpaces(s string, i int) int {
+
+	for {
+
+		switch {
+
+		case strings.HasPrefix(s[i:], " "):
+
+			i++
+
+		case strings.HasPrefix(s[i:], NoBreakSpace):
+
+			i += len(NoBreakSpace)
+
+		case strings.HasPrefix(s[i:], NarrowNoBreakSpace):
+
+			i += len(NarrowNoBreakSpace)
+
+		case strings.HasPrefix(s[i:], "\u2009"):
+
+			i += len("\u2009")
+
+		default:
+
+			return i
+		}
+	}
+}
+
+// asciiDigits replaces the decimal digits of any script in s, the Unicode
+// category Nd, with ASCII digits, and the Arabic decimal and thousands
+// separators with a point and a comma, so that numbers formatted with a
+// DigitSet can be parsed.
+func asciiDigits(s string) string {
+
+	// Most input is ASCII and is returned without allocating
+	for i := 0; i < len(s); i++ {
+
+		if s[i] >= utf8.RuneSelf {
+
+			return strings.Map(asciiDigit, s)
+		}
+	}
+
+	return s
+}
+
+// asciiDigit maps a rune for asciiDigits.
+func asciiDigit(r rune) rune {
+
+	switch r {
+
+	case '\u066B':
+
+		return '.'
+
+	case '\u066C':
+
+		return ','
+	}
+
+	if v, ok := digitValue(r); ok {
+
+		return '0' + rune(v)
+	}
+
+	return r
+}
+
+// digitValue returns the value of a decimal digit in any script. Unicode
+// encodes the digits of each script as a run of ten from zero, so the value
+// is the offset from the start of the run.
+func digitValue(r rune) (int, bool) {
+
+	if r < utf8.RuneSelf {
+
+		return int(r - '0'), r >= '0' && r <= '9'
+	}
+
+	for _, rg := range unicode.Nd.R16 {
+
+		if r >= rune(rg.Lo) && r <= rune(rg.Hi) {
+
+			return int(r-rune(rg.Lo)) % 10, true
+		}
+	}
+
+	for _, rg := range unicode.Nd.R32 {
+
+		if r >= rune(rg.Lo) && r <= rune(rg.Hi) {
+
+			return int(r-rune(rg.Lo)) % 10, true
+		}
+	}
+
+	return 0, false
+}
+
+// isDigit reports whether c is an ASCII decimal digit.
+func isDigit(c byte) bool {
+
+	return c >= '0' && c <= '9'
+}
+
+// isDigits reports whether s is made up only of ASCII decimal digits.
+func isDigits(s string) bool {
+
+	for i := 0; i < len(s); i++ {
+
+		if !isDigit(s[i]) {
+
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,338 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"unicode"
+)
+
+// Test ParseFloat with a range of valid inputs
+func TestParseFloat(t *testing.T) {
+
+	inputs := []string{
+		"0",
+		"5",
+		"-5",
+		"5.5",
+		".5",
+		"5.",
+		"1,000",
+		"-1,000",
+		"5,555,555.123",
+		"1234567.5",
+		"1.2e3",
+		"1.2E+03",
+		"-1.2e-3",
+		"+5",
+		" 5.5 ",
+		"1_000_000",
+		"1_000.000_1",
+	}
+
+	flags := []ParseFlag{
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseExponent,
+		ParseExponent,
+		ParseExponent,
+		ParsePlus,
+		ParseSpace,
+		ParseUnderscore,
+		ParseUnderscore,
+	}
+
+	expected := []float64{
+		0,
+		5,
+		-5,
+		5.5,
+		0.5,
+		5,
+		1000,
+		-1000,
+		5555555.123,
+		1234567.5,
+		1200,
+		1200,
+		-0.0012,
+		5,
+		5.5,
+		1000000,
+		1000.0001,
+	}
+
+	for i, s := range inputs {
+
+		output, err := ParseFloat(s, flags[i])
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v (%v) testing ParseFloat(%q)",
+				expected[i], output, err, s)
+		}
+	}
+}
+
+// Test ParseFloat rejects malformed input and disabled forms
+func TestParseFloatSyntax(t *testing.T) {
+
+	inputs := []string{
+		"",
+		"-",
+		".",
+		"abc",
+		"5a",
+		"1,00",
+		"1,0000",
+		"1000,000",
+		",100",
+		"1,000.000,1",
+		"1.2e3",
+		"+5",
+		" 5",
+		"1_000",
+		"1__000",
+		"_1000",
+		"1000_",
+		"1_000,000",
+		"1.2e",
+		"NaN",
+		"Inf",
+	}
+
+	for _, s := range inputs {
+
+		_, err := ParseFloat(s, ParseStrict)
+
+		if !errors.Is(err, ErrSyntax) {
+
+			t.Errorf("Expected: ErrSyntax but received: %v testing ParseFloat(%q)",
+				err, s)
+		}
+	}
+
+	for _, s := range []string{"1__000", "_1000", "1000_", "1_000,000", "1e"} {
+
+		_, err := ParseFloat(s, ParseLenient)
+
+		if !errors.Is(err, ErrSyntax) {
+
+			t.Errorf("Expected: ErrSyntax but received: %v testing lenient ParseFloat(%q)",
+				err, s)
+		}
+	}
+}
+
+// Test ParseFloat reports values outside the float64 range
+func TestParseFloatRange(t *testing.T) {
+
+	output, err := ParseFloat("-1e400", ParseExponent)
+
+	if !errors.Is(err, ErrRange) || !math.IsInf(output, -1) {
+
+		t.Errorf("Expected: -Inf and ErrRange but received: %v (%v) testing ParseFloat",
+			output, err)
+	}
+}
+
+// Test ParseFloat accepts the output of FormatFloat
+func TestParseFloatRoundTrip(t *testing.T) {
+
+	inputs := []float64{5555555.123456789, -5555555.123456789, 0.5, -42}
+
+	for _, n := range inputs {
+
+		s := FormatFloat(n, 9)
+		output, err := ParseFloat(s, ParseStrict)
+
+		if err != nil || output != n {
+
+			t.Errorf("Expected: %v but received: %v (%v) parsing %q",
+				n, output, err, s)
+		}
+	}
+}
+
+// Test ParseCompact with suffixes and flags
+func TestParseCompact(t *testing.T) {
+
+	inputs := []string{
+		"512",
+		"512K",
+		"1.2k",
+		"3.4M",
+		"-1.5B",
+		"2G",
+		"1T",
+		"1,500K",
+		"1.5m",
+		"1.5 M",
+		"0.000001E",
+	}
+
+	flags := []ParseFlag{
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+		ParseIgnoreCase,
+		ParseSpace,
+		ParseStrict,
+	}
+
+	expected := []float64{512, 512000, 1200, 3400000, -1500000000, 2e9, 1e12, 1500000, 1500000, 1500000, 1e12}
+
+	for i, s := range inputs {
+
+		output, err := ParseCompact(s, flags[i])
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v (%v) testing ParseCompact(%q)",
+				expected[i], output, err, s)
+		}
+	}
+
+	for _, s := range []string{"", "K", "1.5m", "1.5 M", "1.5X", "1.5KK"} {
+
+		if _, err := ParseCompact(s, ParseStrict); !errors.Is(err, ErrSyntax) {
+
+			t.Errorf("Expected: ErrSyntax but received: %v testing ParseCompact(%q)", err, s)
+		}
+	}
+
+	if _, err := ParseCompact("1e300E", ParseExponent); !errors.Is(err, ErrRange) {
+
+		t.Errorf("Expected: ErrRange but received: %v testing ParseCompact", err)
+	}
+
+	// Compact output parses back to the rounded value
+	if output, err := ParseCompact(FormatCompact(1234567, 1), ParseStrict); err != nil || output != 1200000 {
+
+		t.Errorf("Expected: 1200000 but received: %v (%v) testing ParseCompact(FormatCompact)",
+			output, err)
+	}
+}
+
+// Test ParseTimesTen accepts the output of FormatScientific
+func TestParseTimesTen(t *testing.T) {
+
+	inputs := []string{
+		"1.23 × 10⁶",
+		"1.23 × 10⁻⁴",
+		"-1.00 x 10^1",
+		"1.23*10^-3",
+		"5 X 10^+2",
+		"2\u202F×\u202F10¹²",
+		FormatScientific(-6.02e23, 2),
+		FormatScientificASCII(1.6e-19, 1),
+	}
+
+	expected := []float64{1.23e6, 1.23e-4, -10, 1.23e-3, 500, 2e12, -6.02e23, 1.6e-19}
+
+	for i, s := range inputs {
+
+		output, err := ParseFloat(s, ParseTimesTen)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v (%v) parsing %q", expected[i], output, err, s)
+		}
+	}
+
+	invalid := []string{"1.23 × 10", "1.23 × 11⁶", "1.23 × 10^", "1.23 ×", "1.23 × 10⁶x", "× 10⁶"}
+
+	for _, s := range invalid {
+
+		if _, err := ParseFloat(s, ParseTimesTen); err == nil {
+
+			t.Errorf("Expected: error but received: nil parsing %q", s)
+		}
+	}
+
+	if _, err := ParseFloat("1.23 × 10⁶", ParseStrict); err == nil {
+
+		t.Errorf("Expected: error but received: nil parsing %q strictly", "1.23 × 10⁶")
+	}
+}
+
+// Test ParseFloat accepts the decimal digits of any script
+func TestParseNativeDigits(t *testing.T) {
+
+	inputs := []string{
+		"۱۲۳٫۴۵",
+		"-٥٬٥٥٥٫١٢",
+		"১,২৩৪.৫",
+		"๓.๑๔",
+		"१२३",
+		"１２.５",
+		"𝟏𝟐𝟑",
+		SubstituteDigits("9,876.5", ThaiDigits),
+	}
+
+	expected := []float64{123.45, -5555.12, 1234.5, 3.14, 123, 12.5, 123, 9876.5}
+
+	for i, s := range inputs {
+
+		output, err := ParseFloat(s, ParseStrict)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v (%v) parsing %q", expected[i], output, err, s)
+		}
+	}
+
+	if d, err := ParseDecimal("۱۲٫۵۰", ParseStrict); err != nil || d.String() != "12.50" {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing ParseDecimal", "12.50", d, err)
+	}
+
+	if _, err := ParseFloat("۱۲x", ParseStrict); !errors.Is(err, ErrSyntax) {
+
+		t.Errorf("Expected: %v but received: %v parsing %q", ErrSyntax, err, "۱۲x")
+	}
+}
+
+// Test every Unicode decimal digit is found in a run of ten from zero
+func TestDigitValue(t *testing.T) {
+
+	for _, rg := range unicode.Nd.R16 {
+
+		if rg.Stride != 1 || (rg.Hi-rg.Lo+1)%10 != 0 {
+
+			t.Errorf("Expected: runs of ten digits but received: %+v testing digitValue", rg)
+		}
+	}
+
+	for _, rg := range unicode.Nd.R32 {
+
+		if rg.Stride != 1 || (rg.Hi-rg.Lo+1)%10 != 0 {
+
+			t.Errorf("Expected: runs of ten digits but received: %+v testing digitValue", rg)
+		}
+	}
+
+	for _, digits := range []DigitSet{EasternArabicDigits, PersianDigits, DevanagariDigits, BengaliDigits, ThaiDigits} {
+
+		for i, r := range digits {
+
+			if v, ok := digitValue(r); !ok || v != i {
+
+				t.Errorf("Expected: %d but received: %d (%v) testing digitValue(%q)", i, v, ok, r)
+			}
+		}
+	}
+}
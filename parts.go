@@ -0,0 +1,107 @@
+package decimals
+
+import (
+	"strings"
+)
+
+// NumberParts are the components of a formatted number, each formatted
+// with the formatter's separators, sign style, digits and template, so
+// that user interfaces can style them separately, such as by showing the
+// fraction in grey or the cents in superscript. Joined in order they are
+// the string that FormatFloat returns.
+type NumberParts struct {
+	Prefix           string // the text of the template before the number
+	Sign             string // the sign marker before the digits, such as "-"
+	Integer          string // the grouped integer digits, such as "1,234"
+	DecimalSeparator string // the decimal separator, if there is a fraction
+	Fraction         string // the fraction digits, such as "50"
+	SignSuffix       string // the sign marker after the digits, such as ")"
+	Suffix           string // the text of the template after the number
+}
+
+// String joins the parts into the formatted number.
+func (p NumberParts) String() string {
+
+	return p.Prefix + p.Sign + p.Integer + p.DecimalSeparator + p.Fraction + p.SignSuffix + p.Suffix
+}
+
+// FormatParts formats a float64 as FormatFloat does using the default
+// formatter and returns its components. See Formatter.FormatParts.
+func FormatParts(x float64, precision int) NumberParts {
+
+	return DefaultFormatter().FormatParts(x, precision)
+}
+
+// FormatParts formats a float64 rounded half up to the given precision, as
+// FormatFloat does, and returns its components, so that the parts need
+// not be found by splitting the string, which depends on the separators
+// of the locale. NaN and the infinities are returned as the integer part,
+// and the formatter's placeholder for NaN, if it has one, is returned
+// alone as the integer part, as it is returned by FormatFloat.
+func (f Formatter) FormatParts(x float64, precision int) NumberParts {
+
+	return f.formatParts(x, precision, HalfUp)
+}
+
+// formatParts formats the components of a float64 rounded to the given
+// precision using mode.
+func (f Formatter) formatParts(x float64, precision int, mode RoundingMode) NumberParts {
+
+	if p, ok := f.placeholder(x); ok {
+
+		return NumberParts{Integer: p}
+	}
+
+	var (
+		parts    NumberParts
+		negative bool
+	)
+
+	// Round the float as formatFloat does, and take the digits and sign
+	// from the rounded value
+	if d, err := floatDecimal(x); err != nil {
+
+		parts.Integer = strings.TrimPrefix(formatSpecial(x), "-")
+		negative = x < 0
+
+	} else {
+
+		r, places := f.floatLimit().roundSignificant(d, precision, mode)
+
+		if places < 0 {
+
+			places = 0
+		}
+
+		is, fs := r.parts(places)
+		parts.Integer = f.groupInteger(f.padInteger(is, fs != ""), f.GroupSeparator)
+
+		if fs != "" {
+
+			parts.DecimalSeparator = f.decimalSeparator()
+			parts.Fraction = fs
+		}
+
+		negative = r.Sign() < 0
+	}
+
+	parts.Sign, parts.SignSuffix = f.signMarkers(negative)
+
+	if f.Digits != (DigitSet{}) {
+
+		for _, s := range []*string{&parts.Sign, &parts.Integer, &parts.Fraction, &parts.SignSuffix} {
+
+			*s = SubstituteDigits(*s, f.Digits)
+		}
+	}
+
+	// Split the template around the placeholder
+	parts.Prefix = f.Template
+
+	if i := strings.Index(f.Template, TemplatePlaceholder); i >= 0 {
+
+		parts.Prefix, parts.Suffix = f.Template[:i], f.Template[i+len(TemplatePlaceholder):]
+	}
+
+	return parts
+}
@@ -0,0 +1,84 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatParts splits formatted numbers into their components
+func TestFormatParts(t *testing.T) {
+
+	var (
+		plain      = DefaultFormatter()
+		german     = Formatter{GroupSeparator: ".", DecimalSeparator: ","}
+		accounts   = Formatter{GroupSeparator: ",", DecimalSeparator: ".", SignStyle: Parentheses, Template: "${}"}
+		labelled   = Formatter{GroupSeparator: ",", DecimalSeparator: ".", Template: "{} USD", Placeholder: "n/a"}
+		devanagari = Formatter{GroupSeparator: ",", DecimalSeparator: ".", Digits: DevanagariDigits}
+	)
+
+	inputs := []NumberParts{
+		plain.FormatParts(1234.5, 2),
+		plain.FormatParts(-0.004, 2),
+		plain.FormatParts(-1234.5, 0),
+		german.FormatParts(-1234567.891, 2),
+		accounts.FormatParts(-1234.5, 2),
+		labelled.FormatParts(99.999, 2),
+		labelled.FormatParts(math.NaN(), 2),
+		plain.FormatParts(math.Inf(-1), 2),
+		devanagari.FormatParts(1234.5, 1),
+	}
+
+	expected := []NumberParts{
+		{Integer: "1,234", DecimalSeparator: ".", Fraction: "50"},
+		{Integer: "0", DecimalSeparator: ".", Fraction: "00"},
+		{Sign: "-", Integer: "1,235"},
+		{Sign: "-", Integer: "1.234.567", DecimalSeparator: ",", Fraction: "89"},
+		{Prefix: "$", Sign: "(", Integer: "1,234", DecimalSeparator: ".", Fraction: "50", SignSuffix: ")"},
+		{Integer: "100", DecimalSeparator: ".", Fraction: "00", Suffix: " USD"},
+		{Integer: "n/a"},
+		{Sign: "-", Integer: "Inf"},
+		{Integer: "१,२३४", DecimalSeparator: ".", Fraction: "५"},
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %+v but received: %+v testing FormatParts", expected[i], output)
+		}
+	}
+}
+
+// Test the parts of formatted numbers join to the string that FormatFloat
+// returns
+func TestFormatPartsString(t *testing.T) {
+
+	formatters := []Formatter{
+		DefaultFormatter(),
+		{GroupSeparator: NarrowNoBreakSpace, DecimalSeparator: ",", MinIntegerDigits: -1},
+		{GroupSeparator: ",", DecimalSeparator: ".", SignStyle: CreditDebit, Template: "≈{}"},
+		{GroupSeparator: ",", DecimalSeparator: ".", SignStyle: TrailingMinus, Digits: EasternArabicDigits},
+		{MaxSignificantDigits: 3, Template: "{} kg", Placeholder: "-"},
+	}
+
+	values := []float64{0, 0.5, -0.5, -0.004, 1234.5678, -9999.995, 1e21, 5e-10, math.Inf(1), math.NaN()}
+
+	for _, f := range formatters {
+
+		for _, x := range values {
+
+			for p := -2; p <= 3; p++ {
+
+				if output, s := f.FormatParts(x, p).String(), f.FormatFloat(x, p); output != s {
+
+					t.Errorf("Expected: %q but received: %q testing FormatParts(%v, %d).String()", s, output, x, p)
+				}
+			}
+		}
+	}
+
+	if output := FormatParts(-1234.5, 1).String(); output != "-1,234.5" {
+
+		t.Errorf("Expected: %q but received: %q testing FormatParts", "-1,234.5", output)
+	}
+}
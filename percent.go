@@ -0,0 +1,228 @@
+package decimals
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// ExactPercent formats the ratio of numerator to denominator as a percentage
+// rounded to the given precision, using the default formatter's separators.
+// See Formatter.ExactPercent.
+func ExactPercent(numerator, denominator int64, precision int) string {
+
+	return DefaultFormatter().ExactPercent(numerator, denominator, precision)
+}
+
+// ExactPercent formats the ratio of numerator to denominator as a percentage
+// rounded to the given precision, such as "33.33%", with the percent sign
+// placed by the formatter's PercentPattern. The ratio is computed exactly
+// with math/big before it is rounded half up, so the result has no floating
+// point artifacts and is the same on every platform. ExactPercent panics if
+// the denominator is zero, as integer division does.
+func (f Formatter) ExactPercent(numerator, denominator int64, precision int) string {
+
+	if denominator == 0 {
+
+		panic("decimals: ExactPercent with zero denominator")
+	}
+
+	num := new(big.Int).Mul(big.NewInt(numerator), big.NewInt(100))
+	den := big.NewInt(denominator)
+
+	d := ratDecimal(num, den, precision, HalfUp)
+
+	return f.applyTemplate(f.formatPercent(f.formatDecimal(d, precision)))
+}
+
+// FormatPercent formats a ratio as a percentage using the default
+// formatter. See Formatter.FormatPercent.
+func FormatPercent(x float64, precision int) string {
+
+	return DefaultFormatter().FormatPercent(x, precision)
+}
+
+// FormatPercent formats a ratio as a percentage rounded half up to the
+// given precision, so 0.125 is formatted as "12.5%" with one decimal place.
+// The ratio is multiplied by 100 exactly, by its decimal digits, and the
+// percent sign is placed by the formatter's PercentPattern.
+func (f Formatter) FormatPercent(x float64, precision int) string {
+
+	if p, ok := f.placeholder(x); ok {
+
+		return p
+	}
+
+	d, err := DecimalFromFloat(x)
+
+	if err != nil {
+
+		return f.applyTemplate(f.formatPercent(formatSpecial(x)))
+	}
+
+	if d.digits != "" {
+
+		d.exponent += 2
+	}
+
+	return f.applyTemplate(f.formatPercent(f.formatDecimal(d.Round(precision, HalfUp), precision)))
+}
+
+// ParsePercent parses a percentage as a ratio using the default formatter.
+// See Formatter.ParsePercent.
+func ParsePercent(s string) (float64, error) {
+
+	return DefaultFormatter().ParsePercent(s)
+}
+
+// ParsePercentPoints parses a percentage as a number of percentage points
+// using the default formatter. See Formatter.ParsePercentPoints.
+func ParsePercentPoints(s string) (float64, error) {
+
+	return DefaultFormatter().ParsePercentPoints(s)
+}
+
+// ParsePercent parses a percentage written with the formatter's separators
+// and returns it as a ratio, reversing FormatPercent, so "42.5%" is 0.425.
+// The percent sign, '%' or the Arabic percent sign U+066A, may come before
+// or after the number, as the locale's PercentPattern places it, separated
+// from it by any spaces, including no-break spaces, and a minus or plus
+// sign may come before either. Surrounding whitespace is ignored. The
+// number is divided by 100 exactly before it is converted to a float64. An
+// error wrapping ErrSyntax is returned if s is not a percentage, and one
+// wrapping ErrRange if it is too large for a float64.
+func (f Formatter) ParsePercent(s string) (float64, error) {
+
+	return f.parsePercent("ParsePercent", s, -2)
+}
+
+// ParsePercentPoints parses a percentage as ParsePercent does but returns
+// the number of percentage points, so "42.5%" is 42.5.
+func (f Formatter) ParsePercentPoints(s string) (float64, error) {
+
+	return f.parsePercent("ParsePercentPoints", s, 0)
+}
+
+// parsePercent parses a percentage and scales it by the power of ten.
+func (f Formatter) parsePercent(name string, s string, exponent int) (float64, error) {
+
+	var (
+		num  = strings.TrimFunc(s, isPercentSpace)
+		sign string
+	)
+
+	if strings.HasPrefix(num, "-") || strings.HasPrefix(num, "+") {
+
+		sign, num = num[:1], num[1:]
+	}
+
+	// Remove the percent sign from either end
+	found := false
+
+	for _, p := range []string{"%", "\u066A"} {
+
+		if strings.HasPrefix(num, p) {
+
+			num, found = num[len(p):], true
+			break
+		}
+
+		if strings.HasSuffix(num, p) {
+
+			num, found = num[:len(num)-len(p)], true
+			break
+		}
+	}
+
+	if !found {
+
+		return 0, &NumError{name, s, ErrSyntax}
+	}
+
+	num = sign + strings.TrimFunc(num, isPercentSpace)
+
+	// Read the formatter's separators as a comma and a point
+	var pairs []string
+
+	if f.GroupSeparator != "" {
+
+		pairs = append(pairs, f.GroupSeparator, ",")
+	}
+
+	if f.DecimalSeparator != "" {
+
+		pairs = append(pairs, f.DecimalSeparator, ".")
+	}
+
+	d, err := ParseDecimal(strings.NewReplacer(pairs...).Replace(num), ParsePlus)
+
+	if err != nil {
+
+		return 0, &NumError{name, s, err.(*NumError).Err}
+	}
+
+	if d.digits != "" {
+
+		d.exponent += exponent
+	}
+
+	r := d.Float64()
+
+	if math.IsInf(r, 0) {
+
+		return r, &NumError{name, s, ErrRange}
+	}
+
+	return r, nil
+}
+
+// isPercentSpace reports whether r is a space that may surround a
+// percentage or separate it from its percent sign.
+func isPercentSpace(r rune) bool {
+
+	return unicode.IsSpace(r) || r == '\u202F'
+}
+
+// ratDecimal rounds the ratio num/den to the given precision using mode
+// and returns the result as a Decimal.
+func ratDecimal(num, den *big.Int, precision int, mode RoundingMode) Decimal {
+
+	var (
+		n        = new(big.Int).Abs(num)
+		d        = new(big.Int).Abs(den)
+		negative = num.Sign()*den.Sign() < 0
+	)
+
+	// Scale the numerator for decimal places or the denominator for powers
+	// of ten, so that the rounding point is at the units digit
+	if precision >= 0 {
+
+		n.Mul(n, pow10Big(precision))
+
+	} else {
+
+		d.Mul(d, pow10Big(-precision))
+	}
+
+	return newDecimal(negative, roundQuo(n, d, negative, mode).String(), -precision)
+}
+
+// roundQuo divides the non-negative n by the positive d and rounds the
+// quotient to an integer using mode, given the sign of the true quotient.
+func roundQuo(n, d *big.Int, negative bool, mode RoundingMode) *big.Int {
+
+	q, rem := new(big.Int).QuoRem(n, d, new(big.Int))
+
+	// Find the first discarded decimal digit and whether any later digits
+	// are set
+	rem.Mul(rem, big.NewInt(10))
+	digit, sticky := new(big.Int).QuoRem(rem, d, new(big.Int))
+
+	if roundsUp(mode, negative, byte(digit.Int64()), sticky.Sign() != 0, q.Bit(0) == 1) {
+
+		q.Add(q, big.NewInt(1))
+	}
+
+	return q
+}
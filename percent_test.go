@@ -0,0 +1,177 @@
+package decimals
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test ExactPercent with a range of ratios
+func TestExactPercent(t *testing.T) {
+
+	inputs := [][2]int64{
+		{1, 3},
+		{2, 3},
+		{333333, 1000000},
+		{1, 8},
+		{-1, 8},
+		{1, -3},
+		{-1, -3},
+		{0, 7},
+		{-1, 3000000},
+		{123456, 1},
+		{9223372036854775807, 1},
+		{1, 3},
+	}
+
+	precisions := []int{2, 2, 2, 0, 0, 1, 1, 2, 2, 0, 0, -1}
+
+	expected := []string{
+		"33.33%",
+		"66.67%",
+		"33.33%",
+		"13%",
+		"-13%",
+		"-33.3%",
+		"33.3%",
+		"0.00%",
+		"0.00%",
+		"12,345,600%",
+		"922,337,203,685,477,580,700%",
+		"30%",
+	}
+
+	for i, n := range inputs {
+
+		output := ExactPercent(n[0], n[1], precisions[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing ExactPercent(%d, %d, %d)",
+				expected[i], output, n[0], n[1], precisions[i])
+		}
+	}
+}
+
+// Test ExactPercent panics on a zero denominator
+func TestExactPercentZero(t *testing.T) {
+
+	defer func() {
+
+		if recover() == nil {
+
+			t.Errorf("Expected a panic testing ExactPercent with a zero denominator")
+		}
+	}()
+
+	ExactPercent(1, 0, 2)
+}
+
+// Test FormatPercent scales ratios exactly
+func TestFormatPercent(t *testing.T) {
+
+	inputs := []float64{0.125, -0.5, 0.00005, 12.345, 0.285}
+	precisions := []int{1, 0, 2, 0, 0}
+
+	expected := []string{"12.5%", "-50%", "0.01%", "1,235%", "29%"}
+
+	for i, x := range inputs {
+
+		if output := FormatPercent(x, precisions[i]); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatPercent(%v, %d)",
+				expected[i], output, x, precisions[i])
+		}
+	}
+}
+
+// Test PercentPattern places the percent sign for each locale
+func TestPercentPattern(t *testing.T) {
+
+	locales := []string{"en-US", "de-DE", "fr-FR", "fr-CA", "tr-TR"}
+
+	expected := []string{
+		"-12.5%",
+		"-12,5\u00A0%",
+		"-12,5\u202F%",
+		"-12,5\u00A0%",
+		"-%12,5",
+	}
+
+	for i, locale := range locales {
+
+		f, _ := NewFormatter(locale)
+
+		if output := f.FormatPercent(-0.125, 1); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatPercent in %s", expected[i], output, locale)
+		}
+
+		if output := f.ExactPercent(-1, 8, 1); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing ExactPercent in %s", expected[i], output, locale)
+		}
+	}
+
+	f := Formatter{PercentPattern: "% ", Template: "({})"}
+
+	if output := f.FormatPercent(0.5, 0); output != "(% 50)" {
+
+		t.Errorf("Expected: %q but received: %q testing a pattern without a placeholder", "(% 50)", output)
+	}
+}
+
+// Test ParsePercent and ParsePercentPoints with a range of inputs
+func TestParsePercent(t *testing.T) {
+
+	inputs := []string{"42.5%", " 42.5 % ", "%42.5", "-12.5%", "+3%", "1,234.5%", "0.1%", "٪50", "100%"}
+
+	expected := []float64{0.425, 0.425, 0.425, -0.125, 0.03, 12.345, 0.001, 0.5, 1}
+
+	points := []float64{42.5, 42.5, 42.5, -12.5, 3, 1234.5, 0.1, 50, 100}
+
+	for i, s := range inputs {
+
+		if output, err := ParsePercent(s); err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v (%v) testing ParsePercent(%q)", expected[i], output, err, s)
+		}
+
+		if output, err := ParsePercentPoints(s); err != nil || output != points[i] {
+
+			t.Errorf("Expected: %v but received: %v (%v) testing ParsePercentPoints(%q)", points[i], output, err, s)
+		}
+	}
+
+	for _, s := range []string{"", "%", "42.5", "42.5%%", "4 2%", "--5%", "%5%"} {
+
+		if _, err := ParsePercent(s); !errors.Is(err, ErrSyntax) {
+
+			t.Errorf("Expected: %v but received: %v testing ParsePercent(%q)", ErrSyntax, err, s)
+		}
+	}
+
+	if _, err := ParsePercent("1e400%"); err == nil {
+
+		t.Errorf("Expected: an error but received: nil testing ParsePercent(%q)", "1e400%")
+	}
+}
+
+// Test ParsePercent reads the output of FormatPercent in each locale
+func TestParsePercentRoundTrip(t *testing.T) {
+
+	for _, locale := range []string{"en-US", "de-DE", "fr-FR", "fr-CA", "tr-TR", "de-CH"} {
+
+		f, _ := NewFormatter(locale)
+
+		for _, x := range []float64{-0.125, 12345.678, 0, 0.0005} {
+
+			s := f.FormatPercent(x, 2)
+
+			if output, err := f.ParsePercent(s); err != nil || output != RoundFloat(x, 4) {
+
+				t.Errorf("Expected: %v but received: %v (%v) testing ParsePercent(%q) in %s",
+					RoundFloat(x, 4), output, err, s, locale)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package decimals
+
+import (
+	"math/big"
+	"sync"
+)
+
+// The cache of powers of ten used by the big number functions, where
+// pow10Cache[n] is 10^n
+var (
+	pow10Mutex sync.RWMutex
+	pow10Cache []*big.Int
+	pow10Limit int = 64
+)
+
+// SetPow10CacheLimit sets the largest power of ten cached by the functions
+// that compute with math/big, such as ExactPercent. Powers up to the limit
+// are computed once and shared, and larger powers are computed on each
+// use. The default limit is 64. It is safe to call concurrently with the
+// functions that use the cache.
+func SetPow10CacheLimit(n int) {
+
+	pow10Mutex.Lock()
+	defer pow10Mutex.Unlock()
+
+	if n < 0 {
+
+		n = -1
+	}
+
+	pow10Limit = n
+
+	// Release any cached powers above the new limit
+	if len(pow10Cache) > n+1 {
+
+		pow10Cache = append([]*big.Int(nil), pow10Cache[:n+1]...)
+	}
+}
+
+// pow10Big returns 10 to the power of n as a big.Int. The result may be
+// shared and must not be modified.
+func pow10Big(n int) *big.Int {
+
+	pow10Mutex.RLock()
+
+	if n < len(pow10Cache) {
+
+		p := pow10Cache[n]
+		pow10Mutex.RUnlock()
+
+		return p
+	}
+
+	limit := pow10Limit
+	pow10Mutex.RUnlock()
+
+	if n > limit {
+
+		return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+	}
+
+	pow10Mutex.Lock()
+	defer pow10Mutex.Unlock()
+
+	// Extend the cache up to n, which another caller may have done already
+	for len(pow10Cache) <= n {
+
+		if len(pow10Cache) == 0 {
+
+			pow10Cache = append(pow10Cache, big.NewInt(1))
+			continue
+		}
+
+		last := pow10Cache[len(pow10Cache)-1]
+		pow10Cache = append(pow10Cache, new(big.Int).Mul(last, big.NewInt(10)))
+	}
+
+	return pow10Cache[n]
+}
@@ -0,0 +1,70 @@
+package decimals
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Test pow10Big inside and outside the cached range
+func TestPow10Big(t *testing.T) {
+
+	defer SetPow10CacheLimit(64)
+
+	for _, limit := range []int{64, 8, -1} {
+
+		SetPow10CacheLimit(limit)
+
+		for _, n := range []int{0, 1, 8, 9, 64, 100} {
+
+			expected := "1" + strings.Repeat("0", n)
+
+			if output := pow10Big(n).String(); output != expected {
+
+				t.Errorf("Expected: %s but received: %s testing pow10Big(%d) with limit %d",
+					expected, output, n, limit)
+			}
+		}
+	}
+}
+
+// Test the cache is shared and safe for concurrent use
+func TestPow10BigConcurrency(t *testing.T) {
+
+	defer SetPow10CacheLimit(64)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+
+		wg.Add(2)
+
+		go func(n int) {
+
+			defer wg.Done()
+
+			expected := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n*8)), nil)
+
+			if pow10Big(n*8).Cmp(expected) != 0 {
+
+				t.Errorf("Incorrect result testing pow10Big(%d) concurrently", n*8)
+			}
+		}(i)
+
+		go func(n int) {
+
+			defer wg.Done()
+			SetPow10CacheLimit(n * 4)
+		}(i)
+	}
+
+	wg.Wait()
+
+	SetPow10CacheLimit(64)
+
+	if pow10Big(10) != pow10Big(10) {
+
+		t.Errorf("Expected cached powers of ten to be shared")
+	}
+}
@@ -0,0 +1,339 @@
+package decimals
+
+import (
+	"math"
+	"math/big"
+)
+
+// The largest magnitude of argument accepted by Decimal.Exp
+var maxExpArg = DecimalFromInt(100000)
+
+// Pow raises d to the power n and rounds the result to the given scale,
+// the number of decimal places, using the given rounding mode. The power
+// is computed exactly before it is rounded, so the result is correctly
+// rounded. Zero to the power zero is one. It returns ErrDivisionByZero if
+// d is zero and n is negative.
+func (d Decimal) Pow(n int, scale int, mode RoundingMode) (Decimal, error) {
+
+	one := DecimalFromInt(1)
+
+	if n < 0 {
+
+		if d.Sign() == 0 {
+
+			return Decimal{}, ErrDivisionByZero
+		}
+
+		return one.Div(d.pow(-n), scale, mode)
+	}
+
+	// Dividing by one rounds to exactly scale places, as Div does
+	return d.pow(n).Div(one, scale, mode)
+}
+
+// pow returns d to the non-negative power n exactly.
+func (d Decimal) pow(n int) Decimal {
+
+	c := new(big.Int).Exp(d.coefficient(), big.NewInt(int64(n)), nil)
+
+	return bigDecimal(c, d.exponent*n)
+}
+
+// Sqrt returns the square root of d rounded to the given scale using the
+// given rounding mode. The result is correctly rounded. An error wrapping
+// ErrRange is returned if d is negative.
+func (d Decimal) Sqrt(scale int, mode RoundingMode) (Decimal, error) {
+
+	if d.Sign() < 0 {
+
+		return Decimal{}, &NumError{"Decimal.Sqrt", d.String(), ErrRange}
+	}
+
+	var (
+		n     = d.coefficient()
+		den   = big.NewInt(1)
+		shift = d.exponent + 2*scale
+	)
+
+	// Scale d by 10^(2*scale) so the root's last place is the units, as the
+	// fraction n/den
+	if shift >= 0 {
+
+		n.Mul(n, pow10Big(shift))
+
+	} else {
+
+		den = pow10Big(-shift)
+	}
+
+	// The floor of the root of n/den is the floor of the root of its
+	// integer part
+	q := new(big.Int).Quo(n, den)
+	q.Sqrt(q)
+
+	// Compare the root with q and q+½ by comparing n with den×q² and 4n
+	// with den×(2q+1)²
+	var (
+		sq     = new(big.Int).Mul(q, q)
+		exact  = sq.Mul(sq, den).Cmp(n) == 0
+		mid    = new(big.Int).Lsh(q, 1)
+		digit  byte
+		sticky bool
+		n4     = new(big.Int).Lsh(n, 2)
+	)
+
+	mid.Add(mid, big.NewInt(1))
+	mid.Mul(mid, mid).Mul(mid, den)
+
+	switch c := n4.Cmp(mid); {
+
+	case exact:
+
+	case c < 0:
+
+		sticky = true
+
+	case c == 0:
+
+		digit = 5
+
+	default:
+
+		digit, sticky = 5, true
+	}
+
+	if roundsUp(mode, false, digit, sticky, q.Bit(0) == 1) {
+
+		q.Add(q, big.NewInt(1))
+	}
+
+	return bigDecimal(q, -scale), nil
+}
+
+// Exp returns e raised to the power d, rounded to the given scale using
+// the given rounding mode. The result is computed to increasing precision
+// until it is known to be correctly rounded. An error wrapping ErrRange is
+// returned if the magnitude of d is 100,000 or more.
+func (d Decimal) Exp(scale int, mode RoundingMode) (Decimal, error) {
+
+	if d.Abs().Cmp(maxExpArg) >= 0 {
+
+		return Decimal{}, &NumError{"Decimal.Exp", d.String(), ErrRange}
+	}
+
+	// The exponential of zero is exactly one
+	if d.Sign() == 0 {
+
+		return ratDecimal(big.NewInt(1), big.NewInt(1), scale, mode), nil
+	}
+
+	var (
+		x      = d.rat()
+		xf     = d.Float64()
+		halves int
+	)
+
+	// Halve x until it is within one half, to square the result back up
+	for math.Abs(xf) > math.Ldexp(0.5, halves) {
+
+		halves++
+	}
+
+	// Start with enough bits for the result's integer and decimal digits
+	digits := scale + int(xf/math.Ln10) + 2
+
+	if digits < 1 {
+
+		digits = 1
+	}
+
+	for prec := uint(digits*10/3 + halves + 64); ; prec *= 2 {
+
+		v, bound := expRat(x, halves, prec)
+
+		if r, ok := roundBounds(v, bound, scale, mode); ok {
+
+			return r, nil
+		}
+	}
+}
+
+// Ln returns the natural logarithm of d, rounded to the given scale using
+// the given rounding mode. The result is computed to increasing precision
+// until it is known to be correctly rounded. An error wrapping ErrRange is
+// returned if d is not positive.
+func (d Decimal) Ln(scale int, mode RoundingMode) (Decimal, error) {
+
+	if d.Sign() <= 0 {
+
+		return Decimal{}, &NumError{"Decimal.Ln", d.String(), ErrRange}
+	}
+
+	// The logarithm of one is exactly zero
+	if d.Cmp(DecimalFromInt(1)) == 0 {
+
+		return ratDecimal(big.NewInt(0), big.NewInt(1), scale, mode), nil
+	}
+
+	// Start with enough bits for the decimal digits and the integer digits
+	// of the logarithm, about log10(|a| × ln 10) for the adjusted exponent a
+	digits := scale + 1
+
+	if a := float64(len(d.digits) + d.exponent - 1); a != 0 {
+
+		digits += int(math.Log10(math.Abs(a)*math.Ln10)) + 1
+	}
+
+	if digits < 1 {
+
+		digits = 1
+	}
+
+	x := d.rat()
+
+	for prec := uint(digits*10/3 + 64); ; prec *= 2 {
+
+		v, bound := lnRat(x, prec)
+
+		if r, ok := roundBounds(v, bound, scale, mode); ok {
+
+			return r, nil
+		}
+	}
+}
+
+// expRat approximates e^x using floats of the given precision, and returns
+// the approximation and a bound on its error. x is divided by 2^halves so
+// that the Taylor series converges quickly, and the sum is squared back up.
+func expRat(x *big.Rat, halves int, prec uint) (*big.Rat, *big.Rat) {
+
+	var (
+		r    = new(big.Float).SetPrec(prec).SetRat(x)
+		sum  = new(big.Float).SetPrec(prec).SetInt64(1)
+		term = new(big.Float).SetPrec(prec).SetInt64(1)
+		n    int
+	)
+
+	r.SetMantExp(r, -halves)
+
+	// Sum the series 1 + r + r²/2! + r³/3! + … until the terms are
+	// negligible
+	for n = 1; ; n++ {
+
+		term.Mul(term, r)
+		term.Quo(term, new(big.Float).SetInt64(int64(n)))
+
+		if term.Sign() == 0 || term.MantExp(nil) < -int(prec) {
+
+			break
+		}
+
+		sum.Add(sum, term)
+	}
+
+	for i := 0; i < halves; i++ {
+
+		sum.Mul(sum, sum)
+	}
+
+	// Each operation adds a relative error of at most 2^-prec, and each
+	// squaring doubles the relative error
+	v, _ := sum.Rat(nil)
+	bound := new(big.Rat).SetFrac(big.NewInt(int64(8*n+24)), new(big.Int).Lsh(big.NewInt(1), prec-uint(halves)))
+	bound.Mul(bound, new(big.Rat).Abs(v))
+
+	return v, bound
+}
+
+// lnRat approximates the natural logarithm of the positive x using floats
+// of the given precision, and returns the approximation and a bound on its
+// error. x is split into m × 2^e with m in [½, 1), and ln m is summed from
+// the series for 2 atanh((m-1)/(m+1)).
+func lnRat(x *big.Rat, prec uint) (*big.Rat, *big.Rat) {
+
+	var (
+		m   = new(big.Float).SetPrec(prec)
+		e   = new(big.Float).SetPrec(prec).SetRat(x).MantExp(m)
+		one = new(big.Float).SetPrec(prec).SetInt64(1)
+		z   = new(big.Float).SetPrec(prec)
+	)
+
+	// Find ln m
+	z.Quo(new(big.Float).SetPrec(prec).Sub(m, one), new(big.Float).SetPrec(prec).Add(m, one))
+	lnm, n := atanhSeries(z, prec)
+	lnm.SetMantExp(lnm, 1)
+
+	// Add e × ln 2, where ln 2 is 2 atanh(1/3)
+	ln2, n2 := atanhSeries(new(big.Float).SetPrec(prec).Quo(one, new(big.Float).SetInt64(3)), prec)
+	ln2.SetMantExp(ln2, 1)
+	ln2.Mul(ln2, new(big.Float).SetInt64(int64(e)))
+	lnm.Add(lnm, ln2)
+
+	if n2 > n {
+
+		n = n2
+	}
+
+	// Each operation adds an absolute error of at most 2^-prec for each
+	// unit of the binary exponent
+	v, _ := lnm.Rat(nil)
+	bound := new(big.Rat).SetFrac(big.NewInt(int64((absInt(e)+2)*(8*n+32))), new(big.Int).Lsh(big.NewInt(1), prec))
+
+	return v, bound
+}
+
+// atanhSeries sums the series z + z³/3 + z⁵/5 + … for |z| ≤ 1/3 until the
+// terms are less than 2^-prec, and returns the sum and the number of terms.
+func atanhSeries(z *big.Float, prec uint) (*big.Float, int) {
+
+	var (
+		z2   = new(big.Float).SetPrec(prec).Mul(z, z)
+		pow  = new(big.Float).SetPrec(prec).Set(z)
+		sum  = new(big.Float).SetPrec(prec).Set(z)
+		term = new(big.Float).SetPrec(prec)
+		n    int
+	)
+
+	for n = 1; ; n++ {
+
+		pow.Mul(pow, z2)
+		term.Quo(pow, new(big.Float).SetInt64(int64(2*n+1)))
+
+		if term.Sign() == 0 || term.MantExp(nil) < -int(prec) {
+
+			break
+		}
+
+		sum.Add(sum, term)
+	}
+
+	return sum, n
+}
+
+// roundBounds rounds the ends of the interval v ± bound to the given scale
+// using mode, and reports whether they round to the same result, in which
+// case any value in the interval rounds to it.
+func roundBounds(v, bound *big.Rat, scale int, mode RoundingMode) (Decimal, bool) {
+
+	var (
+		lo = new(big.Rat).Sub(v, bound)
+		hi = new(big.Rat).Add(v, bound)
+		l  = ratDecimal(lo.Num(), lo.Denom(), scale, mode)
+		h  = ratDecimal(hi.Num(), hi.Denom(), scale, mode)
+	)
+
+	return l, l.Cmp(h) == 0
+}
+
+// rat returns d as a big.Rat.
+func (d Decimal) rat() *big.Rat {
+
+	r := new(big.Rat).SetInt(d.coefficient())
+
+	if d.exponent >= 0 {
+
+		return r.Mul(r, new(big.Rat).SetInt(pow10Big(d.exponent)))
+	}
+
+	return r.Quo(r, new(big.Rat).SetInt(pow10Big(-d.exponent)))
+}
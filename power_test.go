@@ -0,0 +1,220 @@
+package decimals
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Test Decimal.Pow with positive and negative powers
+func TestDecimalPow(t *testing.T) {
+
+	type powInput struct {
+		d     string
+		n     int
+		scale int
+		mode  RoundingMode
+	}
+
+	inputs := []powInput{
+		{"1.5", 2, 4, HalfUp},
+		{"-1.1", 3, 3, HalfUp},
+		{"-1.1", 3, 2, HalfEven},
+		{"2", -2, 3, HalfUp},
+		{"3", -1, 4, Down},
+		{"0", 0, 0, HalfUp},
+		{"1.05", 10, 6, HalfUp},
+		{"12", 3, -2, HalfUp},
+	}
+
+	expected := []string{
+		"2.2500",
+		"-1.331",
+		"-1.33",
+		"0.250",
+		"0.3333",
+		"1",
+		"1.628895",
+		"1700",
+	}
+
+	for i, input := range inputs {
+
+		output, err := MustParseDecimal(input.d).Pow(input.n, input.scale, input.mode)
+
+		if err != nil || output.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing Pow(%s, %d)",
+				expected[i], output.String(), err, input.d, input.n)
+		}
+	}
+
+	if _, err := MustParseDecimal("0").Pow(-1, 2, HalfUp); err != ErrDivisionByZero {
+
+		t.Errorf("Expected: ErrDivisionByZero but received: %v testing Pow(0, -1)", err)
+	}
+}
+
+// Test Decimal.Sqrt is correctly rounded in each mode
+func TestDecimalSqrt(t *testing.T) {
+
+	type sqrtInput struct {
+		d     string
+		scale int
+		mode  RoundingMode
+	}
+
+	inputs := []sqrtInput{
+		{"2", 10, HalfUp},
+		{"2", 2, Up},
+		{"2", 2, Down},
+		{"2.25", 0, HalfEven},
+		{"2.25", 0, HalfDown},
+		{"2.25", 0, HalfUp},
+		{"6.25", 0, HalfEven},
+		{"0", 2, HalfUp},
+		{"0.0001", 2, HalfUp},
+		{"0.0001", 1, Ceiling},
+		{"10000", -2, HalfUp},
+		{"1e-7", 5, HalfUp},
+	}
+
+	expected := []string{
+		"1.4142135624",
+		"1.42",
+		"1.41",
+		"2",
+		"1",
+		"2",
+		"2",
+		"0.00",
+		"0.01",
+		"0.1",
+		"100",
+		"0.00032",
+	}
+
+	for i, input := range inputs {
+
+		output, err := MustParseDecimal(input.d).Sqrt(input.scale, input.mode)
+
+		if err != nil || output.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing Sqrt(%s, %d, %v)",
+				expected[i], output.String(), err, input.d, input.scale, input.mode)
+		}
+	}
+
+	if _, err := MustParseDecimal("-1").Sqrt(2, HalfUp); !errors.Is(err, ErrRange) {
+
+		t.Errorf("Expected: ErrRange but received: %v testing Sqrt(-1)", err)
+	}
+}
+
+// Test Decimal.Exp and Decimal.Ln
+func TestDecimalExpLn(t *testing.T) {
+
+	type expInput struct {
+		d     string
+		scale int
+		mode  RoundingMode
+	}
+
+	expInputs := []expInput{
+		{"1", 20, HalfUp},
+		{"0", 2, Down},
+		{"-1", 10, HalfUp},
+		{"10", 5, HalfUp},
+		{"0.5", 3, Down},
+		{"0.5", 3, Up},
+		{"-50", 2, Up},
+		{"-50", 2, HalfUp},
+	}
+
+	expExpected := []string{
+		"2.71828182845904523536",
+		"1.00",
+		"0.3678794412",
+		"22026.46579",
+		"1.648",
+		"1.649",
+		"0.01",
+		"0.00",
+	}
+
+	for i, input := range expInputs {
+
+		output, err := MustParseDecimal(input.d).Exp(input.scale, input.mode)
+
+		if err != nil || output.String() != expExpected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing Exp(%s, %d, %v)",
+				expExpected[i], output.String(), err, input.d, input.scale, input.mode)
+		}
+	}
+
+	lnInputs := []expInput{
+		{"2", 20, HalfUp},
+		{"10", 10, HalfUp},
+		{"1", 2, Down},
+		{"0.5", 5, HalfUp},
+		{"1.0001", 12, HalfUp},
+		{"1e100", 5, HalfUp},
+		{"0.5", 5, Floor},
+	}
+
+	lnExpected := []string{
+		"0.69314718055994530942",
+		"2.3025850930",
+		"0.00",
+		"-0.69315",
+		"0.000099995000",
+		"230.25851",
+		"-0.69315",
+	}
+
+	for i, input := range lnInputs {
+
+		output, err := MustParseDecimal(input.d).Ln(input.scale, input.mode)
+
+		if err != nil || output.String() != lnExpected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing Ln(%s, %d, %v)",
+				lnExpected[i], output.String(), err, input.d, input.scale, input.mode)
+		}
+	}
+
+	// The precision depends on the size of the logarithm, not of d
+	start := time.Now()
+
+	for _, input := range []string{"1e300000", "1e-300000"} {
+
+		if _, err := MustParseDecimal(input).Ln(5, HalfUp); err != nil {
+
+			t.Errorf("Expected: no error but received: %v testing Ln(%s)", err, input)
+		}
+	}
+
+	if output, _ := MustParseDecimal("1e300000").Ln(5, HalfUp); output.String() != "690775.52790" {
+
+		t.Errorf("Expected: \"690775.52790\" but received: %q testing Ln(1e300000, 5, HalfUp)", output.String())
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+
+		t.Errorf("Expected: under 5s but took %v testing Ln(1e300000)", elapsed)
+	}
+
+	for _, input := range []string{"0", "-1"} {
+
+		if _, err := MustParseDecimal(input).Ln(2, HalfUp); !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: ErrRange but received: %v testing Ln(%s)", err, input)
+		}
+	}
+
+	if _, err := MustParseDecimal("100000").Exp(2, HalfUp); !errors.Is(err, ErrRange) {
+
+		t.Errorf("Expected: ErrRange but received: %v testing Exp(100000)", err)
+	}
+}
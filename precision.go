@@ -0,0 +1,71 @@
+package decimals
+
+import (
+	"strconv"
+)
+
+// MaxFloatDigits is the number of significant decimal digits needed to
+// identify any float64. Digits beyond it carry no information about the
+// float.
+const MaxFloatDigits = 17
+
+// A PrecisionPolicy decides how floats are formatted to a precision that
+// would show more than MaxFloatDigits significant digits, such as
+// 123456789.123 to twenty places.
+type PrecisionPolicy int
+
+const (
+	// PrecisionZeroFill pads the float's shortest decimal representation
+	// with zeros to the precision, so 0.1 to thirty places is "0.1"
+	// followed by twenty-nine zeros. It is the default.
+	PrecisionZeroFill PrecisionPolicy = iota
+
+	// PrecisionCap shows no more than MaxFloatDigits significant digits, as
+	// if MaxSignificantDigits were seventeen, so 123456789.123 to twenty
+	// places is "123,456,789.12300000".
+	PrecisionCap
+
+	// PrecisionError formats as PrecisionCap does, and FormatFloatChecked
+	// reports an error for precisions beyond MaxFloatDigits.
+	PrecisionError
+)
+
+// FormatFloatChecked converts a float64 to a formatted string as
+// FormatFloat does, and reports whether the precision asked for more
+// significant digits than a float64 holds. With the PrecisionError policy
+// it then returns an error wrapping ErrRange alongside the capped string.
+// With the other policies the error is always nil.
+func (f Formatter) FormatFloatChecked(x float64, precision int) (string, error) {
+
+	s := f.FormatFloat(x, precision)
+
+	if f.PrecisionPolicy != PrecisionError {
+
+		return s, nil
+	}
+
+	if d, err := DecimalFromFloat(x); err == nil {
+
+		limit := Formatter{MaxSignificantDigits: MaxFloatDigits}
+
+		if limit.significantPrecision(d, precision) < precision {
+
+			return s, &NumError{"FormatFloatChecked", strconv.FormatFloat(x, 'g', -1, 64), ErrRange}
+		}
+	}
+
+	return s, nil
+}
+
+// floatLimit returns the formatter with its significant digits limited as
+// its precision policy requires for formatting floats.
+func (f Formatter) floatLimit() Formatter {
+
+	if f.PrecisionPolicy != PrecisionZeroFill &&
+		(f.MaxSignificantDigits <= 0 || f.MaxSignificantDigits > MaxFloatDigits) {
+
+		f.MaxSignificantDigits = MaxFloatDigits
+	}
+
+	return f
+}
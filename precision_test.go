@@ -0,0 +1,78 @@
+package decimals
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test each precision policy with precisions beyond the digits of a float64
+func TestPrecisionPolicy(t *testing.T) {
+
+	var (
+		fill   = DefaultFormatter()
+		capped = Formatter{GroupSeparator: ",", DecimalSeparator: ".", PrecisionPolicy: PrecisionCap}
+	)
+
+	inputs := []string{
+		fill.FormatFloat(0.1, 30),
+		fill.FormatFloat(123456789.123, 20),
+		capped.FormatFloat(0.1, 30),
+		capped.FormatFloat(123456789.123, 20),
+		capped.FormatFloat(1.0/3, 30),
+		capped.FormatFloat(1e-20, 40),
+		capped.FormatFloat(2.5, 2),
+		capped.FormatWithSpec(0.1, FormatSpec{Precision: 20}),
+		Formatter{PrecisionPolicy: PrecisionCap, MaxSignificantDigits: 3}.FormatFloat(123456, 2),
+	}
+
+	expected := []string{
+		"0.100000000000000000000000000000",
+		"123,456,789.12300000000000000000",
+		"0.10000000000000000",
+		"123,456,789.12300000",
+		"0.33333333333333330",
+		"0.000000000000000000010000000000000000",
+		"2.50",
+		"0.10000000000000000",
+		"123000",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing PrecisionPolicy", expected[i], output)
+		}
+	}
+}
+
+// Test FormatFloatChecked reports excess precision with PrecisionError
+func TestFormatFloatChecked(t *testing.T) {
+
+	var (
+		checked = Formatter{GroupSeparator: ",", DecimalSeparator: ".", PrecisionPolicy: PrecisionError}
+		values  = []float64{0.1, 0.1, 123456789.123, 123456789.123, 0}
+		places  = []int{17, 18, 8, 9, 30}
+		fails   = []bool{false, true, false, true, false}
+	)
+
+	for i, x := range values {
+
+		s, err := checked.FormatFloatChecked(x, places[i])
+
+		if (err != nil) != fails[i] || err != nil && !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: error %v but received: %v testing FormatFloatChecked(%v, %d)", fails[i], err, x, places[i])
+		}
+
+		if s != checked.FormatFloat(x, places[i]) {
+
+			t.Errorf("Expected: %q but received: %q testing FormatFloatChecked", checked.FormatFloat(x, places[i]), s)
+		}
+	}
+
+	if _, err := DefaultFormatter().FormatFloatChecked(0.1, 30); err != nil {
+
+		t.Errorf("Expected: nil but received: %v testing FormatFloatChecked with PrecisionZeroFill", err)
+	}
+}
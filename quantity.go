@@ -0,0 +1,155 @@
+package decimals
+
+import (
+	"math"
+	"strings"
+)
+
+// A Quantity is a number with a unit, such as 1.5 km or 3 items, so that
+// applications can pass quantities around and format them consistently.
+type Quantity struct {
+
+	// Value is the number of units.
+	Value float64
+
+	// Unit is the unit, such as "km" or "item". By default it follows the
+	// number after a space. If it contains the placeholder "{}" the number
+	// is placed there instead, as in "${}" or "{}%".
+	Unit string
+
+	// Plural is the unit used for any value other than exactly one, such
+	// as "items". If it is empty Unit is used for every value.
+	Plural string
+}
+
+// Format formats the quantity using the default formatter. See
+// Formatter.FormatQuantity.
+func (q Quantity) Format(precision int) string {
+
+	return DefaultFormatter().FormatQuantity(q, precision)
+}
+
+// FormatSI formats the quantity with an SI prefix using the default
+// formatter. See Formatter.FormatQuantitySI.
+func (q Quantity) FormatSI(precision int) string {
+
+	return DefaultFormatter().FormatQuantitySI(q, precision)
+}
+
+// FormatQuantity formats a quantity with its value rounded to the given
+// precision as by FormatFloat, followed by its unit. The plural unit is
+// used unless the value is formatted as exactly one, so a quantity of one
+// item is formatted as "1 item" but with one decimal place as "1.0 items".
+func (f Formatter) FormatQuantity(q Quantity, precision int) string {
+
+	if p, ok := f.placeholder(q.Value); ok {
+
+		return p
+	}
+
+	d, err := DecimalFromFloat(q.Value)
+
+	if err != nil {
+
+		return f.applyTemplate(placeUnit(formatSpecial(q.Value), q.plural()))
+	}
+
+	r := d.Round(precision, HalfUp)
+
+	return f.applyTemplate(placeUnit(f.formatDecimal(r, precision), q.unitFor(r, precision, "")))
+}
+
+// FormatQuantitySI formats a quantity scaled to the largest SI prefix not
+// greater than its value, such as "1.5 km" for 1,500 m or "2.4 GB" for
+// 2.4 billion bytes. The prefix is added to the front of the unit and the
+// value is rounded to the given precision after scaling. Values below one
+// pico unit are formatted without a prefix.
+func (f Formatter) FormatQuantitySI(q Quantity, precision int) string {
+
+	var (
+		g      Formatter = f
+		prefix string
+	)
+
+	if p, ok := f.placeholder(q.Value); ok {
+
+		return p
+	}
+
+	if math.IsNaN(q.Value) || math.IsInf(q.Value, 0) {
+
+		return f.applyTemplate(placeUnit(formatSpecial(q.Value), q.plural()))
+	}
+
+	g.Magnitudes = SIScale
+	r, m := g.scaleMagnitude(q.Value, precision)
+
+	// Values too small for any prefix are formatted without one
+	if m != nil {
+
+		prefix = m.Symbol
+	}
+
+	return f.applyTemplate(placeUnit(f.formatDecimal(r, precision), q.unitFor(r, precision, prefix)))
+}
+
+// unitFor returns the unit for the rounded value r, with the given prefix.
+func (q Quantity) unitFor(r Decimal, precision int, prefix string) string {
+
+	if precision <= 0 && r.Abs().Cmp(DecimalFromInt(1)) == 0 {
+
+		return prefixUnit(q.Unit, prefix)
+	}
+
+	return prefixUnit(q.plural(), prefix)
+}
+
+// plural returns the unit used for values other than one.
+func (q Quantity) plural() string {
+
+	if q.Plural == "" {
+
+		return q.Unit
+	}
+
+	return q.Plural
+}
+
+// prefixUnit adds the prefix to the front of the unit, which follows any
+// placeholder.
+func prefixUnit(unit, prefix string) string {
+
+	i := strings.Index(unit, TemplatePlaceholder)
+
+	if i < 0 {
+
+		return prefix + unit
+	}
+
+	// Skip the placeholder and any spaces that follow it
+	i += len(TemplatePlaceholder)
+
+	for i < len(unit) && unit[i] == ' ' {
+
+		i++
+	}
+
+	return unit[:i] + prefix + unit[i:]
+}
+
+// placeUnit combines a formatted number with its unit.
+func placeUnit(number, unit string) string {
+
+	switch {
+
+	case unit == "":
+
+		return number
+
+	case strings.Contains(unit, TemplatePlaceholder):
+
+		return strings.Replace(unit, TemplatePlaceholder, number, 1)
+	}
+
+	return number + " " + unit
+}
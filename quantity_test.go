@@ -0,0 +1,81 @@
+package decimals
+
+import (
+	"testing"
+)
+
+// Test Quantity.Format with a range of units
+func TestQuantityFormat(t *testing.T) {
+
+	inputs := []Quantity{
+		{1, "item", "items"},
+		{3, "item", "items"},
+		{1, "item", "items"},
+		{-1, "item", "items"},
+		{1500.25, "km", ""},
+		{12.5, "${}", ""},
+		{99.5, "{}%", ""},
+		{0, "", ""},
+	}
+
+	precisions := []int{0, 0, 1, 0, 1, 2, 0, 0}
+
+	expected := []string{
+		"1 item",
+		"3 items",
+		"1.0 items",
+		"-1 item",
+		"1,500.3 km",
+		"$12.50",
+		"100%",
+		"0",
+	}
+
+	for i, q := range inputs {
+
+		output := q.Format(precisions[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing %+v.Format(%d)",
+				expected[i], output, q, precisions[i])
+		}
+	}
+}
+
+// Test Quantity.FormatSI scales to SI prefixes
+func TestQuantityFormatSI(t *testing.T) {
+
+	inputs := []Quantity{
+		{1500, "m", ""},
+		{2.4e9, "B", ""},
+		{999.96, "g", ""},
+		{0.0025, "s", ""},
+		{5, "V", ""},
+		{0, "B", ""},
+		{1000, "{} m", ""},
+		{1e-15, "F", ""},
+	}
+
+	expected := []string{
+		"1.5 km",
+		"2.4 GB",
+		"1.0 kg",
+		"2.5 ms",
+		"5.0 V",
+		"0.0 B",
+		"1.0 km",
+		"0.0 F",
+	}
+
+	for i, q := range inputs {
+
+		output := q.FormatSI(1)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing %+v.FormatSI(1)",
+				expected[i], output, q)
+		}
+	}
+}
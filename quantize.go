@@ -0,0 +1,92 @@
+package decimals
+
+import (
+	"math"
+)
+
+// Quantize rounds x to a multiple of step using the given rounding mode,
+// generalizing rounding to a number of decimal places to any increment,
+// such as ticks of 0.125 or intervals of 15 minutes. As with RoundFloatMode
+// the shortest decimal representations of x and step are used, so
+// Quantize(0.3, 0.1, HalfUp) is 0.3 and the multiple is computed exactly
+// before it is converted to the nearest float. NaN and infinities are
+// returned unchanged, and NaN is returned if step is not positive and
+// finite.
+func Quantize(x float64, step float64, mode RoundingMode) float64 {
+
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+
+		return x
+	}
+
+	if !(step > 0) || math.IsInf(step, 0) {
+
+		return math.NaN()
+	}
+
+	var (
+		d, _ = DecimalFromFloat(x)
+		s, _ = DecimalFromFloat(step)
+	)
+
+	q, _ := d.Quantize(s, mode)
+
+	return roundedFloat(x, q)
+}
+
+// RoundToNearest rounds an int64 to the nearest multiple of n using the
+// given rounding mode, generalizing RoundIntMode to steps that are not
+// powers of ten, as for rounding cents to nickels, seconds to quarter
+// minutes or bytes to pages. The sign of n is ignored and x is returned
+// unchanged if n is zero. The result is limited to the minimum and maximum
+// for int64 as with RoundInt.
+func RoundToNearest(x int64, n int64, mode RoundingMode) int64 {
+
+	if n == 0 {
+
+		return x
+	}
+
+	q, _ := DecimalFromInt(x).Quantize(DecimalFromInt(n).Abs(), mode)
+
+	// Int64 saturates on overflow
+	r, _ := q.Int64()
+
+	return r
+}
+
+// Quantize rounds d to a multiple of step using the given rounding mode,
+// as the package level Quantize does but exactly. The result has as many
+// decimal places as step. It returns ErrRange if step is not positive.
+func (d Decimal) Quantize(step Decimal, mode RoundingMode) (Decimal, error) {
+
+	if step.Sign() <= 0 {
+
+		return Decimal{}, ErrRange
+	}
+
+	// Divide d by step at a common exponent, so both coefficients are
+	// integers, and round the quotient to a whole number of steps
+	var (
+		num = d.coefficient()
+		den = step.coefficient()
+	)
+
+	if d.exponent > step.exponent {
+
+		num.Mul(num, pow10Big(d.exponent-step.exponent))
+
+	} else {
+
+		den.Mul(den, pow10Big(step.exponent-d.exponent))
+	}
+
+	n := roundQuo(num.Abs(num), den, d.negative, mode)
+
+	if d.negative {
+
+		n.Neg(n)
+	}
+
+	return bigDecimal(n.Mul(n, step.coefficient()), step.exponent), nil
+}
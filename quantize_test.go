@@ -0,0 +1,88 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test Quantize rounds to multiples of a step
+func TestQuantize(t *testing.T) {
+
+	var (
+		inputs   = []float64{101.3, 101.3, 101.3, -101.3, 37, 52.5, 0.3, 2.675, 0.0625, 0.0625, -0.04, 7}
+		steps    = []float64{0.125, 0.125, 0.125, 0.125, 15, 15, 0.1, 0.01, 0.125, 0.125, 0.25, 2.5}
+		modes    = []RoundingMode{HalfUp, Floor, Ceiling, HalfUp, HalfUp, HalfEven, HalfUp, HalfUp, HalfUp, HalfEven, HalfUp, HalfUp}
+		expected = []float64{101.25, 101.25, 101.375, -101.25, 30, 60, 0.3, 2.68, 0.125, 0, math.Copysign(0, -1), 7.5}
+	)
+
+	for i, input := range inputs {
+
+		output := Quantize(input, steps[i], modes[i])
+
+		if output != expected[i] || math.Signbit(output) != math.Signbit(expected[i]) {
+
+			t.Errorf("Expected: %v but received: %v testing Quantize(%v, %v, %v)", expected[i], output, input, steps[i], modes[i])
+		}
+	}
+}
+
+// Test Quantize with special values and invalid steps
+func TestQuantizeSpecial(t *testing.T) {
+
+	if output := Quantize(math.Inf(1), 0.5, HalfUp); !math.IsInf(output, 1) {
+
+		t.Errorf("Expected: +Inf but received: %v testing Quantize", output)
+	}
+
+	for _, step := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+
+		if output := Quantize(1, step, HalfUp); !math.IsNaN(output) {
+
+			t.Errorf("Expected: NaN but received: %v testing Quantize with step %v", output, step)
+		}
+	}
+}
+
+// Test Decimal.Quantize keeps the places of the step
+func TestDecimalQuantize(t *testing.T) {
+
+	var (
+		inputs   = []string{"101.3", "1.23456", "-7.5", "1234", "0"}
+		steps    = []string{"0.125", "0.05", "5", "1e2", "0.25"}
+		expected = []string{"101.250", "1.25", "-10", "1200", "0.00"}
+	)
+
+	for i, input := range inputs {
+
+		output, err := MustParseDecimal(input).Quantize(MustParseDecimal(steps[i]), HalfUp)
+
+		if err != nil || output.String() != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing Decimal.Quantize", expected[i], output, err)
+		}
+	}
+
+	if _, err := MustParseDecimal("1").Quantize(Decimal{}, HalfUp); err != ErrRange {
+
+		t.Errorf("Expected: %v but received: %v testing Decimal.Quantize", ErrRange, err)
+	}
+}
+
+// Test RoundToNearest rounds to multiples of n
+func TestRoundToNearest(t *testing.T) {
+
+	var (
+		inputs   = []int64{1234, 1237, 1237, -1237, 52, 67, 5000, 4097, 7, math.MaxInt64, math.MinInt64, 10}
+		steps    = []int64{5, 5, 5, 5, 15, 15, 4096, 4096, -5, 1000, 1000, 0}
+		modes    = []RoundingMode{HalfUp, HalfUp, Floor, Ceiling, HalfUp, HalfEven, Ceiling, Down, HalfUp, HalfUp, HalfUp, HalfUp}
+		expected = []int64{1235, 1235, 1235, -1235, 45, 60, 8192, 4096, 5, math.MaxInt64, math.MinInt64, 10}
+	)
+
+	for i, input := range inputs {
+
+		if output := RoundToNearest(input, steps[i], modes[i]); output != expected[i] {
+
+			t.Errorf("Expected: %d but received: %d testing RoundToNearest(%d, %d, %v)", expected[i], output, input, steps[i], modes[i])
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package decimals
+
+import (
+	"math"
+	"strings"
+)
+
+// FormatRange formats a range of float64s such as prices or ages, with both
+// bounds rounded to the given precision and joined by an en dash unless
+// changed with WithRangeSeparator. The formatter's template is shared by
+// the bounds rather than repeated, so the template "${}" gives
+// "$1,200–1,500" rather than "$1,200–$1,500". An infinite upper bound
+// gives an open-ended range such as "500+", and an infinite lower bound
+// one such as "<500". If the bounds are formatted the same a single value
+// is returned. Other options apply as for FormatFloatOpt.
+func FormatRange(lo, hi float64, precision int, opts ...Option) string {
+
+	o := applyOptions(opts)
+	o.spec.Precision = precision
+
+	var (
+		f      Formatter = o.formatter
+		spec   FormatSpec
+		prefix string = f.Template
+		suffix string
+		r      string
+	)
+
+	// Split the template around the placeholder
+	if i := strings.Index(prefix, TemplatePlaceholder); i >= 0 {
+
+		prefix, suffix = prefix[:i], prefix[i+len(TemplatePlaceholder):]
+	}
+
+	// Format the bounds without the template or padding
+	f.Template = ""
+	spec = o.spec
+	spec.Width = 0
+
+	los := f.FormatWithSpec(lo, spec)
+	his := f.FormatWithSpec(hi, spec)
+
+	switch {
+
+	case math.IsInf(hi, 1) && !math.IsInf(lo, 0):
+
+		r = prefix + los + "+" + suffix
+
+	case math.IsInf(lo, -1) && !math.IsInf(hi, 0):
+
+		r = "<" + prefix + his + suffix
+
+	case los == his:
+
+		r = prefix + los + suffix
+
+	default:
+
+		r = prefix + los + o.rangeSep + his + suffix
+	}
+
+	// Pad to the width
+	if pad := o.spec.Width - DisplayWidth(r); pad > 0 {
+
+		r = strings.Repeat(" ", pad) + r
+	}
+
+	return r
+}
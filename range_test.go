@@ -0,0 +1,43 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatRange with templates, open ends and options
+func TestFormatRange(t *testing.T) {
+
+	inputs := []string{
+		FormatRange(1200, 1500, 0),
+		FormatRange(1200, 1500, 0, WithTemplate("${}")),
+		FormatRange(1200, 1500, 0, WithTemplate("{} USD")),
+		FormatRange(18, 24, 0, WithRangeSeparator(" to ")),
+		FormatRange(500, math.Inf(1), 0, WithTemplate("${}")),
+		FormatRange(math.Inf(-1), 500, 0, WithTemplate("${}")),
+		FormatRange(1.004, 0.996, 2),
+		FormatRange(1.5, 2.25, 2, WithLocale("de-DE"), WithTemplate("{} €")),
+		FormatRange(1, 2, 0, WithWidth(6)),
+	}
+
+	expected := []string{
+		"1,200–1,500",
+		"$1,200–1,500",
+		"1,200–1,500 USD",
+		"18 to 24",
+		"$500+",
+		"<$500",
+		"1.00",
+		"1,50–2,25 €",
+		"   1–2",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatRange",
+				expected[i], output)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package decimals
+
+import (
+	"math"
+	"strconv"
+)
+
+// maxRatioMultiplier is the largest multiplier DescribeRatio states, beyond
+// which ratios are described as more than it.
+const maxRatioMultiplier = 1e6
+
+// ratioFractions are the fractions that DescribeRatio names, in order of
+// preference.
+var ratioFractions = []struct {
+	value float64
+	name  string
+}{
+	{1.0 / 2, "half"},
+	{1.0 / 3, "a third"},
+	{2.0 / 3, "two thirds"},
+	{1.0 / 4, "a quarter"},
+	{3.0 / 4, "three quarters"},
+}
+
+// DescribeRatio describes a compared with b in English using the default
+// formatter. See Formatter.DescribeRatio.
+func DescribeRatio(a, b float64) string {
+
+	return DefaultFormatter().DescribeRatio(a, b)
+}
+
+// DescribeRatio describes how a compares with b as an English phrase for
+// report narratives and alert messages, such as "about 3× larger",
+// "roughly half" or "about 20% smaller", with the multiplier rounded to a
+// figure a reader would use and formatted with the formatter's separators.
+// Ratios within 5% of one are "about the same", and those within 5% of a
+// half, a third, two thirds, a quarter or three quarters are named. Other
+// ratios below 1.95 are given as a percentage larger or smaller, rounded
+// to a multiple of five, and larger ratios as a multiplier, rounded to a
+// multiple of a half below ten and to two significant digits above it, up
+// to a million, beyond which they are "more than 1,000,000×" larger or
+// smaller. Equal values are "the same", and values that are not both finite and
+// positive are "not comparable".
+func (f Formatter) DescribeRatio(a, b float64) string {
+
+	if !(a > 0 && b > 0) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+
+		return "not comparable"
+	}
+
+	var (
+		r         = a / b
+		m         = r
+		direction = "larger"
+	)
+
+	if r < 1 {
+
+		m, direction = b/a, "smaller"
+
+		for _, fr := range ratioFractions {
+
+			if math.Abs(r/fr.value-1) <= 0.05 {
+
+				return "roughly " + fr.name
+			}
+		}
+	}
+
+	if m == 1 {
+
+		return "the same"
+	}
+
+	if m < 1.05 {
+
+		return "about the same"
+	}
+
+	// Describe small differences as percentages
+	if m < 1.95 {
+
+		pct := 5 * math.Round(math.Abs(r-1)*20)
+
+		return "about " + f.FormatFloat(pct, 0) + "% " + direction
+	}
+
+	// Cap multipliers too large to be meaningful, or that overflow
+	if m > maxRatioMultiplier {
+
+		return "more than " + f.FormatFloat(maxRatioMultiplier, 0) + "× " + direction
+	}
+
+	// Round the multiplier to a half below ten and to two significant
+	// digits above it
+	var (
+		multiplier float64
+		places     int
+	)
+
+	if m < 9.75 {
+
+		multiplier = math.Round(m*2) / 2
+
+		if multiplier != math.Trunc(multiplier) {
+
+			places = 1
+		}
+
+	} else {
+
+		if places = 2 - len(strconv.FormatFloat(math.Floor(m), 'f', 0, 64)); places > 0 {
+
+			places = 0
+		}
+
+		multiplier = RoundFloat(m, places)
+	}
+
+	return "about " + f.FormatFloat(multiplier, places) + "× " + direction
+}
@@ -0,0 +1,80 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test DescribeRatio with ratios of every kind of phrase
+func TestDescribeRatio(t *testing.T) {
+
+	inputs := [][2]float64{
+		{5, 5},
+		{102, 100},
+		{96, 100},
+		{120, 100},
+		{60, 100},
+		{50, 100},
+		{34, 100},
+		{65, 100},
+		{24, 100},
+		{76, 100},
+		{3, 1},
+		{2.3, 1},
+		{9.9, 1},
+		{123, 1},
+		{1, 3},
+		{1, 1234},
+		{999999, 1},
+		{1e-320, 1e10},
+		{1, 1e308},
+		{1e308, 1e-10},
+		{0, 1},
+		{-2, 1},
+		{1, math.Inf(1)},
+		{math.NaN(), 1},
+	}
+
+	expected := []string{
+		"the same",
+		"about the same",
+		"about the same",
+		"about 20% larger",
+		"about 40% smaller",
+		"roughly half",
+		"roughly a third",
+		"roughly two thirds",
+		"roughly a quarter",
+		"roughly three quarters",
+		"about 3× larger",
+		"about 2.5× larger",
+		"about 10× larger",
+		"about 120× larger",
+		"roughly a third",
+		"about 1,200× smaller",
+		"about 1,000,000× larger",
+		"more than 1,000,000× smaller",
+		"more than 1,000,000× smaller",
+		"more than 1,000,000× larger",
+		"not comparable",
+		"not comparable",
+		"not comparable",
+		"not comparable",
+	}
+
+	for i, in := range inputs {
+
+		if output := DescribeRatio(in[0], in[1]); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing DescribeRatio(%v, %v)",
+				expected[i], output, in[0], in[1])
+		}
+	}
+
+	f := Formatter{GroupSeparator: ".", DecimalSeparator: ","}
+
+	if output := f.DescribeRatio(2.6, 1); output != "about 2,5× larger" {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.DescribeRatio", "about 2,5× larger", output)
+	}
+}
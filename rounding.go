@@ -0,0 +1,393 @@
+package decimals
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode determines how a number is rounded when it falls between two
+// values at the given precision. The zero value is HalfUp, the mode used by
+// RoundInt, RoundFloat and the Format functions.
+type RoundingMode int
+
+const (
+	// HalfUp rounds to the nearest value, with ties rounded away from zero.
+	HalfUp RoundingMode = iota
+
+	// HalfEven rounds to the nearest value, with ties rounded to the even
+	// neighbour. This is also known as banker's rounding.
+	HalfEven
+
+	// HalfDown rounds to the nearest value, with ties rounded toward zero.
+	HalfDown
+
+	// Up rounds away from zero.
+	Up
+
+	// Down rounds toward zero, truncating the number.
+	Down
+
+	// Ceiling rounds toward positive infinity.
+	Ceiling
+
+	// Floor rounds toward negative infinity.
+	Floor
+)
+
+// Names of the rounding modes used for text encoding
+var roundingModeNames = []string{
+	"half-up", "half-even", "half-down", "up", "down", "ceiling", "floor",
+}
+
+// String returns the name of the rounding mode, such as "half-even".
+func (m RoundingMode) String() string {
+
+	if m < 0 || int(m) >= len(roundingModeNames) {
+
+		return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+	}
+
+	return roundingModeNames[m]
+}
+
+// MarshalText implements encoding.TextMarshaler so rounding modes can be
+// stored by name in configuration files.
+func (m RoundingMode) MarshalText() ([]byte, error) {
+
+	if m < 0 || int(m) >= len(roundingModeNames) {
+
+		return nil, fmt.Errorf("decimals: invalid rounding mode %d", int(m))
+	}
+
+	return []byte(roundingModeNames[m]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the names
+// returned by String in any case.
+func (m *RoundingMode) UnmarshalText(text []byte) error {
+
+	for i, name := range roundingModeNames {
+
+		if strings.EqualFold(string(text), name) {
+
+			*m = RoundingMode(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("decimals: unknown rounding mode %q", text)
+}
+
+// RoundIntMode rounds a base ten int64 to the given precision using the
+// given rounding mode. Precision is interpreted as for RoundInt, and the
+// result is limited to the minimum and maximum for int64 in the same way.
+func RoundIntMode(x int64, precision int, mode RoundingMode) int64 {
+
+	// If precision is not negative return x
+	if precision > -1 {
+
+		return x
+	}
+
+	// Int64 saturates on overflow
+	r, _ := DecimalFromInt(x).Round(precision, mode).Int64()
+
+	return r
+}
+
+// RoundFloatMode rounds a base ten float64 to the given decimal precision
+// using the given rounding mode. Precision is interpreted as for RoundFloat.
+// Rounding is applied to the shortest decimal representation of the float,
+// the digits printed by strconv.FormatFloat(x, 'f', -1, 64), so 2.675 is
+// rounded as the decimal 2.675 would be. Use RoundFloatExact to round the
+// exact value of the float's binary representation instead.
+func RoundFloatMode(x float64, precision int, mode RoundingMode) float64 {
+
+	// Zero, infinities, NaN and exact integers rounded to a whole number of
+	// places are unchanged by rounding
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) || IsExactInt(x) && precision >= 0 {
+
+		return x
+	}
+
+	d, _ := DecimalFromFloat(x)
+
+	return roundedFloat(x, d.Round(precision, mode))
+}
+
+// RoundFloatExact rounds a base ten float64 to the given decimal precision
+// using the given rounding mode, applied to the exact decimal value of the
+// float's binary representation. Many decimals are stored as a float just
+// below or above their true value, so RoundFloatExact(2.675, 2, HalfUp)
+// returns 2.67, because the float nearest to 2.675 is slightly less than
+// it. This matches strconv and most other languages, whereas RoundFloatMode
+// returns 2.68 as a reader of the decimal would expect.
+func RoundFloatExact(x float64, precision int, mode RoundingMode) float64 {
+
+	// Zero, infinities, NaN and exact integers rounded to a whole number of
+	// places are unchanged by rounding
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) || IsExactInt(x) && precision >= 0 {
+
+		return x
+	}
+
+	d, _ := decimalFromString(exactDecimal(x))
+
+	return roundedFloat(x, d.Round(precision, mode))
+}
+
+// RoundNeverExceed rounds a float64 down to the given decimal precision,
+// as RoundFloatMode does with Floor, and guarantees that the result is
+// never greater than x, for billing code that must not round a charge
+// upward. RoundNeverExceed(2.675, 2) returns 2.67 and RoundNeverExceed(0.3,
+// 1) returns 0.3. NaN is returned unchanged.
+func RoundNeverExceed(x float64, precision int) float64 {
+
+	r := RoundFloatMode(x, precision, Floor)
+
+	// The float nearest a decimal below the shortest decimal of x is never
+	// above x, but check the guarantee and fall back to rounding the exact
+	// value of x, which is always below it
+	if r > x {
+
+		r = RoundFloatExact(x, precision, Floor)
+	}
+
+	return r
+}
+
+// RoundNeverBelow rounds a float64 up to the given decimal precision, as
+// RoundFloatMode does with Ceiling, and guarantees that the result is
+// never less than x, for amounts such as refunds and credits that must not
+// be rounded downward. NaN is returned unchanged.
+func RoundNeverBelow(x float64, precision int) float64 {
+
+	r := RoundFloatMode(x, precision, Ceiling)
+
+	// Check the guarantee as RoundNeverExceed does
+	if r < x {
+
+		r = RoundFloatExact(x, precision, Ceiling)
+	}
+
+	return r
+}
+
+// RoundThreshold rounds a float64 to the given decimal precision, rounding
+// away from zero when the fraction of the last place kept that is
+// discarded is at least the threshold, and toward zero otherwise, for
+// domains such as pharmacy dosing and grading whose rules do not round at
+// a half. A threshold of 0.5 rounds half up as RoundFloat does, while with
+// 0.6 RoundThreshold(1.25, 1, 0.6) returns 1.2 and RoundThreshold(1.26, 1,
+// 0.6) returns 1.3. The fraction is compared by the shortest decimals of x
+// and the threshold. It panics if the threshold is not from 0 to 1, so
+// thresholds read from configuration should be checked with
+// RoundThresholdE.
+func RoundThreshold(x float64, precision int, threshold float64) float64 {
+
+	r, err := RoundThresholdE(x, precision, threshold)
+
+	if err != nil {
+
+		panic("decimals: RoundThreshold with threshold outside 0 to 1")
+	}
+
+	return r
+}
+
+// RoundThresholdE rounds a float64 as RoundThreshold does, and returns an
+// error wrapping ErrRange instead of panicking if the threshold is NaN or
+// not from 0 to 1. x is then returned unchanged.
+func RoundThresholdE(x float64, precision int, threshold float64) (float64, error) {
+
+	if !(threshold >= 0 && threshold <= 1) {
+
+		return x, &NumError{"RoundThresholdE", strconv.FormatFloat(threshold, 'g', -1, 64), ErrRange}
+	}
+
+	// Zero, infinities, NaN and exact integers rounded to a whole number of
+	// places are unchanged by rounding
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) || IsExactInt(x) && precision >= 0 {
+
+		return x, nil
+	}
+
+	var (
+		d, _    = DecimalFromFloat(x)
+		t, _    = DecimalFromFloat(threshold)
+		r       = d.Round(precision, Down)
+		discard = Sum([]Decimal{d.Abs(), r.Abs().Neg()})
+	)
+
+	// Measure the discarded fraction in units of the last place kept
+	if discard.digits != "" {
+
+		discard.exponent += precision
+	}
+
+	if discard.Sign() > 0 && discard.Cmp(t) >= 0 {
+
+		r = d.Round(precision, Up)
+	}
+
+	return roundedFloat(x, r), nil
+}
+
+// exactDecimal returns the exact decimal expansion of the finite float x.
+func exactDecimal(x float64) string {
+
+	// x is a multiple of 2^(exp-53), which needs 53-exp decimal places
+	_, exp := math.Frexp(x)
+	places := 53 - exp
+
+	if places < 0 {
+
+		places = 0
+
+	} else if places > 1074 {
+
+		places = 1074
+	}
+
+	return strconv.FormatFloat(x, 'f', places, 64)
+}
+
+// roundDigits rounds the unsigned decimal digits in the slice to the first
+// n digits using mode, setting the digits that follow to zero. The slice is
+// modified in place. If rounding carries out of the leading digit a one is
+// prepended. The index n must be less than the length of the slice.
+func roundDigits(digits []byte, n int, negative bool, mode RoundingMode) []byte {
+
+	var (
+		roundDigit byte = digits[n] - '0'
+		sticky     bool
+		odd        bool
+	)
+
+	// Note whether any digits after the rounding digit are non-zero
+	for _, d := range digits[n+1:] {
+
+		if d != '0' {
+
+			sticky = true
+			break
+		}
+	}
+
+	// Note whether the last digit kept is odd
+	if n > 0 {
+
+		odd = (digits[n-1]-'0')%2 == 1
+	}
+
+	up := roundsUp(mode, negative, roundDigit, sticky, odd)
+
+	// Zero all digits from the rounding point
+	for i := n; i < len(digits); i++ {
+
+		digits[i] = '0'
+	}
+
+	if !up {
+
+		return digits
+	}
+
+	// Otherwise move left carrying into the digits that are kept
+	i := n - 1
+
+	for ; i >= 0 && digits[i] == '9'; i-- {
+
+		digits[i] = '0'
+	}
+
+	// If the carry runs off the leading digit add a leading one
+	if i < 0 {
+
+		return append([]byte{'1'}, digits...)
+	}
+
+	digits[i]++
+
+	return digits
+}
+
+// roundsUp reports whether a number should be rounded away from zero when
+// the first discarded digit is roundDigit. Sticky reports whether any later
+// discarded digits are non-zero, and odd whether the last kept digit is odd.
+func roundsUp(mode RoundingMode, negative bool, roundDigit byte, sticky, odd bool) bool {
+
+	// Exact values are never rounded
+	if roundDigit == 0 && !sticky {
+
+		return false
+	}
+
+	switch mode {
+
+	case HalfEven:
+
+		return roundDigit > 5 || roundDigit == 5 && (sticky || odd)
+
+	case HalfDown:
+
+		return roundDigit > 5 || roundDigit == 5 && sticky
+
+	case Up:
+
+		return true
+
+	case Down:
+
+		return false
+
+	case Ceiling:
+
+		return !negative
+
+	case Floor:
+
+		return negative
+
+	default:
+
+		return roundDigit >= 5
+	}
+}
+
+// SignAt returns the sign of x once rounded half up to the given precision:
+// -1 if it is negative, 0 if it rounds to zero and 1 if it is positive.
+// Values that display as zero are treated as zero, so a UI can avoid
+// showing "-0.00" or colouring a change of -0.0001% as a loss. Precision
+// is interpreted as for RoundFloat. SignAt returns 0 for NaN.
+func SignAt(x float64, precision int) int {
+
+	d, err := DecimalFromFloat(x)
+
+	if err != nil {
+
+		switch {
+
+		case math.IsInf(x, 1):
+
+			return 1
+
+		case math.IsInf(x, -1):
+
+			return -1
+		}
+
+		return 0
+	}
+
+	return d.Round(precision, HalfUp).Sign()
+}
+
+// IsZeroAt reports whether x rounds half up to zero at the given precision,
+// so that it is formatted as zero. It is false for NaN.
+func IsZeroAt(x float64, precision int) bool {
+
+	return !math.IsNaN(x) && SignAt(x, precision) == 0
+}
@@ -0,0 +1,525 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Test RoundIntMode with each rounding mode
+func TestRoundIntMode(t *testing.T) {
+
+	inputs := []int64{250, 251, 350, -250, -251, -350, 201, -201, 5}
+
+	modes := []RoundingMode{HalfUp, HalfEven, HalfDown, Up, Down, Ceiling, Floor}
+
+	expected := []int64{
+		300, 200, 200, 300, 200, 300, 200,
+		300, 300, 300, 300, 200, 300, 200,
+		400, 400, 300, 400, 300, 400, 300,
+		-300, -200, -200, -300, -200, -200, -300,
+		-300, -300, -300, -300, -200, -200, -300,
+		-400, -400, -300, -400, -300, -300, -400,
+		200, 200, 200, 300, 200, 300, 200,
+		-200, -200, -200, -300, -200, -200, -300,
+		0, 0, 0, 100, 0, 100, 0,
+	}
+
+	for i, n := range inputs {
+
+		for j, m := range modes {
+
+			output := RoundIntMode(n, -2, m)
+			index := (i * len(modes)) + j
+
+			if output != expected[index] {
+
+				t.Errorf("Expected: %d but received: %d testing RoundIntMode(%d, -2, %v)",
+					expected[index], output, n, m)
+			}
+		}
+	}
+}
+
+// Test RoundIntMode saturates at the int64 limits
+func TestRoundIntModeLimits(t *testing.T) {
+
+	inputs := []int64{9223372036854775801, -9223372036854775801, 1, -1}
+
+	precisions := []int{-1, -1, -25, -25}
+
+	expected := []int64{
+		9223372036854775807,
+		-9223372036854775808,
+		9223372036854775807,
+		-9223372036854775808,
+	}
+
+	for i, n := range inputs {
+
+		output := RoundIntMode(n, precisions[i], Up)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %d but received: %d testing RoundIntMode",
+				expected[i], output)
+		}
+	}
+}
+
+// Test RoundFloatMode with each rounding mode
+func TestRoundFloatMode(t *testing.T) {
+
+	inputs := []float64{2.5, 3.5, -2.5, 0.125, 1.1, -1.1, 2.675}
+
+	modes := []RoundingMode{HalfUp, HalfEven, HalfDown, Up, Down, Ceiling, Floor}
+
+	precisions := []int{0, 0, 0, 2, 0, 0, 2}
+
+	expected := []float64{
+		3, 2, 2, 3, 2, 3, 2,
+		4, 4, 3, 4, 3, 4, 3,
+		-3, -2, -2, -3, -2, -2, -3,
+		0.13, 0.12, 0.12, 0.13, 0.12, 0.13, 0.12,
+		1, 1, 1, 2, 1, 2, 1,
+		-1, -1, -1, -2, -1, -1, -2,
+		2.68, 2.68, 2.67, 2.68, 2.67, 2.68, 2.67,
+	}
+
+	for i, n := range inputs {
+
+		for j, m := range modes {
+
+			output := RoundFloatMode(n, precisions[i], m)
+			index := (i * len(modes)) + j
+
+			if output != expected[index] {
+
+				t.Errorf("Expected: %v but received: %v testing RoundFloatMode(%v, %d, %v)",
+					expected[index], output, n, precisions[i], m)
+			}
+		}
+	}
+}
+
+// Test RoundingMode text encoding round trips
+func TestRoundingModeText(t *testing.T) {
+
+	for m := HalfUp; m <= Floor; m++ {
+
+		text, err := m.MarshalText()
+
+		if err != nil {
+
+			t.Errorf("Unexpected error: %v marshalling %v", err, m)
+		}
+
+		var output RoundingMode
+
+		if err := output.UnmarshalText(text); err != nil || output != m {
+
+			t.Errorf("Expected: %v but received: %v (%v) unmarshalling %q",
+				m, output, err, text)
+		}
+	}
+
+	var output RoundingMode
+
+	if err := output.UnmarshalText([]byte("HALF-EVEN")); err != nil || output != HalfEven {
+
+		t.Errorf("Expected: half-even but received: %v (%v) testing UnmarshalText",
+			output, err)
+	}
+
+	if err := output.UnmarshalText([]byte("sideways")); err == nil {
+
+		t.Errorf("Expected an error unmarshalling an unknown rounding mode")
+	}
+}
+
+// Test RoundFloatMode honours the mode at negative precisions, including
+// the fractional part discarded by the rounding
+func TestRoundFloatModeNegativePrecision(t *testing.T) {
+
+	inputs := []float64{250, 350, 250.5, 249.5, -250, -250.5, 200.5, -200.5, 5, 1e20}
+
+	modes := []RoundingMode{HalfUp, HalfEven, HalfDown, Up, Down, Ceiling, Floor}
+
+	expected := []float64{
+		300, 200, 200, 300, 200, 300, 200,
+		400, 400, 300, 400, 300, 400, 300,
+		300, 300, 300, 300, 200, 300, 200,
+		200, 200, 200, 300, 200, 300, 200,
+		-300, -200, -200, -300, -200, -200, -300,
+		-300, -300, -300, -300, -200, -200, -300,
+		200, 200, 200, 300, 200, 300, 200,
+		-200, -200, -200, -300, -200, -200, -300,
+		0, 0, 0, 100, 0, 100, 0,
+		1e20, 1e20, 1e20, 1e20, 1e20, 1e20, 1e20,
+	}
+
+	for i, n := range inputs {
+
+		for j, m := range modes {
+
+			output := RoundFloatMode(n, -2, m)
+			index := (i * len(modes)) + j
+
+			if output != expected[index] {
+
+				t.Errorf("Expected: %v but received: %v testing RoundFloatMode(%v, -2, %v)",
+					expected[index], output, n, m)
+			}
+		}
+	}
+}
+
+// Test RoundFloatExact rounds the exact binary value while RoundFloatMode
+// rounds the shortest decimal representation
+func TestRoundFloatExact(t *testing.T) {
+
+	inputs := []float64{2.675, 1.005, 0.125, 1.15, 2.5, -2.675}
+
+	expected := []float64{2.67, 1, 0.13, 1.1, 3, -2.67}
+
+	shortest := []float64{2.68, 1.01, 0.13, 1.2, 3, -2.68}
+
+	precisions := []int{2, 2, 2, 1, 0, 2}
+
+	for i, n := range inputs {
+
+		output := RoundFloatExact(n, precisions[i], HalfUp)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v testing RoundFloatExact(%v, %d)",
+				expected[i], output, n, precisions[i])
+		}
+
+		output = RoundFloat(n, precisions[i])
+
+		if output != shortest[i] {
+
+			t.Errorf("Expected: %v but received: %v testing RoundFloat(%v, %d)",
+				shortest[i], output, n, precisions[i])
+		}
+	}
+}
+
+// Test RoundNeverExceed and RoundNeverBelow with a range of values
+func TestRoundNeverExceed(t *testing.T) {
+
+	// Add at run time, as the constant 0.1 + 0.2 is exactly 0.3
+	tenth, fifth := 0.1, 0.2
+
+	inputs := []float64{2.675, 0.3, 19.999, -2.675, 1234.5, tenth + fifth}
+
+	precisions := []int{2, 1, 2, 2, -2, 2}
+
+	below := []float64{2.67, 0.3, 19.99, -2.68, 1200, 0.3}
+
+	above := []float64{2.68, 0.3, 20, -2.67, 1300, 0.31}
+
+	for i, n := range inputs {
+
+		if output := RoundNeverExceed(n, precisions[i]); output != below[i] {
+
+			t.Errorf("Expected: %v but received: %v testing RoundNeverExceed(%v, %d)",
+				below[i], output, n, precisions[i])
+		}
+
+		if output := RoundNeverBelow(n, precisions[i]); output != above[i] {
+
+			t.Errorf("Expected: %v but received: %v testing RoundNeverBelow(%v, %d)",
+				above[i], output, n, precisions[i])
+		}
+	}
+}
+
+// Test RoundNeverExceed never rounds up and RoundNeverBelow never rounds
+// down, and that both results are already rounded to the precision
+func TestRoundNeverExceedInvariants(t *testing.T) {
+
+	var (
+		xs         = monotonicFloats()
+		precisions = []int{-20, -10, -3, -1, 0, 1, 2, 3, 5, 10, 17}
+	)
+
+	for _, p := range precisions {
+
+		for _, x := range xs {
+
+			down, up := RoundNeverExceed(x, p), RoundNeverBelow(x, p)
+
+			if down > x || up < x {
+
+				t.Errorf("Expected: %v <= %v <= %v testing RoundNeverExceed and RoundNeverBelow(%v, %d)",
+					down, x, up, x, p)
+			}
+
+			if RoundFloat(down, p) != down && !math.IsInf(down, 0) ||
+				RoundFloat(up, p) != up && !math.IsInf(up, 0) {
+
+				t.Errorf("Expected: %v and %v rounded to %d places testing RoundNeverExceed and RoundNeverBelow(%v)",
+					down, up, p, x)
+			}
+		}
+	}
+}
+
+// monotonicFloats returns a sorted sample of finite floats for property
+// tests, mixing random values of every magnitude with values near ties,
+// the limit of exact integers and the limits of int64, and the floats
+// either side of each.
+func monotonicFloats() []float64 {
+
+	var (
+		rng = rand.New(rand.NewSource(1))
+		xs  = []float64{
+			0, 0.5, 1.5, 2.5, 0.005, 2.675, 1e300, 5e-324, math.MaxFloat64,
+			1 << 53, 1<<53 + 2, 1 << 62, 1 << 63, 1 << 64, 9.2233720368547e18,
+		}
+	)
+
+	for i := 0; i < 200; i++ {
+
+		xs = append(xs, rng.Float64()*math.Pow10(rng.Intn(40)-15))
+		xs = append(xs, float64(rng.Int63()))
+		xs = append(xs, float64(rng.Intn(20000))/8)
+	}
+
+	// Add the negations and the neighbouring floats
+	for _, x := range xs {
+
+		xs = append(xs, -x)
+	}
+
+	for _, x := range xs {
+
+		xs = append(xs, math.Nextafter(x, math.Inf(1)), math.Nextafter(x, math.Inf(-1)))
+	}
+
+	for i := 0; i < len(xs); i++ {
+
+		if math.IsInf(xs[i], 0) {
+
+			xs = append(xs[:i], xs[i+1:]...)
+			i--
+		}
+	}
+
+	sort.Float64s(xs)
+
+	return xs
+}
+
+// Test RoundThreshold with a range of values and thresholds
+func TestRoundThreshold(t *testing.T) {
+
+	inputs := []float64{1.25, 1.26, -1.25, -1.26, 2.675, 1250, 1260, 0.19, 0.11, 0.1, 5, math.Inf(-1)}
+
+	precisions := []int{1, 1, 1, 1, 2, -2, -2, 1, 1, 1, 0, 1}
+
+	thresholds := []float64{0.6, 0.6, 0.6, 0.6, 0.5, 0.6, 0.6, 1, 0, 0, 0.6, 0.6}
+
+	expected := []float64{1.2, 1.3, -1.2, -1.3, 2.68, 1200, 1300, 0.1, 0.2, 0.1, 5, math.Inf(-1)}
+
+	for i, n := range inputs {
+
+		if output := RoundThreshold(n, precisions[i], thresholds[i]); output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v testing RoundThreshold(%v, %d, %v)",
+				expected[i], output, n, precisions[i], thresholds[i])
+		}
+	}
+
+	// A threshold of a half rounds as RoundFloat does
+	for _, x := range monotonicFloats() {
+
+		for p := -3; p <= 3; p++ {
+
+			if a, b := RoundThreshold(x, p, 0.5), RoundFloat(x, p); a != b {
+
+				t.Fatalf("Expected: %v but received: %v testing RoundThreshold(%v, %d, 0.5)", b, a, x, p)
+			}
+		}
+	}
+}
+
+// Test RoundThreshold panics with a threshold outside 0 to 1
+func TestRoundThresholdPanics(t *testing.T) {
+
+	defer func() {
+
+		if recover() == nil {
+
+			t.Errorf("Expected: a panic but received: none testing RoundThreshold")
+		}
+	}()
+
+	RoundThreshold(1.25, 1, 1.5)
+}
+
+// Test RoundThresholdE reports thresholds outside 0 to 1
+func TestRoundThresholdE(t *testing.T) {
+
+	if output, err := RoundThresholdE(1.26, 1, 0.6); err != nil || output != 1.3 {
+
+		t.Errorf("Expected: 1.3 but received: %v (%v) testing RoundThresholdE", output, err)
+	}
+
+	for _, threshold := range []float64{-0.1, 1.5, math.NaN(), math.Inf(1)} {
+
+		if output, err := RoundThresholdE(1.25, 1, threshold); !errors.Is(err, ErrRange) || output != 1.25 {
+
+			t.Errorf("Expected: 1.25 and ErrRange but received: %v (%v) testing RoundThresholdE(1.25, 1, %v)",
+				output, err, threshold)
+		}
+	}
+}
+
+// Test rounding floats is monotonic, so that x <= y implies that x rounds
+// to no more than y does, for every mode and precision
+func TestRoundFloatMonotonic(t *testing.T) {
+
+	var (
+		xs         = monotonicFloats()
+		modes      = []RoundingMode{HalfUp, HalfEven, HalfDown, Up, Down, Ceiling, Floor}
+		precisions = []int{-20, -19, -18, -16, -10, -3, -1, 0, 1, 2, 5, 10, 17}
+	)
+
+	for _, m := range modes {
+
+		for _, p := range precisions {
+
+			for i := 1; i < len(xs); i++ {
+
+				a, b := RoundFloatMode(xs[i-1], p, m), RoundFloatMode(xs[i], p, m)
+
+				if a > b {
+
+					t.Errorf("Expected: %v <= %v testing RoundFloatMode(%v, %d, %v) and RoundFloatMode(%v, %d, %v)",
+						a, b, xs[i-1], p, m, xs[i], p, m)
+				}
+
+				a, b = RoundFloatExact(xs[i-1], p, m), RoundFloatExact(xs[i], p, m)
+
+				if a > b {
+
+					t.Errorf("Expected: %v <= %v testing RoundFloatExact(%v, %d, %v) and RoundFloatExact(%v, %d, %v)",
+						a, b, xs[i-1], p, m, xs[i], p, m)
+				}
+			}
+		}
+	}
+}
+
+// Test formatted floats sort in the same numeric order as the floats
+func TestFormatFloatMonotonic(t *testing.T) {
+
+	var (
+		xs         = monotonicFloats()
+		precisions = []int{-19, -3, 0, 2, 10}
+	)
+
+	for _, p := range precisions {
+
+		prev := MustParseDecimal(Formatter{}.FormatFloat(xs[0], p))
+
+		for _, x := range xs[1:] {
+
+			s := Formatter{}.FormatFloat(x, p)
+			d := MustParseDecimal(s)
+
+			if prev.Cmp(d) > 0 {
+
+				t.Errorf("Expected: %s <= %s testing FormatFloat(%v, %d)", prev, d, x, p)
+			}
+
+			prev = d
+		}
+	}
+}
+
+// Test rounding ints is monotonic up to the limits of int64, and that the
+// int and float paths agree on integers that floats hold exactly
+func TestRoundIntMonotonic(t *testing.T) {
+
+	var (
+		rng   = rand.New(rand.NewSource(1))
+		plain = Formatter{}
+		modes = []RoundingMode{HalfUp, HalfEven, HalfDown, Up, Down, Ceiling, Floor}
+		xs    = []int64{math.MinInt64, math.MinInt64 + 1, -1, 0, 1, math.MaxInt64 - 1, math.MaxInt64}
+	)
+
+	for i := 0; i < 500; i++ {
+
+		xs = append(xs, rng.Int63(), -rng.Int63(), rng.Int63n(1<<53), -rng.Int63n(1<<53), rng.Int63n(100000))
+	}
+
+	sort.Slice(xs, func(i, j int) bool {
+
+		return xs[i] < xs[j]
+	})
+
+	for _, m := range modes {
+
+		for p := -19; p <= 0; p++ {
+
+			for i := 1; i < len(xs); i++ {
+
+				a, b := RoundIntMode(xs[i-1], p, m), RoundIntMode(xs[i], p, m)
+
+				if a > b {
+
+					t.Errorf("Expected: %d <= %d testing RoundIntMode(%d, %d, %v) and RoundIntMode(%d, %d, %v)",
+						a, b, xs[i-1], p, m, xs[i], p, m)
+				}
+
+				// Ints saturate at the limits of int64 where floats do not
+				x := xs[i]
+
+				if x < -1<<53 || x > 1<<53 || b == math.MinInt64 || b == math.MaxInt64 {
+
+					continue
+				}
+
+				if f := RoundFloatMode(float64(x), p, m); f != float64(b) {
+
+					t.Errorf("Expected: %d but received: %v testing RoundFloatMode(%d, %d, %v)", b, f, x, p, m)
+				}
+
+				if m == HalfUp && plain.FormatInt(x, p) != plain.FormatFloat(float64(x), p) {
+
+					t.Errorf("Expected: %q but received: %q testing FormatFloat(%d, %d)",
+						plain.FormatInt(x, p), plain.FormatFloat(float64(x), p), x, p)
+				}
+			}
+		}
+	}
+}
+
+// Test SignAt and IsZeroAt treat values that round to zero as zero
+func TestSignAt(t *testing.T) {
+
+	inputs := []float64{-0.004, -0.005, 0.004, 0.005, 0, math.Copysign(0, -1), -49, 50, math.Inf(-1), math.NaN()}
+	precisions := []int{2, 2, 2, 2, 2, 2, -2, -2, 2, 2}
+
+	expected := []int{0, -1, 0, 1, 0, 0, 0, 1, -1, 0}
+	expectedZero := []bool{true, false, true, false, true, true, true, false, false, false}
+
+	for i, x := range inputs {
+
+		if output := SignAt(x, precisions[i]); output != expected[i] {
+
+			t.Errorf("Expected: %d but received: %d testing SignAt(%v, %d)", expected[i], output, x, precisions[i])
+		}
+
+		if output := IsZeroAt(x, precisions[i]); output != expectedZero[i] {
+
+			t.Errorf("Expected: %v but received: %v testing IsZeroAt(%v, %d)", expectedZero[i], output, x, precisions[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInexact indicates that a result cannot be represented exactly.
+var ErrInexact = errors.New("decimals: inexact result")
+
+// MulPow10 multiplies x by 10 to the power n, for unit conversions such as
+// dollars to cents or millimeters to meters. A negative n divides x,
+// truncating toward zero, and returns ErrInexact if any non-zero digits
+// are discarded, along with the truncated result. It returns ErrRange if
+// the result overflows an int64.
+func MulPow10(x int64, n int) (int64, error) {
+
+	if x == 0 {
+
+		return 0, nil
+	}
+
+	// Divide one power at a time, noting any remainder
+	if n < 0 {
+
+		var inexact bool
+
+		for ; n < 0 && x != 0; n++ {
+
+			inexact = inexact || x%10 != 0
+			x /= 10
+		}
+
+		if inexact || n < 0 {
+
+			return x, ErrInexact
+		}
+
+		return x, nil
+	}
+
+	// Multiply one power at a time, checking for overflow before each step
+	for ; n > 0; n-- {
+
+		if x > math.MaxInt64/10 || x < math.MinInt64/10 {
+
+			return 0, ErrRange
+		}
+
+		x *= 10
+	}
+
+	return x, nil
+}
+
+// MulPow10Float multiplies x by 10 to the power n by moving the decimal
+// point of the shortest decimal representation of x, so that 1.23 times
+// 10^2 is exactly 123 rather than the 123.00000000000001 of 1.23 * 100. It
+// returns the float64 nearest to the result and reports whether it is
+// exact, meaning that it has the same shortest decimal representation as
+// the shifted digits. Results that overflow to infinity, underflow to zero
+// or need more digits than a float64 holds are not exact. Infinities are
+// returned unchanged and exact, and NaN is returned unchanged and inexact.
+func MulPow10Float(x float64, n int) (float64, bool) {
+
+	d, err := DecimalFromFloat(x)
+
+	if err != nil {
+
+		return x, !math.IsNaN(x)
+	}
+
+	if d.digits == "" {
+
+		return x, true
+	}
+
+	d.exponent += n
+	r := d.Float64()
+
+	if math.IsInf(r, 0) {
+
+		return r, false
+	}
+
+	rd, _ := DecimalFromFloat(r)
+
+	return r, rd.Cmp(d) == 0
+}
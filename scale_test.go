@@ -0,0 +1,60 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test MulPow10 scales exactly and reports overflow and lost digits
+func TestMulPow10(t *testing.T) {
+
+	var (
+		inputs = []int64{
+			125, 125, 12500, 125, -125, 0, 1, 922337203685477580, 922337203685477581, -922337203685477580,
+			-922337203685477581, 5, math.MaxInt64}
+		powers = []int{
+			0, 2, -2, -2, -1, 100, 18, 1, 1, 1, 1, -1, -100}
+		expected = []int64{
+			125, 12500, 125, 1, -12, 0, 1000000000000000000, 9223372036854775800, 0, -9223372036854775800,
+			0, 0, 0}
+		errs = []error{
+			nil, nil, nil, ErrInexact, ErrInexact, nil, nil, nil, ErrRange, nil, ErrRange, ErrInexact, ErrInexact}
+	)
+
+	for i, input := range inputs {
+
+		output, err := MulPow10(input, powers[i])
+
+		if output != expected[i] || err != errs[i] {
+
+			t.Errorf("Expected: %d (%v) but received: %d (%v) testing MulPow10(%d, %d)",
+				expected[i], errs[i], output, err, input, powers[i])
+		}
+	}
+}
+
+// Test MulPow10Float shifts the shortest decimal and reports exactness
+func TestMulPow10Float(t *testing.T) {
+
+	var (
+		inputs = []float64{
+			1.23, 0.1, 123, 1.5, -2.5e-3, 0, 1e308, 5e-324, 0.1, math.Inf(-1), math.NaN()}
+		powers = []int{
+			2, 1, -2, 400, 3, 5, 10, -1, -400, 3, 3}
+		expected = []float64{
+			123, 1, 1.23, math.Inf(1), -2.5, 0, math.Inf(1), 0, 0, math.Inf(-1), math.NaN()}
+		exact = []bool{
+			true, true, true, false, true, true, false, false, false, true, false}
+	)
+
+	for i, input := range inputs {
+
+		output, ok := MulPow10Float(input, powers[i])
+
+		if (output != expected[i] && !(math.IsNaN(output) && math.IsNaN(expected[i]))) || ok != exact[i] {
+
+			t.Errorf("Expected: %v (%t) but received: %v (%t) testing MulPow10Float(%v, %d)",
+				expected[i], exact[i], output, ok, input, powers[i])
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package decimals
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// ParseFrom reads a number from the start of r and stops at the first rune
+// that cannot continue it, which is left unread, so that tokenizers for
+// expressions and query languages can embed the number grammar. It returns
+// the number and the count of bytes consumed. The number is an optional
+// sign, digits with an optional point and fractional digits, and with
+// ParseExponent an optional exponent. Digits may be those of any script.
+// Numbers are not grouped with commas, which separate arguments in most
+// grammars. ParsePlus, ParseSpace, which skips leading whitespace, and
+// ParseUnderscore are also honoured, and the other flags are ignored.
+//
+// A rune scanner can only unread one rune, so input that ends inside the
+// grammar, such as "1e" without exponent digits, stays consumed and is
+// reported as an error wrapping ErrSyntax. An error wrapping ErrRange is
+// returned if the exponent does not fit in an int. Errors reading r other
+// than io.EOF are returned as they are.
+func ParseFrom(r io.RuneScanner, flags ParseFlag) (Decimal, int, error) {
+
+	var (
+		s      = &runeScanner{r: r}
+		buf    []byte
+		digits int
+		frac   int
+		valid  bool
+	)
+
+	// Skip leading whitespace
+	for c, ok := s.peek(); ok && flags&ParseSpace != 0 && unicode.IsSpace(c); c, ok = s.peek() {
+
+		s.take()
+	}
+
+	// Copy the sign
+	if c, ok := s.peek(); ok && (c == '-' || c == '+' && flags&ParsePlus != 0) {
+
+		buf = append(buf, byte(c))
+		s.take()
+	}
+
+	// Copy the integer and fractional parts
+	buf, digits, valid = s.digits(buf, flags)
+
+	if c, ok := s.peek(); ok && valid && c == '.' {
+
+		buf = append(buf, '.')
+		s.take()
+		buf, frac, valid = s.digits(buf, flags)
+	}
+
+	if !valid || digits+frac == 0 {
+
+		return s.fail(ErrSyntax)
+	}
+
+	// Copy the exponent
+	if c, ok := s.peek(); ok && flags&ParseExponent != 0 && (c == 'e' || c == 'E') {
+
+		buf = append(buf, 'e')
+		s.take()
+
+		if c, ok := s.peek(); ok && (c == '-' || c == '+') {
+
+			buf = append(buf, byte(c))
+			s.take()
+		}
+
+		if buf, digits, valid = s.digits(buf, flags); !valid || digits == 0 {
+
+			return s.fail(ErrSyntax)
+		}
+	}
+
+	s.release()
+
+	if s.err != nil && s.err != io.EOF {
+
+		return Decimal{}, s.n, s.err
+	}
+
+	d, ok := decimalFromString(string(buf))
+
+	if !ok {
+
+		return s.fail(ErrRange)
+	}
+
+	return d, s.n, nil
+}
+
+// A runeScanner reads runes with one rune of lookahead, counting the bytes
+// consumed and keeping their text for error messages.
+type runeScanner struct {
+	r    io.RuneScanner
+	c    rune
+	size int // the size of the rune peeked at, or zero if there is none
+	n    int
+	text strings.Builder
+	err  error
+}
+
+// peek returns the next rune without consuming it, and reports false at
+// the end of the input or on an error.
+func (s *runeScanner) peek() (rune, bool) {
+
+	if s.size > 0 {
+
+		return s.c, true
+	}
+
+	if s.err != nil {
+
+		return 0, false
+	}
+
+	c, size, err := s.r.ReadRune()
+
+	if err != nil {
+
+		s.err = err
+		return 0, false
+	}
+
+	s.c, s.size = c, size
+
+	return c, true
+}
+
+// take consumes the rune peeked at.
+func (s *runeScanner) take() {
+
+	s.n += s.size
+	s.text.WriteRune(s.c)
+	s.size = 0
+}
+
+// release unreads the rune peeked at, if it was not consumed.
+func (s *runeScanner) release() {
+
+	if s.size > 0 {
+
+		s.r.UnreadRune()
+		s.size = 0
+	}
+}
+
+// digits appends a run of digits as ASCII digits to buf and returns the
+// count of digits. With ParseUnderscore underscores are skipped between
+// digits, and it reports false if one is not followed by a digit.
+func (s *runeScanner) digits(buf []byte, flags ParseFlag) ([]byte, int, bool) {
+
+	var count int
+
+	for {
+
+		c, ok := s.peek()
+
+		if !ok {
+
+			return buf, count, true
+		}
+
+		if v, digit := digitValue(c); digit {
+
+			buf = append(buf, byte('0'+v))
+			count++
+			s.take()
+			continue
+		}
+
+		if c != '_' || flags&ParseUnderscore == 0 || count == 0 {
+
+			return buf, count, true
+		}
+
+		s.take()
+
+		if c, ok = s.peek(); !ok {
+
+			return buf, count, false
+		}
+
+		if _, digit := digitValue(c); !digit {
+
+			return buf, count, false
+		}
+	}
+}
+
+// fail unreads the rune peeked at and returns an error for the text
+// consumed, or the error reading the input if there was one.
+func (s *runeScanner) fail(err error) (Decimal, int, error) {
+
+	s.release()
+
+	if s.err != nil && s.err != io.EOF {
+
+		return Decimal{}, s.n, s.err
+	}
+
+	return Decimal{}, s.n, &NumError{"ParseFrom", s.text.String(), err}
+}
@@ -0,0 +1,107 @@
+package decimals
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// Test ParseFrom reads a number and leaves the rest of the input
+func TestParseFrom(t *testing.T) {
+
+	inputs := []string{
+		"12.5)",
+		"-3,4",
+		"1e3+x",
+		"1.5E-2 ",
+		"7.",
+		"+5",
+		"  42 rows",
+		"1_000_000;",
+		"۱۲٫5",
+		"0.25",
+		"2em",
+	}
+
+	flags := []ParseFlag{
+		ParseStrict,
+		ParseStrict,
+		ParseExponent,
+		ParseExponent,
+		ParseStrict,
+		ParsePlus,
+		ParseSpace,
+		ParseUnderscore,
+		ParseStrict,
+		ParseStrict,
+		ParseStrict,
+	}
+
+	expected := []string{"12.5", "-3", "1000", "0.015", "7", "5", "42", "1000000", "12", "0.25", "2"}
+	consumed := []int{4, 2, 3, 6, 2, 2, 4, 9, 4, 4, 1}
+	rest := []string{")", ",4", "+x", " ", "", "", " rows", ";", "٫5", "", "em"}
+
+	for i, input := range inputs {
+
+		r := strings.NewReader(input)
+		d, n, err := ParseFrom(r, flags[i])
+		remaining, _ := ioutil.ReadAll(r)
+
+		if err != nil || d.String() != expected[i] || n != consumed[i] || string(remaining) != rest[i] {
+
+			t.Errorf("Expected: %s, %d and %q but received: %s, %d and %q (%v) testing ParseFrom(%q)",
+				expected[i], consumed[i], rest[i], d, n, remaining, err, input)
+		}
+	}
+}
+
+// Test ParseFrom reports invalid numbers and how much was consumed
+func TestParseFromErrors(t *testing.T) {
+
+	var (
+		inputs   = []string{"", "x1", "-x", "1e", "1e+", "1_", "+5", ".", "1e999999999999999999999"}
+		flags    = []ParseFlag{0, 0, 0, ParseExponent, ParseExponent, ParseUnderscore, 0, 0, ParseExponent}
+		consumed = []int{0, 0, 1, 2, 3, 2, 0, 1, 23}
+		reasons  = []error{ErrSyntax, ErrSyntax, ErrSyntax, ErrSyntax, ErrSyntax, ErrSyntax, ErrSyntax, ErrSyntax, ErrRange}
+	)
+
+	for i, input := range inputs {
+
+		_, n, err := ParseFrom(strings.NewReader(input), flags[i])
+
+		if !errors.Is(err, reasons[i]) || n != consumed[i] {
+
+			t.Errorf("Expected: %v after %d bytes but received: %v after %d testing ParseFrom(%q)",
+				reasons[i], consumed[i], err, n, input)
+		}
+	}
+
+	// Errors reading the input are returned unchanged
+	r := &failingScanner{strings.NewReader("12"), io.ErrUnexpectedEOF}
+
+	if _, _, err := ParseFrom(r, 0); err != io.ErrUnexpectedEOF {
+
+		t.Errorf("Expected: %v but received: %v testing ParseFrom", io.ErrUnexpectedEOF, err)
+	}
+}
+
+// failingScanner returns an error in place of io.EOF
+type failingScanner struct {
+	*strings.Reader
+	err error
+}
+
+// ReadRune returns the scanner's error at the end of the input
+func (s *failingScanner) ReadRune() (rune, int, error) {
+
+	c, size, err := s.Reader.ReadRune()
+
+	if err == io.EOF {
+
+		err = s.err
+	}
+
+	return c, size, err
+}
@@ -0,0 +1,104 @@
+package decimals
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Superscript forms of the digits 0 to 9
+var superscriptDigits = []string{"⁰", "¹", "²", "³", "⁴", "⁵", "⁶", "⁷", "⁸", "⁹"}
+
+// SuperscriptMinus is the superscript minus sign, U+207B.
+const SuperscriptMinus = "⁻"
+
+// FormatScientific formats a float64 in typographic scientific notation
+// using the default formatter. See Formatter.FormatScientific.
+func FormatScientific(x float64, precision int) string {
+
+	return DefaultFormatter().FormatScientific(x, precision)
+}
+
+// FormatScientificASCII formats a float64 in plain ASCII scientific
+// notation using the default formatter. See Formatter.FormatScientificASCII.
+func FormatScientificASCII(x float64, precision int) string {
+
+	return DefaultFormatter().FormatScientificASCII(x, precision)
+}
+
+// FormatScientific formats a float64 in scientific notation for
+// publication, with a multiplication sign and a superscript exponent, as
+// in "1.23 × 10⁶". The coefficient has one integer digit and is rounded
+// half up to the given number of decimal places, using the formatter's
+// decimal separator. Zero is formatted with the exponent zero.
+func (f Formatter) FormatScientific(x float64, precision int) string {
+
+	return f.formatScientific(x, precision, " × 10", superscript)
+}
+
+// FormatScientificASCII formats a float64 in scientific notation as
+// FormatScientific does, using only ASCII characters, as in "1.23 x 10^6",
+// for output that cannot show the multiplication sign or superscripts.
+func (f Formatter) FormatScientificASCII(x float64, precision int) string {
+
+	return f.formatScientific(x, precision, " x 10", func(e int) string {
+
+		return "^" + strconv.Itoa(e)
+	})
+}
+
+// formatScientific formats x in scientific notation, joining the
+// coefficient to the power of ten with times and writing the exponent with
+// the function exp.
+func (f Formatter) formatScientific(x float64, precision int, times string, exp func(int) string) string {
+
+	if p, ok := f.placeholder(x); ok {
+
+		return p
+	}
+
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+
+		return f.applyTemplate(formatSpecial(x))
+	}
+
+	var (
+		d, _     = DecimalFromFloat(x)
+		exponent int
+	)
+
+	if d.digits != "" {
+
+		exponent = len(d.digits) + d.exponent - 1
+	}
+
+	// Scale the coefficient exactly, moving up a power of ten if rounding
+	// carries it to ten
+	r := Decimal{d.negative, d.digits, d.exponent - exponent}.Round(precision, HalfUp)
+
+	if r.digits != "" && len(r.digits)+r.exponent > 1 {
+
+		exponent++
+		r = Decimal{d.negative, d.digits, d.exponent - exponent}.Round(precision, HalfUp)
+	}
+
+	return f.applyTemplate(f.formatDecimal(r, precision) + times + exp(exponent))
+}
+
+// superscript writes an integer in superscript digits.
+func superscript(e int) string {
+
+	var b strings.Builder
+
+	if e < 0 {
+
+		b.WriteString(SuperscriptMinus)
+	}
+
+	for _, c := range strconv.FormatUint(absUint64(int64(e)), 10) {
+
+		b.WriteString(superscriptDigits[c-'0'])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test FormatScientific and FormatScientificASCII
+func TestFormatScientific(t *testing.T) {
+
+	inputs := []float64{1234567, 0.000123, -9.996, 5, 0, 1e-300, math.Inf(-1)}
+
+	expected := []string{
+		"1.23 × 10⁶",
+		"1.23 × 10⁻⁴",
+		"-1.00 × 10¹",
+		"5.00 × 10⁰",
+		"0.00 × 10⁰",
+		"1.00 × 10⁻³⁰⁰",
+		"-Inf",
+	}
+
+	expectedASCII := []string{
+		"1.23 x 10^6",
+		"1.23 x 10^-4",
+		"-1.00 x 10^1",
+		"5.00 x 10^0",
+		"0.00 x 10^0",
+		"1.00 x 10^-300",
+		"-Inf",
+	}
+
+	for i, input := range inputs {
+
+		if output := FormatScientific(input, 2); output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatScientific(%v, 2)", expected[i], output, input)
+		}
+
+		if output := FormatScientificASCII(input, 2); output != expectedASCII[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatScientificASCII(%v, 2)", expectedASCII[i], output, input)
+		}
+	}
+
+	f, _ := NewFormatter("de-DE")
+
+	if output := f.FormatScientific(1234567, 1); output != "1,2 × 10⁶" {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.FormatScientific", "1,2 × 10⁶", output)
+	}
+}
@@ -0,0 +1,68 @@
+package decimals
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatSeries formats a slice of float64s as a list using the default
+// formatter. See Formatter.FormatSeries.
+func FormatSeries(xs []float64, maxItems int, precision int) string {
+
+	return DefaultFormatter().FormatSeries(xs, maxItems, precision)
+}
+
+// FormatSeries formats a slice of float64s as a list, each rounded to the
+// same precision as by FormatFloat, for log lines and error messages. The
+// values are separated by commas, or by semicolons if the formatter's
+// decimal separator is a comma. If the slice has more than maxItems values
+// only the first and last are shown, around an ellipsis and followed by the
+// length of the slice, as in "1.2, 3.4, …, 9.9 (n=10,000)". The first half
+// of maxItems, rounded up, is taken from the start of the slice.
+func (f Formatter) FormatSeries(xs []float64, maxItems int, precision int) string {
+
+	var (
+		head  int = len(xs)
+		tail  int
+		items []string
+	)
+
+	if maxItems < 0 {
+
+		maxItems = 0
+	}
+
+	// Split the items shown between the start and end of the slice
+	if len(xs) > maxItems {
+
+		head = (maxItems + 1) / 2
+		tail = maxItems / 2
+	}
+
+	// Use the list separator that does not clash with the decimal separator
+	sep := ", "
+
+	if f.decimalSeparator() == "," {
+
+		sep = "; "
+	}
+
+	for _, x := range xs[:head] {
+
+		items = append(items, f.FormatFloat(x, precision))
+	}
+
+	if head == len(xs) {
+
+		return strings.Join(items, sep)
+	}
+
+	items = append(items, "…")
+
+	for _, x := range xs[len(xs)-tail:] {
+
+		items = append(items, f.FormatFloat(x, precision))
+	}
+
+	return strings.Join(items, sep) + " (n=" + f.groupInteger(strconv.Itoa(len(xs)), f.GroupSeparator) + ")"
+}
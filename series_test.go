@@ -0,0 +1,47 @@
+package decimals
+
+import (
+	"testing"
+)
+
+// Test FormatSeries with short and long slices
+func TestFormatSeries(t *testing.T) {
+
+	long := make([]float64, 10000)
+
+	for i := range long {
+
+		long[i] = float64(i) / 10
+	}
+
+	inputs := [][]float64{nil, {1.25, 2.5}, long, long, long}
+
+	maxItems := []int{3, 3, 3, 4, 0}
+
+	expected := []string{
+		"",
+		"1.3, 2.5",
+		"0.0, 0.1, …, 999.9 (n=10,000)",
+		"0.0, 0.1, …, 999.8, 999.9 (n=10,000)",
+		"… (n=10,000)",
+	}
+
+	for i, xs := range inputs {
+
+		output := FormatSeries(xs, maxItems[i], 1)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatSeries(%d items, %d, 1)",
+				expected[i], output, len(xs), maxItems[i])
+		}
+	}
+
+	f, _ := NewFormatter("de-DE")
+
+	if output := f.FormatSeries([]float64{1.5, 2.5, 3.5}, 2, 1); output != "1,5; …; 3,5 (n=3)" {
+
+		t.Errorf("Expected: %q but received: %q testing Formatter.FormatSeries",
+			"1,5; …; 3,5 (n=3)", output)
+	}
+}
@@ -0,0 +1,195 @@
+package decimals
+
+import (
+	"strings"
+)
+
+// A SignStyle marks the sign of formatted numbers with text placed before
+// and after the digits, for the conventions of accounting reports and
+// banking exports. Styles are compared by value, so new ones can be
+// defined alongside the presets. The zero value is equivalent to
+// MinusSign.
+type SignStyle struct {
+
+	// NegativePrefix and NegativeSuffix surround negative numbers.
+	NegativePrefix string
+	NegativeSuffix string
+
+	// PositivePrefix and PositiveSuffix surround zero and positive numbers.
+	PositivePrefix string
+	PositiveSuffix string
+}
+
+// Preset sign styles
+var (
+	// MinusSign marks negative numbers with a leading minus sign, as in
+	// "-1,234.50".
+	MinusSign = SignStyle{NegativePrefix: "-"}
+
+	// Parentheses encloses negative numbers in parentheses, as in
+	// "(1,234.50)", as is usual in accounting.
+	Parentheses = SignStyle{NegativePrefix: "(", NegativeSuffix: ")"}
+
+	// TrailingMinus marks negative numbers with a minus sign after the
+	// digits, as in "1,234.50-", as in many mainframe and banking exports.
+	TrailingMinus = SignStyle{NegativeSuffix: "-"}
+
+	// CreditDebit marks negative numbers as debits and other numbers as
+	// credits, as in "1,234.50 DR" and "1,234.50 CR" on bank statements.
+	CreditDebit = SignStyle{NegativeSuffix: " DR", PositiveSuffix: " CR"}
+)
+
+// ParseFloat converts a string formatted with the sign style into a
+// float64. The markers of the style are removed and the rest is parsed as
+// by the package level ParseFloat with the same flags, without a sign of
+// its own. A number without markers is accepted as positive if the style
+// has no positive markers. With ParseSpace whitespace around the markers
+// is ignored.
+func (st SignStyle) ParseFloat(s string, flags ParseFlag) (float64, error) {
+
+	rest, negative, ok := st.unmark(s, flags)
+
+	if !ok {
+
+		return 0, &NumError{"SignStyle.ParseFloat", s, ErrSyntax}
+	}
+
+	r, err := ParseFloat(rest, flags)
+
+	if err != nil {
+
+		err = &NumError{"SignStyle.ParseFloat", s, err.(*NumError).Err}
+	}
+
+	if negative {
+
+		r = -r
+	}
+
+	return r, err
+}
+
+// ParseDecimal converts a string formatted with the sign style into a
+// Decimal, as ParseFloat does but keeping every digit.
+func (st SignStyle) ParseDecimal(s string, flags ParseFlag) (Decimal, error) {
+
+	rest, negative, ok := st.unmark(s, flags)
+
+	if !ok {
+
+		return Decimal{}, &NumError{"SignStyle.ParseDecimal", s, ErrSyntax}
+	}
+
+	d, err := ParseDecimal(rest, flags)
+
+	if err != nil {
+
+		return Decimal{}, &NumError{"SignStyle.ParseDecimal", s, err.(*NumError).Err}
+	}
+
+	if negative {
+
+		d = d.Neg()
+	}
+
+	return d, nil
+}
+
+// unmark removes the sign markers of the style from s, and reports whether
+// they mark a negative number and whether s is validly marked.
+func (st SignStyle) unmark(s string, flags ParseFlag) (string, bool, bool) {
+
+	if st == (SignStyle{}) {
+
+		st = MinusSign
+	}
+
+	// Find the markers that surround s, trying the negative ones first
+	var (
+		space    = flags&ParseSpace != 0
+		negative bool
+		rest     string
+		ok       bool
+	)
+
+	if space {
+
+		s = strings.TrimSpace(s)
+	}
+
+	if rest, ok = trimMarkers(s, st.NegativePrefix, st.NegativeSuffix, space); ok {
+
+		negative = true
+
+	} else if rest, ok = trimMarkers(s, st.PositivePrefix, st.PositiveSuffix, space); !ok {
+
+		return "", false, false
+	}
+
+	// The markers carry the sign, so the number must not have its own
+	if rest == "" || rest[0] == '-' || rest[0] == '+' {
+
+		return "", false, false
+	}
+
+	return rest, negative, true
+}
+
+// trimMarkers removes the prefix and suffix from s, ignoring whitespace
+// around them if space is true. It reports false if s does not have both.
+func trimMarkers(s, prefix, suffix string, space bool) (string, bool) {
+
+	if space {
+
+		prefix, suffix = strings.TrimSpace(prefix), strings.TrimSpace(suffix)
+	}
+
+	if len(s) < len(prefix)+len(suffix) || !strings.HasPrefix(s, prefix) || !strings.HasSuffix(s, suffix) {
+
+		return "", false
+	}
+
+	s = s[len(prefix) : len(s)-len(suffix)]
+
+	if space {
+
+		s = strings.TrimSpace(s)
+	}
+
+	return s, true
+}
+
+// applySign replaces the leading minus sign of a formatted number with the
+// formatter's sign style.
+func (f Formatter) applySign(s string) string {
+
+	if strings.HasPrefix(s, "-") {
+
+		prefix, suffix := f.signMarkers(true)
+
+		return prefix + s[1:] + suffix
+	}
+
+	prefix, suffix := f.signMarkers(false)
+
+	return prefix + s + suffix
+}
+
+// signMarkers returns the text that the formatter's sign style places
+// before and after the digits of a negative or other number.
+func (f Formatter) signMarkers(negative bool) (string, string) {
+
+	st := f.SignStyle
+
+	if st == (SignStyle{}) {
+
+		st = MinusSign
+	}
+
+	if negative {
+
+		return st.NegativePrefix, st.NegativeSuffix
+	}
+
+	return st.PositivePrefix, st.PositiveSuffix
+}
@@ -0,0 +1,100 @@
+package decimals
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test each sign style marks formatted numbers
+func TestSignStyle(t *testing.T) {
+
+	var (
+		arrows = SignStyle{NegativePrefix: "▼", PositivePrefix: "▲"}
+		f      = Formatter{GroupSeparator: ","}
+	)
+
+	with := func(st SignStyle, template string) Formatter {
+
+		g := f
+		g.SignStyle, g.Template = st, template
+
+		return g
+	}
+
+	inputs := []string{
+		f.FormatFloat(-1234.5, 2),
+		with(MinusSign, "").FormatFloat(-1234.5, 2),
+		with(Parentheses, "").FormatFloat(-1234.5, 2),
+		with(TrailingMinus, "").FormatFloat(-1234.5, 2),
+		with(CreditDebit, "").FormatFloat(-1234.5, 2),
+		with(CreditDebit, "").FormatFloat(0.004, 2),
+		with(Parentheses, "{} USD").FormatFloat(-1234.5, 2),
+		with(Parentheses, "").FormatPercent(-0.125, 1),
+		with(Parentheses, "").FormatWithSpec(-1234.5, FormatSpec{Precision: 1, Grouping: true, Width: 10}),
+		with(arrows, "").FormatFloat(-1234.5, 2),
+		with(arrows, "").FormatInt(5, 0),
+	}
+
+	expected := []string{
+		"-1,234.50",
+		"-1,234.50",
+		"(1,234.50)",
+		"1,234.50-",
+		"1,234.50 DR",
+		"0.00 CR",
+		"(1,234.50) USD",
+		"(12.5%)",
+		" (1,234.5)",
+		"▼1,234.50",
+		"▲5",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing SignStyle", expected[i], output)
+		}
+	}
+}
+
+// Test sign styles parse their own output
+func TestSignStyleParse(t *testing.T) {
+
+	var (
+		styles = []SignStyle{
+			{}, Parentheses, Parentheses, TrailingMinus, TrailingMinus, CreditDebit, CreditDebit, CreditDebit,
+			Parentheses, Parentheses, CreditDebit, Parentheses}
+		inputs = []string{
+			"-1,234.5", "(1,234.5)", "1,234.5", "1,234.5-", "7", "1,234.5 DR", "1,234.5 CR", " 2 dr ",
+			"(-1)", "(1", "5", "( 2 )"}
+		flags = []ParseFlag{
+			0, 0, 0, 0, 0, 0, 0, ParseSpace,
+			0, 0, 0, ParseSpace}
+		expected = []float64{
+			-1234.5, -1234.5, 1234.5, -1234.5, 7, -1234.5, 1234.5, 0,
+			0, 0, 0, -2}
+		errs = []error{
+			nil, nil, nil, nil, nil, nil, nil, ErrSyntax,
+			ErrSyntax, ErrSyntax, ErrSyntax, nil}
+	)
+
+	for i, input := range inputs {
+
+		output, err := styles[i].ParseFloat(input, flags[i])
+
+		if output != expected[i] || !errors.Is(err, errs[i]) || (err == nil) != (errs[i] == nil) {
+
+			t.Errorf("Expected: %v (%v) but received: %v (%v) testing SignStyle.ParseFloat(%q)",
+				expected[i], errs[i], output, err, input)
+		}
+	}
+
+	d, err := Parentheses.ParseDecimal("(12,345,678,901,234,567,890.12)", 0)
+
+	if err != nil || d.String() != "-12345678901234567890.12" {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing SignStyle.ParseDecimal",
+			"-12345678901234567890.12", d.String(), err)
+	}
+}
@@ -0,0 +1,167 @@
+package decimals
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SignMode determines when a sign is shown in formatted output. The zero
+// value is SignNegative.
+type SignMode int
+
+const (
+	// SignNegative shows a minus sign for negative numbers only.
+	SignNegative SignMode = iota
+
+	// SignAlways shows a minus sign for negative numbers and a plus sign for
+	// all others.
+	SignAlways
+
+	// SignNever shows no sign, formatting the absolute value.
+	SignNever
+)
+
+// Names of the sign modes used for text encoding
+var signModeNames = []string{"negative", "always", "never"}
+
+// String returns the name of the sign mode, such as "always".
+func (m SignMode) String() string {
+
+	if m < 0 || int(m) >= len(signModeNames) {
+
+		return "SignMode(" + strconv.Itoa(int(m)) + ")"
+	}
+
+	return signModeNames[m]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m SignMode) MarshalText() ([]byte, error) {
+
+	if m < 0 || int(m) >= len(signModeNames) {
+
+		return nil, fmt.Errorf("decimals: invalid sign mode %d", int(m))
+	}
+
+	return []byte(signModeNames[m]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the names
+// returned by String in any case.
+func (m *SignMode) UnmarshalText(text []byte) error {
+
+	for i, name := range signModeNames {
+
+		if strings.EqualFold(string(text), name) {
+
+			*m = SignMode(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("decimals: unknown sign mode %q", text)
+}
+
+// FormatSpec describes how a number is formatted, so that formats can be
+// defined declaratively, for example per column in a report, and loaded from
+// configuration files. The zero value formats a number rounded half up to
+// an integer, without grouping or padding.
+type FormatSpec struct {
+
+	// Precision is the number of decimal places, or if negative the power
+	// of ten to round to, as for RoundFloat.
+	Precision int `json:"precision" yaml:"precision"`
+
+	// Mode is the rounding mode.
+	Mode RoundingMode `json:"mode" yaml:"mode"`
+
+	// Grouping enables the comma separator for thousands.
+	Grouping bool `json:"grouping" yaml:"grouping"`
+
+	// Sign determines when a sign is shown.
+	Sign SignMode `json:"sign" yaml:"sign"`
+
+	// Width is the minimum width of the output in columns, as measured by
+	// DisplayWidth, so that columns stay aligned when separators or
+	// suffixes are fullwidth characters. Shorter output is padded with
+	// spaces on the left.
+	Width int `json:"width" yaml:"width"`
+
+	// Suffix is appended to the number, before padding.
+	Suffix string `json:"suffix" yaml:"suffix"`
+}
+
+// FormatWithSpec converts a float64 to a string formatted as described by
+// the spec, using the default formatter's separators.
+func FormatWithSpec(x float64, spec FormatSpec) string {
+
+	return DefaultFormatter().FormatWithSpec(x, spec)
+}
+
+// FormatWithSpec converts a float64 to a string formatted as described by
+// the spec, using the formatter's separators. The formatter's template is
+// applied after the spec's suffix and before padding to the spec's width.
+func (f Formatter) FormatWithSpec(x float64, spec FormatSpec) string {
+
+	var (
+		d    Decimal
+		sep  string
+		sign string
+		rstr string
+	)
+
+	if spec.Grouping {
+
+		sep = f.GroupSeparator
+	}
+
+	// Round the number and format its digits, or show the placeholder for
+	// a missing value without the template
+	p, missing := f.placeholder(x)
+	d, err := DecimalFromFloat(x)
+
+	if missing {
+
+		rstr = p
+
+	} else if err != nil {
+
+		rstr = strings.TrimPrefix(formatSpecial(x), "-")
+
+	} else {
+
+		var places int
+
+		d, places = f.floatLimit().roundSignificant(d, spec.Precision, spec.Mode)
+		rstr = f.formatDigits(d, places, sep)
+	}
+
+	// Choose the sign, treating a value rounded to zero as positive
+	switch {
+
+	case spec.Sign == SignNever:
+
+	case d.Sign() < 0 || math.IsInf(x, -1):
+
+		sign = "-"
+
+	case spec.Sign == SignAlways:
+
+		sign = "+"
+	}
+
+	if !missing {
+
+		rstr = f.applyTemplate(sign + rstr + spec.Suffix)
+	}
+
+	// Pad to the width
+	if pad := spec.Width - DisplayWidth(rstr); pad > 0 {
+
+		rstr = strings.Repeat(" ", pad) + rstr
+	}
+
+	return rstr
+}
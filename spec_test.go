@@ -0,0 +1,91 @@
+package decimals
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// Test FormatWithSpec with a range of specs
+func TestFormatWithSpec(t *testing.T) {
+
+	inputs := []float64{
+		5555555.125,
+		5555555.125,
+		5555555.125,
+		-5555555.125,
+		-5555555.125,
+		5555.5,
+		-0.004,
+		-0.004,
+		12.5,
+		12.5,
+		math.Inf(-1),
+	}
+
+	specs := []FormatSpec{
+		{Precision: 2},
+		{Precision: 2, Mode: HalfEven, Grouping: true},
+		{Precision: -3, Grouping: true},
+		{Precision: 1, Grouping: true, Sign: SignNever},
+		{Precision: 0, Mode: Down, Grouping: true},
+		{Precision: 0, Sign: SignAlways, Suffix: " km"},
+		{Precision: 2},
+		{Precision: 2, Sign: SignAlways},
+		{Precision: 1, Width: 8, Suffix: "%"},
+		{Precision: 0, Width: 2},
+		{Precision: 2, Grouping: true},
+	}
+
+	expected := []string{
+		"5555555.13",
+		"5,555,555.12",
+		"5,556,000",
+		"5,555,555.1",
+		"-5,555,555",
+		"+5556 km",
+		"0.00",
+		"+0.00",
+		"   12.5%",
+		"13",
+		"-Inf",
+	}
+
+	for i, n := range inputs {
+
+		output := FormatWithSpec(n, specs[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatWithSpec",
+				expected[i], output)
+		}
+	}
+}
+
+// Test FormatSpec can be loaded from JSON
+func TestFormatSpecJSON(t *testing.T) {
+
+	var spec FormatSpec
+
+	data := `{"precision": 2, "mode": "half-even", "grouping": true,
+		"sign": "always", "width": 12, "suffix": " USD"}`
+
+	if err := json.Unmarshal([]byte(data), &spec); err != nil {
+
+		t.Fatalf("Unexpected error: %v unmarshalling FormatSpec", err)
+	}
+
+	expected := FormatSpec{2, HalfEven, true, SignAlways, 12, " USD"}
+
+	if spec != expected {
+
+		t.Errorf("Expected: %+v but received: %+v unmarshalling FormatSpec",
+			expected, spec)
+	}
+
+	if err := json.Unmarshal([]byte(`{"mode": "sideways"}`), &spec); err == nil {
+
+		t.Errorf("Expected an error unmarshalling an unknown rounding mode")
+	}
+}
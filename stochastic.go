@@ -0,0 +1,118 @@
+package decimals
+
+import (
+	"hash/fnv"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundStochastic rounds a float64 to the given precision either up or
+// down, choosing at random with the probability of rounding away from zero
+// equal to the discarded fraction of the last place. 1.23 rounded to one
+// place becomes 1.3 for 30% of keys and 1.2 for the rest, so the expected
+// rounded value is the value itself and totals of many rounded figures are
+// unbiased. Precision is interpreted as for RoundFloat.
+//
+// The random choice is drawn from a hash of the key and the value, so
+// rounding is deterministic: the same key, such as a user ID, always sees
+// the same value rounded the same way, while rounding across many keys is
+// unbiased. A number and its negation are rounded symmetrically.
+func RoundStochastic(x float64, precision int, key string) float64 {
+
+	// Zero, infinities, NaN and exact integers rounded to a whole number of
+	// places are unchanged by rounding
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) || IsExactInt(x) && precision >= 0 {
+
+		return x
+	}
+
+	d, _ := DecimalFromFloat(x)
+
+	return roundedFloat(x, d.RoundStochastic(precision, key))
+}
+
+// RoundStochastic rounds d to the given precision up or down at random, as
+// the RoundStochastic function does, with the choice drawn from a hash of
+// the key and the value. Trailing zeros do not change the choice, so 1.5
+// and 1.50 are rounded the same way for the same key.
+func (d Decimal) RoundStochastic(precision int, key string) Decimal {
+
+	keep := d.keep(precision)
+
+	if d.digits == "" || keep >= len(d.digits) {
+
+		return d
+	}
+
+	// Hash the key and the absolute value, without trailing zeros
+	digits := strings.TrimRight(d.digits, "0")
+	exponent := d.exponent + len(d.digits) - len(digits)
+
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(digits + "e" + strconv.Itoa(exponent) + "p" + strconv.Itoa(precision)))
+
+	var (
+		hash = mix64(h.Sum64())
+		mode = Down
+	)
+
+	// A discarded fraction of the last place below 10^-20 is below the
+	// resolution of the hash, so only a zero hash rounds it away from zero
+	if keep < -19 {
+
+		if hash == 0 {
+
+			mode = Up
+		}
+
+		return d.Round(precision, mode)
+	}
+
+	// Find the discarded digits as a fraction of the last kept place
+	discarded := d.digits
+
+	if keep > 0 {
+
+		discarded = d.digits[keep:]
+
+	} else {
+
+		discarded = strings.Repeat("0", -keep) + discarded
+	}
+
+	// The hash as a fraction of 2^64 is uniform in [0, 1), and rounds the
+	// number away from zero if it is less than the discarded fraction,
+	// that is if hash × 10^n < discarded × 2^64
+	var (
+		u    = new(big.Int).SetUint64(hash)
+		f, _ = new(big.Int).SetString(discarded, 10)
+	)
+
+	u.Mul(u, pow10Big(len(discarded)))
+	f.Lsh(f, 64)
+
+	if u.Cmp(f) < 0 {
+
+		mode = Up
+	}
+
+	return d.Round(precision, mode)
+}
+
+// mix64 is the finalizer of MurmurHash3, which spreads every bit of x over
+// the whole result. FNV alone leaves the high bits of hashes of similar
+// keys, such as consecutive user IDs, correlated.
+func mix64(x uint64) uint64 {
+
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+
+	return x
+}
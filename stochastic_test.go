@@ -0,0 +1,89 @@
+package decimals
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// Test RoundStochastic is deterministic per key and unbiased across keys
+func TestRoundStochastic(t *testing.T) {
+
+	inputs := []float64{1.23, 0.005, 2.5, -1.23, 1234, 1e-9}
+	precisions := []int{1, 2, 0, 1, -2, 3}
+
+	for i, x := range inputs {
+
+		var (
+			p     = precisions[i]
+			step  = math.Pow10(-p)
+			sum   float64
+			count = 5000
+		)
+
+		for k := 0; k < count; k++ {
+
+			key := "user-" + strconv.Itoa(k)
+			r := RoundStochastic(x, p, key)
+
+			if r != RoundStochastic(x, p, key) {
+
+				t.Errorf("Expected: the same result testing RoundStochastic(%v, %d, %q) twice", x, p, key)
+			}
+
+			if -r != RoundStochastic(-x, p, key) {
+
+				t.Errorf("Expected: %v but received: %v testing RoundStochastic(%v, %d, %q)",
+					-r, RoundStochastic(-x, p, key), -x, p, key)
+			}
+
+			if r != RoundFloatMode(x, p, Down) && r != RoundFloatMode(x, p, Up) {
+
+				t.Errorf("Expected: %v or %v but received: %v testing RoundStochastic(%v, %d, %q)",
+					RoundFloatMode(x, p, Down), RoundFloatMode(x, p, Up), r, x, p, key)
+			}
+
+			sum += r
+		}
+
+		// The mean is within a few standard errors of x
+		if mean := sum / float64(count); math.Abs(mean-x) > 4*step/math.Sqrt(float64(count)) {
+
+			t.Errorf("Expected: a mean near %v but received: %v testing RoundStochastic(%v, %d)", x, mean, x, p)
+		}
+	}
+
+	// Values that need no rounding are unchanged
+	for _, x := range []float64{0, 1.5, 42, math.Inf(1)} {
+
+		if r := RoundStochastic(x, 1, "key"); r != x {
+
+			t.Errorf("Expected: %v but received: %v testing RoundStochastic(%v, 1)", x, r, x)
+		}
+	}
+}
+
+// Test Decimal.RoundStochastic ignores trailing zeros
+func TestDecimalRoundStochastic(t *testing.T) {
+
+	for k := 0; k < 100; k++ {
+
+		key := strconv.Itoa(k)
+		a := MustParseDecimal("1.25").RoundStochastic(1, key)
+		b := MustParseDecimal("1.2500").RoundStochastic(1, key)
+
+		if a.Cmp(b) != 0 || (a.String() != "1.2" && a.String() != "1.3") {
+
+			t.Errorf("Expected: equal results but received: %s and %s testing Decimal.RoundStochastic", a, b)
+		}
+	}
+
+	// Precisions far beyond the digits round down without padding them
+	for _, precision := range []int{-1 << 40, math.MinInt} {
+
+		if output := RoundStochastic(1.5, precision, "k"); output != 0 {
+
+			t.Errorf("Expected: 0 but received: %v testing RoundStochastic(1.5, %d, \"k\")", output, precision)
+		}
+	}
+}
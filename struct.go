@@ -0,0 +1,335 @@
+package decimals
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	decimalType  = reflect.TypeOf(Decimal{})
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// FormatStruct formats the fields of a struct for display using the
+// default formatter. See Formatter.FormatStruct.
+func FormatStruct(v interface{}) (map[string]string, error) {
+
+	return DefaultFormatter().FormatStruct(v)
+}
+
+// FormatStruct formats each exported field of a struct, or of a pointer to
+// a struct, for display in templates and CSV exports. The result is keyed
+// by field name. Numeric fields with a decimals struct tag are formatted as
+// the tag directs, with the options described at ParseTag. For example, a
+// float64 field tagged `decimals:"currency=USD,group"` holding 1234.5 is
+// formatted as "$1,234.50". Tags apply to integers, floats, Decimals and
+// pointers to them. Other fields are formatted with fmt.Sprint, except that
+// the fields of nested structs that do not implement fmt.Stringer are
+// included under keys such as "Address.City", and the fields of embedded
+// structs are included under their own names. Nil pointers, and NaN in
+// tagged fields, are formatted as the formatter's Placeholder, which is
+// kept by the locale option. An error is returned if v is not a struct or a
+// tag is invalid.
+func (f Formatter) FormatStruct(v interface{}) (map[string]string, error) {
+
+	rv := reflect.ValueOf(v)
+
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+
+		return nil, fmt.Errorf("decimals: FormatStruct of non-struct type %T", v)
+	}
+
+	out := make(map[string]string)
+
+	if err := f.formatFields(out, "", rv); err != nil {
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// formatFields adds the formatted fields of the struct v to out, with keys
+// beginning with the given prefix.
+func (f Formatter) formatFields(out map[string]string, prefix string, v reflect.Value) error {
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+
+		var (
+			field = t.Field(i)
+			fv    = v.Field(i)
+			key   = prefix + field.Name
+		)
+
+		if field.PkgPath != "" && !field.Anonymous {
+
+			continue
+		}
+
+		// Format tagged numbers
+		if tag, ok := field.Tag.Lookup("decimals"); ok {
+
+			tf, err := f.ParseTag(tag)
+
+			if err != nil {
+
+				return err
+			}
+
+			if fv.Kind() == reflect.Ptr {
+
+				if fv.IsNil() {
+
+					out[key] = f.Placeholder
+					continue
+				}
+
+				fv = fv.Elem()
+			}
+
+			var ok bool
+
+			if out[key], ok = tf.Format(fv); !ok {
+
+				return fmt.Errorf("decimals: field %s: decimals tag on non-numeric type %s", key, fv.Type())
+			}
+
+			continue
+		}
+
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Type().Elem().Kind() == reflect.Struct {
+
+			fv = fv.Elem()
+		}
+
+		// Include the fields of embedded and nested structs
+		if fv.Kind() == reflect.Struct && !fv.Type().Implements(stringerType) {
+
+			p := prefix
+
+			if !field.Anonymous {
+
+				p = key + "."
+			}
+
+			if err := f.formatFields(out, p, fv); err != nil {
+
+				return err
+			}
+
+			continue
+		}
+
+		if field.PkgPath != "" {
+
+			continue
+		}
+
+		if fv.Kind() == reflect.Ptr && fv.IsNil() {
+
+			out[key] = f.Placeholder
+			continue
+		}
+
+		out[key] = fmt.Sprint(fv.Interface())
+	}
+
+	return nil
+}
+
+// ParseTag parses a decimals struct tag using the default formatter. See
+// Formatter.ParseTag.
+func ParseTag(tag string) (TagFormat, error) {
+
+	return DefaultFormatter().ParseTag(tag)
+}
+
+// A TagFormat formats numbers as a decimals struct tag directs. It is
+// shared by FormatStruct and the decimaljson package, so that a tagged
+// field is formatted the same way in each.
+type TagFormat struct {
+	formatter Formatter
+	precision int
+	mode      RoundingMode
+	percent   bool
+	currency  *Currency
+}
+
+// ParseTag parses a decimals struct tag, a comma separated list of
+// options, into the format it describes. The options are:
+//
+//	precision=n    the precision, as for RoundFloat (default 0)
+//	mode=name      the rounding mode, such as half-even (default half-up)
+//	group          group thousands with the formatter's separator
+//	locale=tag     use the separators of a locale, such as de-DE
+//	percent        multiply by 100 and add a percent sign, placed by the
+//	               formatter's PercentPattern
+//	currency=code  round to the currency's minor unit, unless a precision
+//	               is given, and prefix the currency's symbol
+//
+// Numbers are formatted with the formatter's separators and template,
+// unless a locale is given, in which case only the formatter's Placeholder
+// is kept. An error is returned if an option is unknown or invalid.
+func (f Formatter) ParseTag(tag string) (TagFormat, error) {
+
+	var (
+		tf           = TagFormat{formatter: f}
+		group        bool
+		hasPrecision bool
+		err          error
+	)
+
+	for _, opt := range strings.Split(tag, ",") {
+
+		key, value := opt, ""
+
+		if i := strings.IndexByte(opt, '='); i >= 0 {
+
+			key, value = opt[:i], opt[i+1:]
+		}
+
+		switch key {
+
+		case "precision":
+
+			tf.precision, err = strconv.Atoi(value)
+			hasPrecision = true
+
+		case "mode":
+
+			err = tf.mode.UnmarshalText([]byte(value))
+
+		case "group":
+
+			group = true
+
+		case "locale":
+
+			tf.formatter, err = NewFormatter(value)
+			tf.formatter.Placeholder = f.Placeholder
+
+		case "percent":
+
+			tf.percent = true
+
+		case "currency":
+
+			if c, ok := LookupCurrency(value); ok {
+
+				tf.currency = &c
+
+			} else {
+
+				err = fmt.Errorf("unknown currency %q", value)
+			}
+
+		default:
+
+			err = fmt.Errorf("unknown option %q", key)
+		}
+
+		if err != nil {
+
+			return tf, fmt.Errorf("decimals: invalid decimals tag %q: %v", tag, err)
+		}
+	}
+
+	if tf.currency != nil && !hasPrecision {
+
+		tf.precision = tf.currency.Exponent
+	}
+
+	if !group {
+
+		tf.formatter.GroupSeparator = ""
+	}
+
+	return tf, nil
+}
+
+// Format formats the number v, an integer, float or Decimal, as the tag
+// directs. NaN is formatted as the formatter's Placeholder if it has one.
+// It reports false if v is not a number.
+func (tf TagFormat) Format(v reflect.Value) (string, bool) {
+
+	var d Decimal
+
+	switch v.Kind() {
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+
+		d = DecimalFromInt(v.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+
+		d = newDecimal(false, strconv.FormatUint(v.Uint(), 10), 0)
+
+	case reflect.Float32, reflect.Float64:
+
+		x := v.Float()
+
+		if p, ok := tf.formatter.placeholder(x); ok {
+
+			return p, true
+		}
+
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+
+			return tf.formatter.applyTemplate(formatSpecial(x)), true
+		}
+
+		// A float32 is formatted from its own shortest digits, not those
+		// of the float64 it widens to
+		if v.Kind() == reflect.Float32 {
+
+			d, _ = ParseDecimal(strconv.FormatFloat(x, 'g', -1, 32), ParseExponent)
+
+		} else {
+
+			d, _ = DecimalFromFloat(x)
+		}
+
+	case reflect.Struct:
+
+		if v.Type() != decimalType {
+
+			return "", false
+		}
+
+		d = v.Interface().(Decimal)
+
+	default:
+
+		return "", false
+	}
+
+	// Scale percentages exactly by moving the exponent
+	if tf.percent && d.digits != "" {
+
+		d.exponent += 2
+	}
+
+	s := tf.formatter.formatDecimal(d.Round(tf.precision, tf.mode), tf.precision)
+
+	if tf.percent {
+
+		s = tf.formatter.formatPercent(s)
+	}
+
+	if tf.currency != nil {
+
+		s = placeSymbol(s, tf.currency.Symbol)
+	}
+
+	return tf.formatter.applyTemplate(s), true
+}
@@ -0,0 +1,176 @@
+package decimals
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type structAddress struct {
+	City string
+	Rent float64 `decimals:"currency=EUR,locale=de-DE,group"`
+}
+
+type structBase struct {
+	ID int
+}
+
+type structRecord struct {
+	structBase
+	Price    float64  `decimals:"currency=USD,group"`
+	Yen      int64    `decimals:"currency=JPY,group"`
+	Rate     float64  `decimals:"percent,precision=1"`
+	Ratio    Decimal  `decimals:"percent,precision=2,mode=half-even"`
+	Count    uint64   `decimals:"group"`
+	Weight   float32  `decimals:"precision=9"`
+	Total    *float64 `decimals:"precision=2"`
+	Missing  *float64 `decimals:"precision=2"`
+	Bad      float64  `decimals:"precision=1"`
+	Name     string
+	Plain    Decimal
+	When     time.Time
+	Address  structAddress
+	Tags     []string
+	internal int
+}
+
+// Test FormatStruct formats tagged and untagged fields
+func TestFormatStruct(t *testing.T) {
+
+	total := -1234.567
+
+	input := &structRecord{
+		structBase: structBase{7},
+		Price:      -1234.5,
+		Yen:        1500,
+		Rate:       0.12345,
+		Ratio:      MustParseDecimal("0.000125"),
+		Count:      18446744073709551615,
+		Weight:     0.1,
+		Total:      &total,
+		Bad:        math.NaN(),
+		Name:       "widget",
+		Plain:      MustParseDecimal("1.50"),
+		When:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Address:    structAddress{"Berlin", 950},
+		Tags:       []string{"a", "b"},
+	}
+
+	expected := map[string]string{
+		"ID":           "7",
+		"Price":        "-$1,234.50",
+		"Yen":          "¥1,500",
+		"Rate":         "12.3%",
+		"Ratio":        "0.01%",
+		"Count":        "18,446,744,073,709,551,615",
+		"Weight":       "0.100000000",
+		"Total":        "-1234.57",
+		"Missing":      "",
+		"Bad":          "NaN",
+		"Name":         "widget",
+		"Plain":        "1.50",
+		"When":         "2024-01-02 03:04:05 +0000 UTC",
+		"Address.City": "Berlin",
+		"Address.Rent": "€950,00",
+		"Tags":         "[a b]",
+	}
+
+	output, err := FormatStruct(input)
+
+	if err != nil || !reflect.DeepEqual(output, expected) {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing FormatStruct", expected, output, err)
+	}
+}
+
+// Test FormatStruct rejects non-structs and invalid tags
+func TestFormatStructErrors(t *testing.T) {
+
+	inputs := []interface{}{
+		1.5,
+		nil,
+		struct {
+			X float64 `decimals:"currency=XYZ"`
+		}{},
+		struct {
+			X float64 `decimals:"precision"`
+		}{},
+		struct {
+			X float64 `decimals:"bold"`
+		}{},
+		struct {
+			X string `decimals:"precision=2"`
+		}{},
+	}
+
+	for _, input := range inputs {
+
+		if _, err := FormatStruct(input); err == nil {
+
+			t.Errorf("Expected: error but received: nil testing FormatStruct(%#v)", input)
+		}
+	}
+}
+
+// Test FormatStruct shows missing values as the formatter's placeholder
+func TestFormatStructPlaceholder(t *testing.T) {
+
+	f := DefaultFormatter()
+	f.Placeholder = "N/A"
+
+	input := struct {
+		Rate    float64  `decimals:"percent,locale=fr-FR"`
+		Missing *float64 `decimals:"precision=2"`
+		Note    *string
+		Count   int
+	}{Rate: math.NaN()}
+
+	expected := map[string]string{
+		"Rate":    "N/A",
+		"Missing": "N/A",
+		"Note":    "N/A",
+		"Count":   "0",
+	}
+
+	output, err := f.FormatStruct(input)
+
+	if err != nil || !reflect.DeepEqual(output, expected) {
+
+		t.Errorf("Expected: %q but received: %q (%v) testing Formatter.FormatStruct", expected, output, err)
+	}
+}
+
+// Test ParseTag formats values as the tag directs
+func TestParseTag(t *testing.T) {
+
+	f := Formatter{GroupSeparator: ",", DecimalSeparator: ".", Template: "{} net"}
+
+	tags := []string{"precision=2,group", "currency=EUR,group,locale=de-DE", "percent,precision=1", "mode=floor"}
+
+	inputs := []interface{}{1234.567, MustParseDecimal("-1234.5"), uint8(3), -2.5}
+
+	expected := []string{"1,234.57 net", "-€1.234,50", "300.0% net", "-3 net"}
+
+	for i, tag := range tags {
+
+		tf, err := f.ParseTag(tag)
+
+		if err != nil {
+
+			t.Fatalf("Expected: no error but received: %v testing ParseTag(%q)", err, tag)
+		}
+
+		if output, ok := tf.Format(reflect.ValueOf(inputs[i])); !ok || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%t) testing ParseTag(%q).Format(%v)", expected[i], output, ok, tag, inputs[i])
+		}
+	}
+
+	tf, _ := ParseTag("precision=2")
+
+	if output, ok := tf.Format(reflect.ValueOf("1.5")); ok {
+
+		t.Errorf("Expected: false but received: %q testing TagFormat.Format of a string", output)
+	}
+}
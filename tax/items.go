@@ -0,0 +1,129 @@
+package tax
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/olihawkins/decimals"
+)
+
+// An Item is a line of an invoice priced as a quantity of a unit price,
+// net of tax, with the tax rate as a percentage.
+type Item struct {
+	Quantity  decimals.Decimal
+	UnitPrice decimals.Decimal
+	Rate      decimals.Decimal
+}
+
+// LineItems are the items of an invoice and the calculator that rounds
+// their amounts and taxes. The amounts of the lines always add up exactly
+// to the subtotal and the subtotal and the tax to the total, so that the
+// amounts shown on an invoice agree to the penny. With the PerLine
+// strategy each line amount is rounded, and the subtotal is their sum.
+// With PerInvoice the exact subtotal is rounded once, and the difference
+// from the rounded lines is spread over the lines whose amounts were
+// rounded furthest, one minor unit each.
+type LineItems struct {
+	Calculator
+	Items []Item
+}
+
+// Amounts returns the amount of each line, rounded to the calculator's
+// scale, in the order of the items.
+func (li LineItems) Amounts() []decimals.Decimal {
+
+	exact := make([]decimals.Decimal, len(li.Items))
+
+	for i, item := range li.Items {
+
+		exact[i] = item.Quantity.Mul(item.UnitPrice)
+	}
+
+	if li.Strategy != PerInvoice {
+
+		amounts := make([]decimals.Decimal, len(exact))
+
+		for i, e := range exact {
+
+			amounts[i] = li.round(e)
+		}
+
+		return amounts
+	}
+
+	return li.allocate(exact)
+}
+
+// Subtotal returns the sum of the line amounts, net of tax.
+func (li LineItems) Subtotal() decimals.Decimal {
+
+	return decimals.Sum(li.Amounts())
+}
+
+// Tax returns the tax on the line amounts, rounded as the calculator's
+// strategy decides.
+func (li LineItems) Tax() decimals.Decimal {
+
+	return li.Totals().Tax
+}
+
+// Total returns the subtotal with the tax added.
+func (li LineItems) Total() decimals.Decimal {
+
+	return li.Totals().Gross
+}
+
+// Totals returns the totals of the line amounts, with the totals of each
+// rate, as FromNet does.
+func (li LineItems) Totals() Totals {
+
+	var (
+		amounts = li.Amounts()
+		lines   = make([]Line, len(amounts))
+	)
+
+	for i, amount := range amounts {
+
+		lines[i] = Line{amount, li.Items[i].Rate}
+	}
+
+	return li.FromNet(lines)
+}
+
+// allocate rounds the exact amounts to the calculator's scale so that they
+// sum to their exact sum rounded, by largest remainder: every amount is
+// rounded down, and a minor unit is added to the amounts with the largest
+// remainders, the first of equal remainders first, until they do.
+func (li LineItems) allocate(exact []decimals.Decimal) []decimals.Decimal {
+
+	var (
+		amounts    = make([]decimals.Decimal, len(exact))
+		remainders = make([]decimals.Decimal, len(exact))
+		order      = make([]int, len(exact))
+		unit, _    = decimals.ParseDecimal("1e"+strconv.Itoa(-li.Scale), decimals.ParseExponent)
+	)
+
+	for i, e := range exact {
+
+		amounts[i], _ = e.Div(one, li.Scale, decimals.Floor)
+		remainders[i] = decimals.Sum([]decimals.Decimal{e, amounts[i].Neg()})
+		order[i] = i
+	}
+
+	// Find the number of units the rounded down amounts fall short by
+	short := decimals.Sum([]decimals.Decimal{li.round(decimals.Sum(exact)), decimals.Sum(amounts).Neg()})
+	units, _ := short.Div(unit, 0, decimals.HalfUp)
+	n, _ := units.Int64()
+
+	sort.SliceStable(order, func(i, j int) bool {
+
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+
+	for _, i := range order[:n] {
+
+		amounts[i] = decimals.Sum([]decimals.Decimal{amounts[i], unit})
+	}
+
+	return amounts
+}
@@ -0,0 +1,104 @@
+package tax
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/olihawkins/decimals"
+)
+
+// Test LineItems rounds per line and per invoice
+func TestLineItems(t *testing.T) {
+
+	items := []Item{
+		{dec("1"), dec("0.333"), dec("20")},
+		{dec("1"), dec("0.333"), dec("20")},
+		{dec("1"), dec("0.333"), dec("20")},
+		{dec("0.5"), dec("3.333"), dec("5")},
+		{dec("-1"), dec("0.335"), dec("5")},
+	}
+
+	inputs := []LineItems{
+		{Calculator{Scale: 2}, items},
+		{Calculator{Scale: 2, Strategy: PerInvoice}, items},
+	}
+
+	expected := [][4]string{
+		{"0.33 0.33 0.33 1.67 -0.34", "2.32", "0.27", "2.59"},
+		{"0.33 0.33 0.33 1.67 -0.33", "2.33", "0.27", "2.60"},
+	}
+
+	for i, li := range inputs {
+
+		var amounts []string
+
+		for _, amount := range li.Amounts() {
+
+			amounts = append(amounts, amount.String())
+		}
+
+		output := [4]string{strings.Join(amounts, " "), li.Subtotal().String(), li.Tax().String(), li.Total().String()}
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing LineItems %d", expected[i], output, i+1)
+		}
+	}
+}
+
+// Test the line amounts of random invoices add up to their subtotals, and
+// the subtotals and taxes to their totals, with either strategy
+func TestLineItemsAddUp(t *testing.T) {
+
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+
+		items := make([]Item, rng.Intn(10)+1)
+
+		for j := range items {
+
+			items[j] = Item{
+				Quantity:  dec(strconv.Itoa(rng.Intn(7)-1) + "." + strconv.Itoa(rng.Intn(100))),
+				UnitPrice: dec(strconv.Itoa(rng.Intn(1000)) + "." + strconv.Itoa(rng.Intn(10000))),
+				Rate:      dec(strconv.Itoa(rng.Intn(3) * 10)),
+			}
+		}
+
+		for _, li := range []LineItems{
+			{Calculator{Scale: 2}, items},
+			{Calculator{Scale: 2, Mode: decimals.HalfEven, Strategy: PerInvoice}, items},
+		} {
+
+			subtotal := li.Subtotal()
+
+			if sum := decimals.Sum(li.Amounts()); sum.Cmp(subtotal) != 0 {
+
+				t.Fatalf("Expected: %s but received: %s testing the line amounts of %v", subtotal, sum, items)
+			}
+
+			if sum := decimals.Sum([]decimals.Decimal{subtotal, li.Tax()}); sum.Cmp(li.Total()) != 0 {
+
+				t.Fatalf("Expected: %s but received: %s testing the total of %v", li.Total(), sum, items)
+			}
+
+			// Per invoice the subtotal is the exact subtotal rounded once
+			if li.Strategy == PerInvoice {
+
+				var exact []decimals.Decimal
+
+				for _, item := range items {
+
+					exact = append(exact, item.Quantity.Mul(item.UnitPrice))
+				}
+
+				if rounded, _ := decimals.Sum(exact).Div(one, 2, li.Mode); rounded.Cmp(subtotal) != 0 {
+
+					t.Fatalf("Expected: %s but received: %s testing the subtotal of %v", rounded, subtotal, items)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Package tax computes value added and sales taxes with correct rounding,
+converting between net and gross amounts and totalling invoices whose
+lines may be taxed at different rates.
+
+The tax on an invoice can be rounded on every line or once per rate, and
+the two can give different totals: three lines of 0.02 net at 20% carry
+no tax each when rounded half up, but their total of 0.06 carries 0.01.
+Jurisdictions and accounting systems prescribe one or the other, so a
+Calculator takes the strategy as a setting:
+
+	c := tax.Calculator{Scale: 2, Strategy: tax.PerInvoice}
+	totals := c.FromNet([]tax.Line{
+		{Amount: decimals.MustParseDecimal("0.02"), Rate: decimals.MustParseDecimal("20")},
+		{Amount: decimals.MustParseDecimal("0.02"), Rate: decimals.MustParseDecimal("20")},
+		{Amount: decimals.MustParseDecimal("0.02"), Rate: decimals.MustParseDecimal("20")},
+	})
+
+gives a tax of 0.01 and a gross total of 0.07. Whatever the strategy,
+amounts always add up exactly: the net and the tax of every rate sum to
+its gross amount, and the totals of the rates sum to the invoice totals.
+
+Rates are percentages, so 20 is a rate of 20%, and are compared by value,
+so 20 and 20.0 are the same rate.
+*/
+package tax
+
+import (
+	"github.com/olihawkins/decimals"
+)
+
+var (
+	one     = decimals.DecimalFromInt(1)
+	percent = decimals.MustParseDecimal("0.01")
+)
+
+// A Strategy decides where the tax on an invoice is rounded.
+type Strategy int
+
+const (
+	// PerLine rounds the tax on every line, and the tax of the invoice is
+	// the sum of the rounded line taxes. It is the default.
+	PerLine Strategy = iota
+
+	// PerInvoice totals the lines of each rate before rounding the tax on
+	// the total once.
+	PerInvoice
+)
+
+// A Calculator computes taxes rounded to the minor unit of a currency.
+type Calculator struct {
+	Scale    int                   // the decimal places of the currency, such as 2
+	Mode     decimals.RoundingMode // the rounding mode, HalfUp by default
+	Strategy Strategy              // where invoice taxes are rounded
+}
+
+// A Line is a line of an invoice: an amount, net or gross of tax, and the
+// tax rate as a percentage.
+type Line struct {
+	Amount decimals.Decimal
+	Rate   decimals.Decimal
+}
+
+// A RateTotal is the total of the lines of an invoice taxed at one rate.
+type RateTotal struct {
+	Rate  decimals.Decimal
+	Net   decimals.Decimal
+	Tax   decimals.Decimal
+	Gross decimals.Decimal
+}
+
+// Totals are the totals of an invoice, with the totals of each rate in the
+// order the rates first appear in its lines.
+type Totals struct {
+	Net    decimals.Decimal
+	Tax    decimals.Decimal
+	Gross  decimals.Decimal
+	ByRate []RateTotal
+}
+
+// Tax returns the tax on a net amount at the given rate, rounded to the
+// calculator's scale.
+func (c Calculator) Tax(net, rate decimals.Decimal) decimals.Decimal {
+
+	return c.round(net.Mul(rate).Mul(percent))
+}
+
+// Gross returns a net amount with the tax at the given rate added, which
+// is rounded to the calculator's scale as by Tax.
+func (c Calculator) Gross(net, rate decimals.Decimal) decimals.Decimal {
+
+	return decimals.Sum([]decimals.Decimal{net, c.Tax(net, rate)})
+}
+
+// Net returns the net amount of a gross amount taxed at the given rate,
+// rounded to the calculator's scale, and the tax it contains, which is the
+// difference between them so that the two add up to the gross amount
+// exactly. It returns decimals.ErrDivisionByZero if the rate is -100.
+func (c Calculator) Net(gross, rate decimals.Decimal) (net, tax decimals.Decimal, err error) {
+
+	factor := decimals.Sum([]decimals.Decimal{one, rate.Mul(percent)})
+	net, err = gross.Div(factor, c.Scale, c.Mode)
+
+	if err != nil {
+
+		return decimals.Decimal{}, decimals.Decimal{}, err
+	}
+
+	return net, decimals.Sum([]decimals.Decimal{gross, net.Neg()}), nil
+}
+
+// FromNet totals an invoice whose line amounts are net of tax, rounding
+// the tax as the calculator's strategy decides.
+func (c Calculator) FromNet(lines []Line) Totals {
+
+	groups := groupLines(lines)
+
+	for i := range groups {
+
+		g := &groups[i]
+
+		if c.Strategy == PerInvoice {
+
+			g.total.Net = decimals.Sum(g.amounts)
+			g.total.Tax = c.Tax(g.total.Net, g.total.Rate)
+
+		} else {
+
+			taxes := make([]decimals.Decimal, len(g.amounts))
+
+			for j, amount := range g.amounts {
+
+				taxes[j] = c.Tax(amount, g.total.Rate)
+			}
+
+			g.total.Net = decimals.Sum(g.amounts)
+			g.total.Tax = decimals.Sum(taxes)
+		}
+
+		g.total.Gross = decimals.Sum([]decimals.Decimal{g.total.Net, g.total.Tax})
+	}
+
+	return totalGroups(groups)
+}
+
+// FromGross totals an invoice whose line amounts include tax, extracting
+// the tax as the calculator's strategy decides: from every line, or once
+// from the total of each rate. It returns decimals.ErrDivisionByZero if a
+// rate is -100.
+func (c Calculator) FromGross(lines []Line) (Totals, error) {
+
+	groups := groupLines(lines)
+
+	for i := range groups {
+
+		g := &groups[i]
+		g.total.Gross = decimals.Sum(g.amounts)
+
+		if c.Strategy == PerInvoice {
+
+			net, tax, err := c.Net(g.total.Gross, g.total.Rate)
+
+			if err != nil {
+
+				return Totals{}, err
+			}
+
+			g.total.Net, g.total.Tax = net, tax
+
+		} else {
+
+			nets := make([]decimals.Decimal, len(g.amounts))
+
+			for j, amount := range g.amounts {
+
+				net, _, err := c.Net(amount, g.total.Rate)
+
+				if err != nil {
+
+					return Totals{}, err
+				}
+
+				nets[j] = net
+			}
+
+			g.total.Net = decimals.Sum(nets)
+			g.total.Tax = decimals.Sum([]decimals.Decimal{g.total.Gross, g.total.Net.Neg()})
+		}
+	}
+
+	return totalGroups(groups), nil
+}
+
+// round rounds d to exactly the calculator's scale. Dividing by one rounds
+// to exactly scale places, as Div does.
+func (c Calculator) round(d decimals.Decimal) decimals.Decimal {
+
+	r, _ := d.Div(one, c.Scale, c.Mode)
+
+	return r
+}
+
+// A rateGroup holds the amounts of the lines taxed at one rate
+type rateGroup struct {
+	total   RateTotal
+	amounts []decimals.Decimal
+}
+
+// groupLines groups the line amounts by rate, in the order the rates first
+// appear.
+func groupLines(lines []Line) []rateGroup {
+
+	var groups []rateGroup
+
+lines:
+	for _, line := range lines {
+
+		for i := range groups {
+
+			if groups[i].total.Rate.Cmp(line.Rate) == 0 {
+
+				groups[i].amounts = append(groups[i].amounts, line.Amount)
+				continue lines
+			}
+		}
+
+		groups = append(groups, rateGroup{RateTotal{Rate: line.Rate}, []decimals.Decimal{line.Amount}})
+	}
+
+	return groups
+}
+
+// totalGroups returns the totals of the invoice from the totals of its
+// rates.
+func totalGroups(groups []rateGroup) Totals {
+
+	var (
+		totals  = Totals{ByRate: make([]RateTotal, len(groups))}
+		nets    = make([]decimals.Decimal, len(groups))
+		taxes   = make([]decimals.Decimal, len(groups))
+		grosses = make([]decimals.Decimal, len(groups))
+	)
+
+	for i, g := range groups {
+
+		totals.ByRate[i] = g.total
+		nets[i], taxes[i], grosses[i] = g.total.Net, g.total.Tax, g.total.Gross
+	}
+
+	totals.Net = decimals.Sum(nets)
+	totals.Tax = decimals.Sum(taxes)
+	totals.Gross = decimals.Sum(grosses)
+
+	return totals
+}
@@ -0,0 +1,128 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/olihawkins/decimals"
+)
+
+// dec parses a decimal constant
+func dec(s string) decimals.Decimal {
+
+	return decimals.MustParseDecimal(s)
+}
+
+// Test Tax, Gross and Net with a range of amounts, rates and modes
+func TestCalculator(t *testing.T) {
+
+	var (
+		cents = Calculator{Scale: 2}
+		even  = Calculator{Scale: 2, Mode: decimals.HalfEven}
+		yen   = Calculator{Scale: 0}
+	)
+
+	net1, tax1, err1 := cents.Net(dec("119.00"), dec("19"))
+	net2, tax2, err2 := cents.Net(dec("10.00"), dec("20"))
+
+	inputs := []string{
+		cents.Tax(dec("19.99"), dec("20")).String(),
+		cents.Gross(dec("19.99"), dec("20")).String(),
+		cents.Tax(dec("0.125"), dec("100")).String(),
+		even.Tax(dec("0.125"), dec("100")).String(),
+		yen.Tax(dec("1001"), dec("10")).String(),
+		yen.Gross(dec("1001"), dec("10")).String(),
+		net1.String(),
+		tax1.String(),
+		net2.String(),
+		tax2.String(),
+	}
+
+	expected := []string{"4.00", "23.99", "0.13", "0.12", "100", "1101", "100.00", "19.00", "8.33", "1.67"}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing Calculator", expected[i], output)
+		}
+	}
+
+	if err1 != nil || err2 != nil {
+
+		t.Errorf("Expected: nil errors but received: %v and %v testing Calculator.Net", err1, err2)
+	}
+
+	if _, _, err := cents.Net(dec("1"), dec("-100")); err != decimals.ErrDivisionByZero {
+
+		t.Errorf("Expected: %v but received: %v testing Calculator.Net", decimals.ErrDivisionByZero, err)
+	}
+}
+
+// Test FromNet and FromGross round per line and per invoice
+func TestCalculatorInvoices(t *testing.T) {
+
+	var (
+		perLine    = Calculator{Scale: 2}
+		perInvoice = Calculator{Scale: 2, Strategy: PerInvoice}
+		nets       = []Line{
+			{dec("0.02"), dec("20")},
+			{dec("10.00"), dec("5")},
+			{dec("0.02"), dec("20")},
+			{dec("10.00"), dec("5.0")},
+			{dec("0.02"), dec("20")},
+		}
+		grosses = []Line{
+			{dec("1.00"), dec("20")},
+			{dec("1.00"), dec("20")},
+			{dec("1.00"), dec("20")},
+		}
+	)
+
+	fromGrossLine, err1 := perLine.FromGross(grosses)
+	fromGrossInvoice, err2 := perInvoice.FromGross(grosses)
+
+	inputs := []Totals{perLine.FromNet(nets), perInvoice.FromNet(nets), fromGrossLine, fromGrossInvoice}
+
+	expected := [][3]string{
+		{"20.06", "1.00", "21.06"},
+		{"20.06", "1.01", "21.07"},
+		{"2.49", "0.51", "3.00"},
+		{"2.50", "0.50", "3.00"},
+	}
+
+	for i, totals := range inputs {
+
+		output := [3]string{totals.Net.String(), totals.Tax.String(), totals.Gross.String()}
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing invoice %d", expected[i], output, i+1)
+		}
+	}
+
+	if err1 != nil || err2 != nil {
+
+		t.Errorf("Expected: nil errors but received: %v and %v testing FromGross", err1, err2)
+	}
+
+	// The rates are totalled separately in the order they first appear
+	byRate := inputs[1].ByRate
+
+	if len(byRate) != 2 {
+
+		t.Fatalf("Expected: 2 rates but received: %d testing ByRate", len(byRate))
+	}
+
+	output := [4]string{byRate[0].Rate.String(), byRate[0].Tax.String(), byRate[1].Rate.String(), byRate[1].Gross.String()}
+	rates := [4]string{"20", "0.01", "5", "21.00"}
+
+	if output != rates {
+
+		t.Errorf("Expected: %q but received: %q testing ByRate", rates, output)
+	}
+
+	if _, err := perLine.FromGross([]Line{{dec("1"), dec("-100")}}); err != decimals.ErrDivisionByZero {
+
+		t.Errorf("Expected: %v but received: %v testing FromGross", decimals.ErrDivisionByZero, err)
+	}
+}
@@ -0,0 +1,83 @@
+package decimals
+
+import (
+	"math"
+)
+
+// ToleranceFor returns the comparison tolerance at the given decimal
+// precision, which is half a unit in the last decimal place. For example,
+// the tolerance for currency values at precision 2 is 0.005.
+func ToleranceFor(precision int) float64 {
+
+	return math.Pow10(-precision) / 2
+}
+
+// WithinTolerance reports whether a and b are equal at the given decimal
+// precision, meaning they differ by no more than ToleranceFor(precision).
+// The tolerance is widened by two units in the last place of the larger
+// operand, so that a difference of exactly half a unit is not rejected
+// because of binary representation error in the operands or in the
+// subtraction. NaN is never within tolerance of any value.
+func WithinTolerance(a, b float64, precision int) bool {
+
+	// Equal values, including equal infinities, are always within tolerance
+	if a == b {
+
+		return true
+	}
+
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+
+		return false
+	}
+
+	// Find the spacing between floats at the magnitude of the operands
+	m := math.Max(math.Abs(a), math.Abs(b))
+	ulp := math.Nextafter(m, math.Inf(1)) - m
+
+	return math.Abs(a-b) <= ToleranceFor(precision)+2*ulp
+}
+
+// FirstDifferingPrecision returns the smallest decimal precision at which a
+// and b round half up to different values, as by RoundFloat, for showing
+// two values in a test failure or a comparison with no more digits than
+// are needed to tell them apart. Rounding can separate values at one
+// precision and join them at the next, as 1.45 and 1.54 round to 1 and 2
+// but both to 1.5, so the precision returned is the smallest from which
+// they differ at every greater precision too. It may be negative, as for
+// 1234 and 1334, which differ at -2. If the values are equal, or either is
+// NaN or infinite, no precision tells them apart by rounding and the
+// number of decimal places needed to show the finite values in full is
+// returned instead.
+func FirstDifferingPrecision(a, b float64) int {
+
+	da, erra := DecimalFromFloat(a)
+	db, errb := DecimalFromFloat(b)
+
+	// Find the places needed to show each finite value in full
+	var places int
+
+	for _, d := range []Decimal{da, db} {
+
+		if d.digits != "" && -d.exponent > places {
+
+			places = -d.exponent
+		}
+	}
+
+	if erra != nil || errb != nil || da.Cmp(db) == 0 {
+
+		return places
+	}
+
+	// The values differ in full, and both round to zero at a precision
+	// coarser than their leading digits, so step back until they agree
+	p := places
+
+	for da.Round(p-1, HalfUp).Cmp(db.Round(p-1, HalfUp)) != 0 {
+
+		p--
+	}
+
+	return p
+}
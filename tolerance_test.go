@@ -0,0 +1,91 @@
+package decimals
+
+import (
+	"math"
+	"testing"
+)
+
+// Test ToleranceFor with a range of precisions
+func TestToleranceFor(t *testing.T) {
+
+	precisions := []int{-2, 0, 2, 6}
+
+	expected := []float64{50, 0.5, 0.005, 0.0000005}
+
+	for i, p := range precisions {
+
+		output := ToleranceFor(p)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v testing ToleranceFor(%d)",
+				expected[i], output, p)
+		}
+	}
+}
+
+// Test WithinTolerance with a range of values
+func TestWithinTolerance(t *testing.T) {
+
+	inputs := [][2]float64{
+		{0.1 + 0.2, 0.3},
+		{1.005, 1},
+		{1.006, 1},
+		{-1.004, -1},
+		{1e15 + 0.4, 1e15},
+		{1e15 + 1, 1e15},
+		{249.9, 250},
+		{math.Inf(1), math.Inf(1)},
+		{math.Inf(1), 1e308},
+		{math.NaN(), math.NaN()},
+	}
+
+	precisions := []int{2, 2, 2, 2, 0, 0, -1, 2, 2, 2}
+
+	expected := []bool{true, true, false, true, true, false, true, true, false, false}
+
+	for i, n := range inputs {
+
+		output := WithinTolerance(n[0], n[1], precisions[i])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %v but received: %v testing WithinTolerance(%v, %v, %d)",
+				expected[i], output, n[0], n[1], precisions[i])
+		}
+	}
+}
+
+// Test FirstDifferingPrecision finds the precision that separates values
+func TestFirstDifferingPrecision(t *testing.T) {
+
+	// Add at run time, as constant arithmetic is exact
+	a, b := 0.1, 0.2
+
+	inputs := [][2]float64{
+		{a + b, 0.3},
+		{1.45, 1.54},
+		{1.4, 1.6},
+		{0.96, 1.04},
+		{1234, 1334},
+		{1e6, 2e6},
+		{3.14159, 3.14160},
+		{-1.5, 1.5},
+		{0.001, 0.001},
+		{1.25, math.NaN()},
+		{math.Inf(1), 5},
+	}
+
+	expected := []int{17, 2, 0, 2, -2, -6, 5, 0, 3, 2, 0}
+
+	for i, in := range inputs {
+
+		output := FirstDifferingPrecision(in[0], in[1])
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %d but received: %d testing FirstDifferingPrecision(%v, %v)",
+				expected[i], output, in[0], in[1])
+		}
+	}
+}
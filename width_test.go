@@ -0,0 +1,61 @@
+package decimals
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Test DisplayWidth with narrow, wide and zero width characters
+func TestDisplayWidth(t *testing.T) {
+
+	inputs := []string{
+		"",
+		"1,234.50",
+		"１２３",
+		"12万",
+		"€5",
+		"1\u202F234",
+		MarkLTR("-5"),
+		"é",
+		"👍5",
+		"一\u00A0　",
+	}
+
+	expected := []int{0, 8, 6, 4, 2, 5, 2, 1, 3, 5}
+
+	for i, input := range inputs {
+
+		output := DisplayWidth(input)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %d but received: %d testing DisplayWidth(%q)", expected[i], output, input)
+		}
+	}
+}
+
+// Test the alignment APIs pad to the display width
+func TestDisplayWidthPadding(t *testing.T) {
+
+	inputs := []string{
+		FormatWithSpec(1234, FormatSpec{Width: 8, Suffix: "円"}),
+		FormatWithSpec(12, FormatSpec{Width: 8, Suffix: "％"}),
+		FormatRange(1, 2, 0, WithTemplate("{}万"), WithWidth(8)),
+		fmt.Sprintf("%6v", MustParseDecimal("1.5")),
+	}
+
+	expected := []string{
+		"  1234円",
+		"    12％",
+		"   1–2万",
+		"   1.5",
+	}
+
+	for i, output := range inputs {
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing display width padding", expected[i], output)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package decimals
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Words for the numbers below twenty
+	smallWords = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight",
+		"nine", "ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen",
+		"sixteen", "seventeen", "eighteen", "nineteen",
+	}
+
+	// Words for the multiples of ten from twenty
+	tensWords = []string{
+		"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy",
+		"eighty", "ninety",
+	}
+
+	// Words for each group of three digits in the short scale
+	groupWords = []string{
+		"", "thousand", "million", "billion", "trillion", "quadrillion",
+		"quintillion",
+	}
+)
+
+// FormatIntWords writes an int64 in English words in the short scale, as
+// in American usage, so 1234 is "one thousand two hundred thirty-four".
+// Negative numbers begin with "minus".
+func FormatIntWords(x int64) string {
+
+	if x < 0 {
+
+		return "minus " + intWords(absUint64(x), false)
+	}
+
+	return intWords(uint64(x), false)
+}
+
+// intWords writes n in English words. If hundredsAnd is true "and" is
+// written before the tens and units, as in British usage, so 105 is "one
+// hundred and five" and 1005 is "one thousand and five".
+func intWords(n uint64, hundredsAnd bool) string {
+
+	if n == 0 {
+
+		return smallWords[0]
+	}
+
+	var (
+		groups []uint64
+		words  []string
+	)
+
+	// Split n into groups of three digits, least significant first
+	for ; n > 0; n /= 1000 {
+
+		groups = append(groups, n%1000)
+	}
+
+	for i := len(groups) - 1; i >= 0; i-- {
+
+		g := groups[i]
+
+		if g == 0 {
+
+			continue
+		}
+
+		if g >= 100 {
+
+			words = append(words, smallWords[g/100], "hundred")
+		}
+
+		// Join the tens and units to the hundreds, or to the higher groups
+		// if this is the last group
+		if g%100 != 0 && hundredsAnd && (g >= 100 || (i == 0 && len(words) > 0)) {
+
+			words = append(words, "and")
+		}
+
+		if t := g % 100; t >= 20 && t%10 != 0 {
+
+			words = append(words, tensWords[t/10]+"-"+smallWords[t%10])
+
+		} else if t >= 20 {
+
+			words = append(words, tensWords[t/10])
+
+		} else if t > 0 {
+
+			words = append(words, smallWords[t])
+		}
+
+		if i > 0 {
+
+			words = append(words, groupWords[i])
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// CurrencyNames are the names of the units of a currency used when amounts
+// are written in words.
+type CurrencyNames struct {
+	Major       string // the major unit of one, such as "dollar"
+	MajorPlural string // the major unit of other amounts, such as "dollars"
+	Minor       string // the minor unit of one, such as "cent"
+	MinorPlural string // the minor unit of other amounts, such as "cents"
+}
+
+// English names of the units of the currencies known to LookupCurrency,
+// keyed by ISO 4217 code
+var englishCurrencyNames = map[string]CurrencyNames{
+	"USD": {"dollar", "dollars", "cent", "cents"},
+	"EUR": {"euro", "euros", "cent", "cents"},
+	"GBP": {"pound", "pounds", "penny", "pence"},
+	"JPY": {"yen", "yen", "", ""},
+	"CNY": {"yuan", "yuan", "fen", "fen"},
+	"AUD": {"dollar", "dollars", "cent", "cents"},
+	"CAD": {"dollar", "dollars", "cent", "cents"},
+	"CHF": {"franc", "francs", "centime", "centimes"},
+	"INR": {"rupee", "rupees", "paisa", "paise"},
+	"KRW": {"won", "won", "", ""},
+	"BRL": {"real", "reais", "centavo", "centavos"},
+	"MXN": {"peso", "pesos", "centavo", "centavos"},
+	"IDR": {"rupiah", "rupiah", "sen", "sen"},
+	"BHD": {"dinar", "dinars", "fils", "fils"},
+	"KWD": {"dinar", "dinars", "fils", "fils"},
+}
+
+// A MoneyWordsStyle describes how FormatMoneyWords writes amounts in words,
+// so that other languages and currency names can be plugged in.
+type MoneyWordsStyle struct {
+
+	// Words writes a whole number in words. If it is nil numbers are
+	// written in English in the short scale, as by FormatIntWords.
+	Words func(n uint64) string
+
+	// Names are the names of the units of currencies, keyed by ISO 4217
+	// code, used in place of the English names.
+	Names map[string]CurrencyNames
+
+	// Conjunction joins the major and minor units. If it is empty "and" is
+	// used.
+	Conjunction string
+}
+
+// EnglishMoneyWords writes amounts in English, as in "one thousand two
+// hundred dollars and thirty-four cents".
+var EnglishMoneyWords = MoneyWordsStyle{}
+
+// FormatMoneyWords writes an amount in words using EnglishMoneyWords. See
+// MoneyWordsStyle.FormatMoneyWords.
+func FormatMoneyWords(amount float64, currency string) (string, error) {
+
+	return EnglishMoneyWords.FormatMoneyWords(amount, currency)
+}
+
+// FormatMoneyWords writes an amount in the currency with the given ISO 4217
+// code entirely in words, for contracts and promissory notes. The amount
+// is rounded half up to the currency's minor unit, and the major and minor
+// units are each written in words and named, so 1200.34 in USD is "one
+// thousand two hundred dollars and thirty-four cents". The singular name
+// is used for exactly one unit. Units of which there are none are left
+// out, unless the amount is zero, which is written as "zero dollars". An
+// error is returned if the code is not known to LookupCurrency or has no
+// names, and an error wrapping ErrRange if the amount is negative, NaN,
+// infinite or too large to write in words.
+func (s MoneyWordsStyle) FormatMoneyWords(amount float64, currency string) (string, error) {
+
+	c, ok := LookupCurrency(currency)
+
+	if !ok {
+
+		return "", fmt.Errorf("decimals: unknown currency %q", currency)
+	}
+
+	names, ok := s.Names[c.Code]
+
+	if !ok {
+
+		if names, ok = englishCurrencyNames[c.Code]; !ok {
+
+			return "", fmt.Errorf("decimals: no names for currency %q", currency)
+		}
+	}
+
+	d, err := DecimalFromFloat(amount)
+
+	if err != nil || d.Sign() < 0 {
+
+		return "", &NumError{"FormatMoneyWords", strconv.FormatFloat(amount, 'g', -1, 64), ErrRange}
+	}
+
+	is, fs := d.Round(c.Exponent, HalfUp).parts(c.Exponent)
+	major, err := strconv.ParseUint(is, 10, 64)
+
+	if err != nil {
+
+		return "", &NumError{"FormatMoneyWords", strconv.FormatFloat(amount, 'g', -1, 64), ErrRange}
+	}
+
+	var minor uint64
+
+	if fs != "" {
+
+		minor, _ = strconv.ParseUint(fs, 10, 64)
+	}
+
+	if minor == 0 {
+
+		return s.unitWords(major, names.Major, names.MajorPlural), nil
+	}
+
+	if major == 0 {
+
+		return s.unitWords(minor, names.Minor, names.MinorPlural), nil
+	}
+
+	conjunction := s.Conjunction
+
+	if conjunction == "" {
+
+		conjunction = "and"
+	}
+
+	return s.unitWords(major, names.Major, names.MajorPlural) + " " + conjunction + " " +
+		s.unitWords(minor, names.Minor, names.MinorPlural), nil
+}
+
+// unitWords writes n in words followed by the singular or plural name of
+// its unit.
+func (s MoneyWordsStyle) unitWords(n uint64, singular, plural string) string {
+
+	var words string
+
+	if s.Words != nil {
+
+		words = s.Words(n)
+
+	} else {
+
+		words = intWords(n, false)
+	}
+
+	if n == 1 {
+
+		return words + " " + singular
+	}
+
+	return words + " " + plural
+}
@@ -0,0 +1,154 @@
+package decimals
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Test FormatIntWords
+func TestFormatIntWords(t *testing.T) {
+
+	inputs := []int64{
+		0,
+		7,
+		15,
+		40,
+		42,
+		100,
+		105,
+		1000,
+		1234,
+		-1000001,
+		math.MinInt64,
+	}
+
+	expected := []string{
+		"zero",
+		"seven",
+		"fifteen",
+		"forty",
+		"forty-two",
+		"one hundred",
+		"one hundred five",
+		"one thousand",
+		"one thousand two hundred thirty-four",
+		"minus one million one",
+		"minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion " +
+			"thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight",
+	}
+
+	for i, input := range inputs {
+
+		output := FormatIntWords(input)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing FormatIntWords(%d)", expected[i], output, input)
+		}
+	}
+}
+
+// Test intWords with British "and"
+func TestIntWordsHundredsAnd(t *testing.T) {
+
+	inputs := []uint64{
+		5,
+		105,
+		1005,
+		1100,
+		2340,
+	}
+
+	expected := []string{
+		"five",
+		"one hundred and five",
+		"one thousand and five",
+		"one thousand one hundred",
+		"two thousand three hundred and forty",
+	}
+
+	for i, input := range inputs {
+
+		output := intWords(input, true)
+
+		if output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q testing intWords(%d, true)", expected[i], output, input)
+		}
+	}
+}
+
+// Test FormatMoneyWords in each style and currency
+func TestFormatMoneyWords(t *testing.T) {
+
+	type moneyInput struct {
+		style    MoneyWordsStyle
+		amount   float64
+		currency string
+	}
+
+	var (
+		british = MoneyWordsStyle{
+			Words: func(n uint64) string { return intWords(n, true) },
+		}
+		spanish = MoneyWordsStyle{
+			Words: func(n uint64) string {
+
+				return []string{"cero", "un", "dos", "tres"}[n]
+			},
+			Names:       map[string]CurrencyNames{"EUR": {"euro", "euros", "céntimo", "céntimos"}},
+			Conjunction: "con",
+		}
+	)
+
+	inputs := []moneyInput{
+		{EnglishMoneyWords, 1200.34, "USD"},
+		{EnglishMoneyWords, 1.01, "usd"},
+		{EnglishMoneyWords, 0.5, "EUR"},
+		{EnglishMoneyWords, 0, "USD"},
+		{EnglishMoneyWords, 42.999, "GBP"},
+		{EnglishMoneyWords, 1500.4, "JPY"},
+		{EnglishMoneyWords, 2.005, "KWD"},
+		{british, 105.02, "GBP"},
+		{spanish, 3.02, "EUR"},
+		{spanish, 1, "USD"},
+	}
+
+	expected := []string{
+		"one thousand two hundred dollars and thirty-four cents",
+		"one dollar and one cent",
+		"fifty cents",
+		"zero dollars",
+		"forty-three pounds",
+		"one thousand five hundred yen",
+		"two dinars and five fils",
+		"one hundred and five pounds and two pence",
+		"tres euros con dos céntimos",
+		"un dollar",
+	}
+
+	for i, input := range inputs {
+
+		output, err := input.style.FormatMoneyWords(input.amount, input.currency)
+
+		if err != nil || output != expected[i] {
+
+			t.Errorf("Expected: %q but received: %q (%v) testing FormatMoneyWords(%v, %q)",
+				expected[i], output, err, input.amount, input.currency)
+		}
+	}
+
+	if _, err := FormatMoneyWords(1, "XYZ"); err == nil {
+
+		t.Errorf("Expected: error but received: nil testing FormatMoneyWords(1, \"XYZ\")")
+	}
+
+	for _, amount := range []float64{-1, math.NaN(), math.Inf(1), 1e20} {
+
+		if _, err := FormatMoneyWords(amount, "USD"); !errors.Is(err, ErrRange) {
+
+			t.Errorf("Expected: %v but received: %v testing FormatMoneyWords(%v, \"USD\")", ErrRange, err, amount)
+		}
+	}
+}